@@ -39,11 +39,15 @@ func (m *Mono) GetTransactions(token, account string, from, to time.Time) ([]mod
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return nil, errors.WithStack(err)
+		return nil, errors.Wrap(model.ErrUpstream, err.Error())
 	}
 
 	defer closeBody(resp.Body, m.log)
 
+	if resp.StatusCode != http.StatusOK {
+		return nil, responseErr(resp)
+	}
+
 	transactions := make([]model.Transaction, 0)
 	if err := json.NewDecoder(resp.Body).Decode(&transactions); err != nil {
 		return nil, errors.WithStack(err)
@@ -65,11 +69,15 @@ func (m Mono) GetClientInfo(token string) (c model.ClientInfo, err error) {
 
 	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
-		return c, errors.WithStack(err)
+		return c, errors.Wrap(model.ErrUpstream, err.Error())
 	}
 
 	defer closeBody(resp.Body, m.log)
 
+	if resp.StatusCode != http.StatusOK {
+		return c, responseErr(resp)
+	}
+
 	clientInfo := model.ClientInfo{}
 	if err := json.NewDecoder(resp.Body).Decode(&clientInfo); err != nil {
 		return c, errors.WithStack(err)
@@ -1,14 +1,48 @@
 package mono
 
-import "io"
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/pkg/errors"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
 
 // Logger - represents the application's logger interface.
 type Logger interface {
 	Errorf(template string, args ...interface{})
 }
 
+// apiError - represents MonoBank error response body.
+type apiError struct {
+	Description string `json:"errorDescription"`
+}
+
 func closeBody(c io.Closer, log Logger) {
 	if err := c.Close(); err != nil {
 		log.Errorf("%+v", err)
 	}
 }
+
+// responseErr - converts unsuccessful MonoBank response to error.
+func responseErr(resp *http.Response) error {
+	switch resp.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return model.ErrInvalidToken
+	case http.StatusTooManyRequests:
+		return model.ErrRateLimited
+	}
+
+	apiErr := apiError{}
+	if err := json.NewDecoder(resp.Body).Decode(&apiErr); err != nil {
+		return errors.Wrapf(model.ErrUpstream, "unexpected MonoBank response: status=%d", resp.StatusCode)
+	}
+
+	if resp.StatusCode == http.StatusBadRequest { // MonoBank explains the wrong parameters, e.g. too long period
+		return model.NewValidationError("MonoBank rejected the request: %s", apiErr.Description)
+	}
+
+	return errors.Wrapf(model.ErrUpstream, "unexpected MonoBank response: status=%d description=%s", resp.StatusCode, apiErr.Description)
+}
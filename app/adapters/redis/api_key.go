@@ -0,0 +1,62 @@
+package redis
+
+import (
+	"encoding/json"
+
+	"github.com/go-redis/redis"
+	"github.com/pkg/errors"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+// NewAPIKey - builds API key repository.
+func NewAPIKey(redisClient *redis.Client) *APIKey {
+	return &APIKey{redisClient: redisClient}
+}
+
+// APIKey - represents REST API keys repository.
+type APIKey struct {
+	redisClient *redis.Client
+}
+
+// Set - save API key by key in redis.
+func (a *APIKey) Set(key string, val model.APIKey) error {
+	apiKey, err := json.Marshal(val)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if err := a.redisClient.Set(key, string(apiKey), 0).Err(); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+// Get - return API key by key from redis.
+func (a *APIKey) Get(key string) (model.APIKey, error) {
+	val, err := a.redisClient.Get(key).Result()
+	if err == redis.Nil {
+		return model.APIKey{}, model.ErrNil
+	}
+
+	if err != nil {
+		return model.APIKey{}, errors.WithStack(err)
+	}
+
+	apiKey := model.APIKey{}
+	if err := json.Unmarshal([]byte(val), &apiKey); err != nil {
+		return model.APIKey{}, errors.WithStack(err)
+	}
+
+	return apiKey, nil
+}
+
+// Delete - remove API key by key from redis.
+func (a *APIKey) Delete(key string) error {
+	if err := a.redisClient.Del(key).Err(); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
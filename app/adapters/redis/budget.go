@@ -0,0 +1,114 @@
+package redis
+
+import (
+	"encoding/json"
+
+	"github.com/go-redis/redis"
+	"github.com/pkg/errors"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+// NewBudget - builds budget repository.
+func NewBudget(redisClient *redis.Client) *Budget {
+	return &Budget{redisClient: redisClient}
+}
+
+// Budget - represents category budgets repository, the budgets of a user are kept in a hash by category.
+type Budget struct {
+	redisClient *redis.Client
+}
+
+// Set - save the budget in the hash by key.
+func (b *Budget) Set(key string, val model.Budget) error {
+	budget, err := json.Marshal(val)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if err := b.redisClient.HSet(key, val.Category, string(budget)).Err(); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+// GetAll - return all the budgets of the hash by key.
+func (b *Budget) GetAll(key string) ([]model.Budget, error) {
+	vals, err := b.redisClient.HGetAll(key).Result()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	budgets := make([]model.Budget, 0, len(vals))
+	for _, val := range vals {
+		budget := model.Budget{}
+		if err := json.Unmarshal([]byte(val), &budget); err != nil {
+			return nil, errors.WithStack(err)
+		}
+		budgets = append(budgets, budget)
+	}
+
+	return budgets, nil
+}
+
+// Delete - remove the field of the hash by key, returns "model.ErrNil" if the field doesn't exist.
+func (b *Budget) Delete(key, field string) error {
+	n, err := b.redisClient.HDel(key, field).Result()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if n == 0 {
+		return model.ErrNil
+	}
+
+	return nil
+}
+
+// SetField - save the value in the field of the hash by key.
+func (b *Budget) SetField(key, field, val string) error {
+	if err := b.redisClient.HSet(key, field, val).Err(); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+// GetFields - return the fields of the hash by key.
+func (b *Budget) GetFields(key string) (map[string]string, error) {
+	vals, err := b.redisClient.HGetAll(key).Result()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return vals, nil
+}
+
+// AddMember - add the member to the set by key.
+func (b *Budget) AddMember(key, member string) error {
+	if err := b.redisClient.SAdd(key, member).Err(); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+// RemoveMember - remove the member from the set by key.
+func (b *Budget) RemoveMember(key, member string) error {
+	if err := b.redisClient.SRem(key, member).Err(); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+// Members - return the members of the set by key.
+func (b *Budget) Members(key string) ([]string, error) {
+	members, err := b.redisClient.SMembers(key).Result()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return members, nil
+}
@@ -0,0 +1,68 @@
+package redis
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/go-redis/redis"
+	"github.com/pkg/errors"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+// NewDialog - builds dialog repository.
+func NewDialog(redisClient *redis.Client) *Dialog {
+	return &Dialog{redisClient: redisClient}
+}
+
+// Dialog - represents the repository of the dialog states, they expire if the chat doesn't answer.
+type Dialog struct {
+	redisClient *redis.Client
+}
+
+// Set - save the dialog by key for "ttl".
+func (d *Dialog) Set(key string, val model.Dialog, ttl time.Duration) error {
+	dialog, err := json.Marshal(val)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if err := d.redisClient.Set(key, string(dialog), ttl).Err(); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+// Get - return the dialog by key, "model.ErrNil" if it doesn't exist or expired.
+func (d *Dialog) Get(key string) (model.Dialog, error) {
+	val, err := d.redisClient.Get(key).Result()
+	if err == redis.Nil {
+		return model.Dialog{}, model.ErrNil
+	}
+
+	if err != nil {
+		return model.Dialog{}, errors.WithStack(err)
+	}
+
+	dialog := model.Dialog{}
+	if err := json.Unmarshal([]byte(val), &dialog); err != nil {
+		return model.Dialog{}, errors.WithStack(err)
+	}
+
+	return dialog, nil
+}
+
+// Delete - remove the dialog by key, returns "model.ErrNil" if it doesn't exist.
+func (d *Dialog) Delete(key string) error {
+	n, err := d.redisClient.Del(key).Result()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if n == 0 {
+		return model.ErrNil
+	}
+
+	return nil
+}
@@ -0,0 +1,171 @@
+package redis
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis"
+	"github.com/pkg/errors"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+const historyTimeSuffix = "_time"
+
+// NewHistory - builds transaction history repository.
+func NewHistory(redisClient *redis.Client) *History {
+	return &History{redisClient: redisClient}
+}
+
+// History - represents transaction history repository, the transactions are kept in a hash by ID
+// and are indexed by time in a sorted set with the "_time" suffix.
+type History struct {
+	redisClient *redis.Client
+}
+
+// Replace - replace the transactions by key from "from" to "to" inclusive: the stored transactions of the period
+// missing in "transactions" are deleted, the transactions with the same ID are replaced.
+func (h *History) Replace(key string, from, to time.Time, transactions []model.Transaction) error {
+	ids, err := h.redisClient.ZRangeByScore(key+historyTimeSuffix, redis.ZRangeBy{
+		Min: strconv.FormatInt(from.Unix(), 10),
+		Max: strconv.FormatInt(to.Unix(), 10),
+	}).Result()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	fields := make(map[string]interface{}, len(transactions))
+	members := make([]redis.Z, 0, len(transactions))
+	for _, tr := range transactions {
+		val, err := json.Marshal(tr)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		fields[tr.ID] = string(val)
+		members = append(members, redis.Z{Score: float64(tr.Time), Member: tr.ID})
+	}
+
+	stale := make([]string, 0)
+	for _, id := range ids {
+		if _, ok := fields[id]; !ok {
+			stale = append(stale, id)
+		}
+	}
+
+	if len(stale) == 0 && len(transactions) == 0 {
+		return nil
+	}
+
+	_, err = h.redisClient.TxPipelined(func(pipe redis.Pipeliner) error {
+		if len(stale) > 0 {
+			pipe.HDel(key, stale...)
+			pipe.ZRem(key+historyTimeSuffix, toInterfaces(stale)...)
+		}
+
+		if len(transactions) > 0 {
+			pipe.HMSet(key, fields)
+			pipe.ZAdd(key+historyTimeSuffix, members...)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+// Range - return the transactions by key from "from" to "to" inclusive, ordered by time.
+func (h *History) Range(key string, from, to time.Time) ([]model.Transaction, error) {
+	ids, err := h.redisClient.ZRangeByScore(key+historyTimeSuffix, redis.ZRangeBy{
+		Min: strconv.FormatInt(from.Unix(), 10),
+		Max: strconv.FormatInt(to.Unix(), 10),
+	}).Result()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if len(ids) == 0 {
+		return []model.Transaction{}, nil
+	}
+
+	vals, err := h.redisClient.HMGet(key, ids...).Result()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	transactions := make([]model.Transaction, 0, len(vals))
+	for _, val := range vals {
+		s, ok := val.(string)
+		if !ok {
+			continue
+		}
+
+		tr := model.Transaction{}
+		if err := json.Unmarshal([]byte(s), &tr); err != nil {
+			return nil, errors.WithStack(err)
+		}
+		transactions = append(transactions, tr)
+	}
+
+	return transactions, nil
+}
+
+// SetTime - save the time in the field of the hash by key.
+func (h *History) SetTime(key, field string, t time.Time) error {
+	if err := h.redisClient.HSet(key, field, t.Format(time.RFC3339)).Err(); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+// GetTimes - return the times of the hash by key.
+func (h *History) GetTimes(key string) (map[string]time.Time, error) {
+	vals, err := h.redisClient.HGetAll(key).Result()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	times := make(map[string]time.Time, len(vals))
+	for field, val := range vals {
+		t, err := time.Parse(time.RFC3339, val)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		times[field] = t
+	}
+
+	return times, nil
+}
+
+// AddMember - add the member to the set by key.
+func (h *History) AddMember(key, member string) error {
+	if err := h.redisClient.SAdd(key, member).Err(); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+// Members - return the members of the set by key.
+func (h *History) Members(key string) ([]string, error) {
+	members, err := h.redisClient.SMembers(key).Result()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return members, nil
+}
+
+func toInterfaces(vals []string) []interface{} {
+	res := make([]interface{}, 0, len(vals))
+	for _, v := range vals {
+		res = append(res, v)
+	}
+
+	return res
+}
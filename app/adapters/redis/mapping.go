@@ -36,6 +36,10 @@ func (t *Mapping) Set(key string, val map[string]model.CategoryMapping) error {
 // Get - return category mapping for chat key from redis.
 func (t *Mapping) Get(key string) (map[string]model.CategoryMapping, error) {
 	val, err := t.redisClient.Get(key).Result()
+	if err == redis.Nil {
+		return nil, model.ErrNil
+	}
+
 	if err != nil {
 		return nil, errors.WithStack(err)
 	}
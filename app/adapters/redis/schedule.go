@@ -0,0 +1,124 @@
+package redis
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/go-redis/redis"
+	"github.com/pkg/errors"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+// NewSchedule - builds schedule repository.
+func NewSchedule(redisClient *redis.Client) *Schedule {
+	return &Schedule{redisClient: redisClient}
+}
+
+// Schedule - represents report schedules repository, the schedules of a user are kept in a hash by ID.
+type Schedule struct {
+	redisClient *redis.Client
+}
+
+// Set - save the schedule in the hash by key.
+func (s *Schedule) Set(key string, val model.Schedule) error {
+	schedule, err := json.Marshal(val)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if err := s.redisClient.HSet(key, val.ID, string(schedule)).Err(); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+// GetAll - return all the schedules of the hash by key.
+func (s *Schedule) GetAll(key string) ([]model.Schedule, error) {
+	vals, err := s.redisClient.HGetAll(key).Result()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	schedules := make([]model.Schedule, 0, len(vals))
+	for _, val := range vals {
+		schedule := model.Schedule{}
+		if err := json.Unmarshal([]byte(val), &schedule); err != nil {
+			return nil, errors.WithStack(err)
+		}
+		schedules = append(schedules, schedule)
+	}
+
+	return schedules, nil
+}
+
+// Delete - remove the field of the hash by key, returns "model.ErrNil" if the field doesn't exist.
+func (s *Schedule) Delete(key, id string) error {
+	n, err := s.redisClient.HDel(key, id).Result()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if n == 0 {
+		return model.ErrNil
+	}
+
+	return nil
+}
+
+// SetTime - save the time in the field of the hash by key.
+func (s *Schedule) SetTime(key, id string, t time.Time) error {
+	if err := s.redisClient.HSet(key, id, t.Format(time.RFC3339)).Err(); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+// GetTimes - return the times of the hash by key.
+func (s *Schedule) GetTimes(key string) (map[string]time.Time, error) {
+	vals, err := s.redisClient.HGetAll(key).Result()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	times := make(map[string]time.Time, len(vals))
+	for id, val := range vals {
+		t, err := time.Parse(time.RFC3339, val)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		times[id] = t
+	}
+
+	return times, nil
+}
+
+// AddMember - add the member to the set by key.
+func (s *Schedule) AddMember(key, member string) error {
+	if err := s.redisClient.SAdd(key, member).Err(); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+// RemoveMember - remove the member from the set by key.
+func (s *Schedule) RemoveMember(key, member string) error {
+	if err := s.redisClient.SRem(key, member).Err(); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+// Members - return the members of the set by key.
+func (s *Schedule) Members(key string) ([]string, error) {
+	members, err := s.redisClient.SMembers(key).Result()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return members, nil
+}
@@ -0,0 +1,51 @@
+package redis
+
+import (
+	"github.com/go-redis/redis"
+	"github.com/pkg/errors"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+// NewSubscription - builds subscriptions repository.
+func NewSubscription(redisClient *redis.Client) *Subscription {
+	return &Subscription{redisClient: redisClient}
+}
+
+// Subscription - represents subscriptions repository keeping the warning chats and the sent warnings in hashes.
+type Subscription struct {
+	redisClient *redis.Client
+}
+
+// SetField - save the value in the field of the hash by key.
+func (s *Subscription) SetField(key, field, val string) error {
+	if err := s.redisClient.HSet(key, field, val).Err(); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+// GetFields - return the fields of the hash by key.
+func (s *Subscription) GetFields(key string) (map[string]string, error) {
+	vals, err := s.redisClient.HGetAll(key).Result()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return vals, nil
+}
+
+// Delete - remove the field of the hash by key, returns "model.ErrNil" if the field doesn't exist.
+func (s *Subscription) Delete(key, field string) error {
+	n, err := s.redisClient.HDel(key, field).Result()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	if n == 0 {
+		return model.ErrNil
+	}
+
+	return nil
+}
@@ -0,0 +1,26 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// API key scopes.
+const (
+	ScopeReportsRead   = "reports:read"   // read-only access to reports and settings
+	ScopeAccountManage = "account:manage" // full access, includes ScopeReportsRead
+)
+
+// APIKey - represents REST API key issued to the user, the key itself is never stored, only its hash.
+type APIKey struct {
+	UserID    uuid.UUID `json:"userId"`
+	Hash      string    `json:"hash"`
+	Scope     string    `json:"scope"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Allows - returns true if the key scope grants the given scope.
+func (a APIKey) Allows(scope string) bool {
+	return a.Scope == scope || a.Scope == ScopeAccountManage
+}
@@ -0,0 +1,52 @@
+package model
+
+import "github.com/google/uuid"
+
+// Budget alert thresholds, in percent of the limit.
+const (
+	BudgetWarningThreshold  = 80
+	BudgetExceededThreshold = 100
+)
+
+// Budget - represents monthly budget of the application's category (CategoryMapping.App),
+// the limit is in minor units (cents), the alerts are sent to the chat.
+type Budget struct {
+	Category string
+	Limit    int64
+	ChatID   int64
+}
+
+// BudgetStatus - represents spending of the current month against the budget.
+type BudgetStatus struct {
+	Budget
+	Spent int64
+}
+
+// Percent - returns the spent part of the limit in percent.
+func (s BudgetStatus) Percent() int64 {
+	if s.Limit <= 0 {
+		return 0
+	}
+
+	return s.Spent * 100 / s.Limit
+}
+
+// Threshold - returns the highest alert threshold reached, 0 if none.
+func (s BudgetStatus) Threshold() int {
+	switch percent := s.Percent(); {
+	case percent >= BudgetExceededThreshold:
+		return BudgetExceededThreshold
+	case percent >= BudgetWarningThreshold:
+		return BudgetWarningThreshold
+	default:
+		return 0
+	}
+}
+
+// BudgetAlert - represents the alert of the budget threshold reached in the month, e.g. "2020-03".
+type BudgetAlert struct {
+	UserID    uuid.UUID
+	Month     string
+	Threshold int
+	Status    BudgetStatus
+}
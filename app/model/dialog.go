@@ -0,0 +1,9 @@
+package model
+
+// Dialog - represents the state of a multi-step dialog of a chat, e.g. "/export": the flow, the current step
+// and the answers to the previous steps.
+type Dialog struct {
+	Flow string            `json:"flow"`
+	Step string            `json:"step"`
+	Data map[string]string `json:"data,omitempty"`
+}
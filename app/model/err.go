@@ -1,6 +1,36 @@
 package model
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 // ErrNil - represent empty result.
 var ErrNil = errors.New("empty result") //nolint:gochecknoglobals
+
+// ErrInvalidToken - represents a token rejected by MonoBank.
+var ErrInvalidToken = errors.New("invalid token") //nolint:gochecknoglobals
+
+// ErrRateLimited - represents a request rejected by MonoBank because of the rate limit.
+var ErrRateLimited = errors.New("MonoBank rate limit exceeded") //nolint:gochecknoglobals
+
+// ErrUpstream - represents MonoBank API failure.
+var ErrUpstream = errors.New("MonoBank API is unavailable") //nolint:gochecknoglobals
+
+// ValidationError - represents invalid user input, the message is safe to show to the user.
+// The format and the arguments of the message let the presentation layer translate it.
+type ValidationError struct {
+	Msg    string
+	Format string
+	Args   []interface{}
+}
+
+// NewValidationError - builds ValidationError.
+func NewValidationError(format string, args ...interface{}) error {
+	return ValidationError{Msg: fmt.Sprintf(format, args...), Format: format, Args: args}
+}
+
+// Error - returns the error message.
+func (e ValidationError) Error() string {
+	return e.Msg
+}
@@ -0,0 +1,11 @@
+package model
+
+import "time"
+
+// HistoryState - represents the period of the account's transactions kept in the history,
+// the history is complete when it covers the whole history depth.
+type HistoryState struct {
+	From     time.Time
+	To       time.Time
+	Complete bool
+}
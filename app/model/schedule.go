@@ -0,0 +1,97 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jinzhu/now"
+)
+
+// Schedule periods.
+const (
+	PeriodDaily   = "daily"
+	PeriodWeekly  = "weekly"
+	PeriodMonthly = "monthly"
+)
+
+// Report formats.
+const (
+	ReportFormatCSV     = "csv"
+	ReportFormatXLSX    = "xlsx"
+	ReportFormatSummary = "summary" // in-chat spending summary
+)
+
+// maxScheduleLookahead - max number of days between two runs of a schedule.
+const maxScheduleLookahead = 62
+
+// Schedule - represents the report delivered to the chat regularly.
+type Schedule struct {
+	ID        string    `json:"id"`
+	ChatID    int64     `json:"chatId"`
+	Period    string    `json:"period"`
+	Day       int       `json:"day"` // weekday for PeriodWeekly (0 - Sunday), day of month for PeriodMonthly
+	Hour      int       `json:"hour"`
+	Minute    int       `json:"minute"`
+	Format    string    `json:"format"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Next - returns the first run time after "after", the time is calculated in the location of "after".
+// A monthly schedule with a day missing in the month runs on the last day of the month.
+func (s Schedule) Next(after time.Time) time.Time {
+	year, month, day := after.Date()
+	for i := 0; i <= maxScheduleLookahead; i++ {
+		run := time.Date(year, month, day+i, s.Hour, s.Minute, 0, 0, after.Location())
+		if run.After(after) && s.matches(run) {
+			return run
+		}
+	}
+
+	return time.Time{}
+}
+
+// Range - returns the report period of the run: the calendar day, week (from Monday) or month
+// before the one of the run time.
+func (s Schedule) Range(run time.Time) (from, to time.Time) {
+	switch s.Period {
+	case PeriodWeekly:
+		from = now.New(run).Monday().AddDate(0, 0, -7)
+
+		return from, now.New(from.AddDate(0, 0, 6)).EndOfDay()
+	case PeriodMonthly:
+		from = now.New(run).BeginningOfMonth().AddDate(0, -1, 0)
+
+		return from, now.New(from).EndOfMonth()
+	default:
+		from = now.New(run).BeginningOfDay().AddDate(0, 0, -1)
+
+		return from, now.New(from).EndOfDay()
+	}
+}
+
+func (s Schedule) matches(run time.Time) bool {
+	switch s.Period {
+	case PeriodWeekly:
+		return int(run.Weekday()) == s.Day
+	case PeriodMonthly:
+		lastDay := lastDayOfMonth(run.Year(), run.Month(), run.Location())
+		if s.Day > lastDay {
+			return run.Day() == lastDay
+		}
+
+		return run.Day() == s.Day
+	default:
+		return true
+	}
+}
+
+func lastDayOfMonth(year int, month time.Month, loc *time.Location) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, loc).Day()
+}
+
+// ScheduledRun - represents the due run of the user's schedule.
+type ScheduledRun struct {
+	UserID   uuid.UUID
+	Schedule Schedule
+	At       time.Time
+}
@@ -0,0 +1,31 @@
+package model
+
+import "time"
+
+// SearchQuery - represents the transaction search: the words of the text are matched against the description,
+// comment, counterparty name and mapped category ignoring case. The amounts are absolute values in minor units,
+// zero "Max" means no upper bound.
+type SearchQuery struct {
+	Text string
+	From time.Time
+	To   time.Time
+	Min  int64
+	Max  int64
+}
+
+// FoundTransaction - represents a transaction matching the search.
+type FoundTransaction struct {
+	Time        time.Time
+	Description string
+	Comment     string
+	CounterName string
+	Category    string
+	Mcc         int
+	Amount      int64
+}
+
+// SearchResult - represents the transactions matching the query, the latest first.
+type SearchResult struct {
+	SearchQuery
+	Transactions []FoundTransaction
+}
@@ -0,0 +1,92 @@
+package model
+
+import (
+	"sort"
+	"strings"
+)
+
+// DefaultLocale - the locale of the users who didn't choose one.
+const DefaultLocale = "uk"
+
+// Languages of the bot messages.
+const (
+	LanguageUK = "uk"
+	LanguageEN = "en"
+	// DefaultLanguage - the language of the users who didn't choose one and whose Telegram language is unknown.
+	DefaultLanguage = LanguageUK
+)
+
+// Locale - represents the date formats of a language and region, the layouts are of the "time" package.
+type Locale struct {
+	Tag            string // BCP 47 language tag, e.g. "en-US"
+	Name           string
+	Date           string // e.g. "02.01.2006"
+	DateTime       string // e.g. "02.01.2006 15:04"
+	DayTime        string // the date without the year, e.g. "02.01 15:04"
+	ReportDateTime string // the dates of the CSV report, e.g. "02.01.2006 15:04:05"
+}
+
+// Settings - represents the user's time zone (IANA name, e.g. "Europe/Warsaw") and locale tag.
+type Settings struct {
+	Timezone string
+	Locale   string
+}
+
+//nolint:gochecknoglobals
+var locales = map[string]Locale{
+	"uk": {
+		Tag: "uk", Name: "Українська",
+		Date: "02.01.2006", DateTime: "02.01.2006 15:04", DayTime: "02.01 15:04", ReportDateTime: "02.01.2006 15:04:05",
+	},
+	"en-GB": {
+		Tag: "en-GB", Name: "English (UK)",
+		Date: "02/01/2006", DateTime: "02/01/2006 15:04", DayTime: "02/01 15:04", ReportDateTime: "02/01/2006 15:04:05",
+	},
+	"en-US": {
+		Tag: "en-US", Name: "English (US)",
+		Date: "01/02/2006", DateTime: "01/02/2006 3:04 PM", DayTime: "01/02 3:04 PM",
+		ReportDateTime: "01/02/2006 3:04:05 PM",
+	},
+	"de": {
+		Tag: "de", Name: "Deutsch",
+		Date: "02.01.2006", DateTime: "02.01.2006 15:04", DayTime: "02.01. 15:04", ReportDateTime: "02.01.2006 15:04:05",
+	},
+	"pl": {
+		Tag: "pl", Name: "Polski",
+		Date: "02.01.2006", DateTime: "02.01.2006 15:04", DayTime: "02.01 15:04", ReportDateTime: "02.01.2006 15:04:05",
+	},
+}
+
+// FindLanguage - returns the supported language of the IETF language tag ignoring case and region,
+// e.g. "uk-UA" is "uk".
+func FindLanguage(tag string) (string, bool) {
+	lang := strings.ToLower(strings.SplitN(strings.TrimSpace(tag), "-", 2)[0])
+	switch lang {
+	case LanguageUK, LanguageEN:
+		return lang, true
+	default:
+		return "", false
+	}
+}
+
+// FindLocale - returns the supported locale of the tag ignoring case.
+func FindLocale(tag string) (Locale, bool) {
+	for t, l := range locales {
+		if strings.EqualFold(t, tag) {
+			return l, true
+		}
+	}
+
+	return Locale{}, false
+}
+
+// Locales - returns the supported locales ordered by tag.
+func Locales() []Locale {
+	list := make([]Locale, 0, len(locales))
+	for _, l := range locales {
+		list = append(list, l)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Tag < list[j].Tag })
+
+	return list
+}
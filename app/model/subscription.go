@@ -0,0 +1,38 @@
+package model
+
+import "time"
+
+// Subscription periods.
+const (
+	SubscriptionWeekly  = "weekly"
+	SubscriptionMonthly = "monthly"
+)
+
+// Subscription warning kinds.
+const (
+	WarningPriceChanged = "price"
+	WarningMissed       = "missed"
+)
+
+// Subscription - represents a recurring charge detected in the transaction history,
+// the amounts are in minor units (cents) and are positive.
+type Subscription struct {
+	Key            string // normalized description and MCC identifying the charges
+	Description    string
+	Mcc            int
+	Period         string
+	Amount         int64 // the last charge
+	PreviousAmount int64 // the charge before the last one
+	MonthlyCost    int64
+	Charges        int
+	LastCharge     time.Time
+	NextCharge     time.Time
+	PriceChanged   bool // the last charge differs from the previous one
+	Missed         bool // the expected charge didn't happen
+}
+
+// SubscriptionWarning - represents the warning about the subscription's price change or missed charge.
+type SubscriptionWarning struct {
+	Kind         string
+	Subscription Subscription
+}
@@ -0,0 +1,60 @@
+package model
+
+import "time"
+
+// Totals - represents income and expenses of the period, amounts are in minor units (cents),
+// expenses are positive.
+type Totals struct {
+	From     time.Time
+	To       time.Time
+	Income   int64
+	Expenses int64
+}
+
+// Net - returns income minus expenses.
+func (t Totals) Net() int64 {
+	return t.Income - t.Expenses
+}
+
+// CategoryTotal - represents expenses of the category and their share in all the expenses of the period.
+type CategoryTotal struct {
+	Category string
+	Amount   int64
+	Share    float64
+}
+
+// Purchase - represents a single expense transaction.
+type Purchase struct {
+	Time        time.Time
+	Description string
+	Category    string
+	Amount      int64
+}
+
+// Summary - represents spending summary of the period.
+type Summary struct {
+	Totals
+	TopCategories    []CategoryTotal
+	BiggestPurchases []Purchase
+	Previous         *Totals // the previous period of the same length, nil if it isn't available
+}
+
+// DayTotal - represents expenses of the day.
+type DayTotal struct {
+	Day    time.Time
+	Amount int64
+}
+
+// ChartLabels - represents the text of the chart in the user's language.
+type ChartLabels struct {
+	Expenses   string // the title, it's followed by the period and the expenses
+	NoExpenses string // the text instead of the charts if there are no expenses in the period
+	Other      string // the pie slice joining the smallest categories
+}
+
+// Chart - represents spending of the period by category and by day, used to draw the chart.
+type Chart struct {
+	Totals
+	Categories []CategoryTotal // all the categories, sorted by amount descending
+	Days       []DayTotal      // every day of the period, including the days without expenses
+}
@@ -0,0 +1,9 @@
+package model
+
+import "time"
+
+// TokenStatus - represents the state of the stored MonoBank token.
+type TokenStatus struct {
+	Masked     string
+	VerifiedAt time.Time
+}
@@ -5,6 +5,8 @@ type Transaction struct {
 	ID              string `json:"id"`
 	Time            int    `json:"time"`
 	Description     string `json:"description"`
+	Comment         string `json:"comment,omitempty"`
+	CounterName     string `json:"counterName,omitempty"`
 	Mcc             int    `json:"mcc"`
 	Hold            bool   `json:"hold"`
 	Amount          int    `json:"amount"`
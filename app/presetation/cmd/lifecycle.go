@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Logger - represents the application's logger interface.
+type Logger interface {
+	Infof(template string, args ...interface{})
+	Errorf(template string, args ...interface{})
+}
+
+// component - a part of the application managed by lifecycle.
+type component struct {
+	name string
+	// run blocks until the component is stopped, it may be nil if the component has nothing to run.
+	run func() error
+	// stop stops the component and waits until it finishes its work or ctx is done.
+	stop func(ctx context.Context) error
+}
+
+// newLifecycle - builds lifecycle, "timeout" limits the time of stopping all the components.
+func newLifecycle(log Logger, timeout time.Duration) *lifecycle {
+	return &lifecycle{log: log, timeout: timeout}
+}
+
+// lifecycle - runs the components until SIGINT, SIGTERM or the first component returning,
+// then stops them in the reverse order.
+type lifecycle struct {
+	log        Logger
+	timeout    time.Duration
+	components []component
+}
+
+// add - registers the component, the components are stopped in the reverse order of adding.
+func (l *lifecycle) add(c component) {
+	l.components = append(l.components, c)
+}
+
+// run - starts the components and blocks until the application is stopped,
+// returns the error of the component that caused the stop.
+func (l *lifecycle) run() error {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	done := make(chan error, len(l.components))
+	for _, c := range l.components {
+		if c.run == nil {
+			continue
+		}
+
+		go func(c component) {
+			if err := c.run(); err != nil {
+				done <- errors.Wrapf(err, "%s failed", c.name)
+
+				return
+			}
+			done <- errors.Errorf("%s stopped unexpectedly", c.name)
+		}(c)
+	}
+
+	var err error
+	select {
+	case sig := <-signals:
+		l.log.Infof("shutting down: signal=%s", sig)
+	case err = <-done:
+		l.log.Errorf("shutting down: err=%v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), l.timeout)
+	defer cancel()
+
+	for i := len(l.components) - 1; i >= 0; i-- {
+		c := l.components[i]
+		if c.stop == nil {
+			continue
+		}
+
+		if stopErr := c.stop(ctx); stopErr != nil {
+			l.log.Errorf("can't stop %s: err=%+v", c.name, stopErr)
+		}
+	}
+
+	return err
+}
@@ -16,19 +16,24 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	tg "github.com/go-telegram-bot-api/telegram-bot-api"
 	"github.com/pkg/errors"
 	"github.com/urfave/cli"
+	"go.uber.org/zap"
 
+	hr "github.com/Kalachevskyi/mono-chat/app/presetation/rest"
 	h "github.com/Kalachevskyi/mono-chat/app/presetation/telegram"
+	uc "github.com/Kalachevskyi/mono-chat/app/usecases"
 	"github.com/Kalachevskyi/mono-chat/config"
 	"github.com/Kalachevskyi/mono-chat/di"
 )
 
-// TimeLocation - application location.
+// TimeLocation - the time zone of the users who didn't set one by "/timezone" or PUT /settings.
 const timeLocation = "Europe/Kiev"
 
 // RootCMD - represents the main command for starting the application.
@@ -85,6 +90,39 @@ func (r *RootCMD) Init() *cli.App {
 			Destination: &r.conf.HTTPPort,
 			EnvVar:      "HTTP_PORT",
 		},
+		cli.IntFlag{
+			Name:        "workers",
+			Usage:       "Number of goroutines processing Telegram updates, updates of a chat are processed in order",
+			Destination: &r.conf.Workers,
+			Value:       4,
+			EnvVar:      "WORKERS",
+		},
+		cli.IntFlag{
+			Name:        "queue_size",
+			Usage:       "Max number of queued Telegram updates per worker",
+			Destination: &r.conf.QueueSize,
+			Value:       100,
+			EnvVar:      "QUEUE_SIZE",
+		},
+		cli.StringFlag{
+			Name:        "webhook_url",
+			Usage:       "Public URL of the HTTP service, if it is set Telegram updates are received by the webhook",
+			Destination: &r.conf.WebhookURL,
+			EnvVar:      "WEBHOOK_URL",
+		},
+		cli.StringFlag{
+			Name:        "webhook_secret",
+			Usage:       "Secret token of the webhook requests, characters A-Z, a-z, 0-9, _ and -",
+			Destination: &r.conf.WebhookSecret,
+			EnvVar:      "WEBHOOK_SECRET",
+		},
+		cli.DurationFlag{
+			Name:        "shutdown_timeout",
+			Usage:       "Time to finish the processed updates and HTTP requests on SIGINT or SIGTERM",
+			Destination: &r.conf.ShutdownTimeout,
+			Value:       30 * time.Second,
+			EnvVar:      "SHUTDOWN_TIMEOUT",
+		},
 	}
 
 	return cmd
@@ -101,13 +139,6 @@ func (r *RootCMD) serve(c *cli.Context) error {
 	}
 
 	bot.Debug = r.conf.Debug
-	u := tg.NewUpdate(r.conf.Offset)
-	u.Timeout = r.conf.Timeout
-
-	up, err := bot.GetUpdatesChan(u)
-	if err != nil {
-		return fmt.Errorf("can't get updates: %v", err.Error())
-	}
 
 	log, err := di.Logger(r.conf.Debug, r.conf.EncodingLog)
 	if err != nil {
@@ -133,12 +164,130 @@ func (r *RootCMD) serve(c *cli.Context) error {
 		h.ClientInfoHandler:   di.InjectClientInfo(toolsWrapper),
 		h.AccountHandler:      di.InjectAccount(toolsWrapper),
 		h.ChatUserHandler:     di.InjectUserChat(toolsWrapper),
+		h.APIKeyHandler:       di.InjectAPIKey(toolsWrapper),
+		h.ScheduleHandler:     di.InjectSchedule(toolsWrapper),
+		h.SummaryHandler:      di.InjectSummary(toolsWrapper),
+		h.ChartHandler:        di.InjectChart(toolsWrapper),
+		h.BudgetHandler:       di.InjectBudget(toolsWrapper),
+		h.SubscriptionHandler: di.InjectSubscription(toolsWrapper),
+		h.SearchHandler:       di.InjectSearch(toolsWrapper),
+		h.SettingsHandler:     di.InjectSettings(toolsWrapper),
+		h.InlineQueryHandler:  di.InjectInlineQuery(toolsWrapper),
+		h.HelpHandler:         di.InjectHelp(toolsWrapper),
+		h.StartHandler:        di.InjectStart(toolsWrapper),
+		h.ExportHandler:       di.InjectExport(toolsWrapper),
+		h.DialogHandler:       di.InjectDialog(toolsWrapper),
+	}
+
+	offsetUC := di.InjectOffset(toolsWrapper)
+	httpService := di.InjectHTTPService(toolsWrapper, r.conf.HTTPPort)
+	lc := newLifecycle(log, r.conf.ShutdownTimeout)
+	lc.add(component{name: "redis client", stop: func(context.Context) error { return rClient.Close() }})
+
+	up, receiver, err := r.updates(bot, httpService, offsetUC, log)
+	if err != nil {
+		return err
+	}
+
+	router := di.InjectRouter(toolsWrapper, handlers)
+	chat := h.NewChat(up, router, di.InjectBotWrapper(toolsWrapper), offsetUC, r.conf.Workers, r.conf.QueueSize)
+	if err := chat.RegisterCommands(); err != nil {
+		log.Errorf("can't register the command menu: err=%+v", err)
+	}
+
+	// The components are stopped in the reverse order: the HTTP service receiving the webhook updates
+	// and the poller are stopped before the chat dispatches the updates left in the channel.
+	lc.add(runUntilStopped("chat", func(ctx context.Context) error {
+		chat.Handle(ctx)
+
+		return nil
+	}))
+
+	lc.add(runUntilStopped("scheduler", di.InjectScheduler(toolsWrapper).Run))
+	lc.add(runUntilStopped("budget watcher", di.InjectBudgetWatcher(toolsWrapper).Run))
+	lc.add(runUntilStopped("subscription watcher", di.InjectSubscriptionWatcher(toolsWrapper).Run))
+	lc.add(runUntilStopped("history sync", di.InjectHistorySync(toolsWrapper).Run))
+
+	if receiver != nil {
+		lc.add(*receiver)
 	}
 
+	lc.add(component{name: "HTTP service", run: httpService.Start, stop: httpService.Shutdown})
+
 	fmt.Println("mono_chat_bot is running")
 
-	go h.NewChat(up, handlers, h.NewBotWrapper(bot, log)).Handle()
-	httpService := di.InjectHTTPService(toolsWrapper, r.conf.HTTPPort)
+	return lc.run()
+}
+
+// updates - returns Telegram updates channel, the updates come from the webhook served by
+// the HTTP service if "webhook_url" is set, otherwise they are received by long polling,
+// the poller is returned as the component to run.
+func (r *RootCMD) updates(bot *tg.BotAPI, httpService *hr.Service, offsetUC *uc.Offset,
+	log *zap.SugaredLogger) (tg.UpdatesChannel, *component, error) {
+	if r.conf.WebhookURL != "" {
+		webhook := hr.NewWebhook(log, r.conf.WebhookSecret)
+		httpService.HandleWebhook(webhook)
+
+		link := strings.TrimSuffix(r.conf.WebhookURL, "/") + hr.WebhookPath
+		if err := h.SetWebhook(bot, link, r.conf.WebhookSecret); err != nil {
+			return nil, nil, err
+		}
+
+		return webhook.Updates(), nil, nil
+	}
+
+	if err := h.RemoveWebhook(bot); err != nil {
+		return nil, nil, err
+	}
+
+	offset := r.conf.Offset
+	if offset == 0 {
+		saved, err := offsetUC.Get()
+		if err != nil {
+			return nil, nil, err
+		}
+		offset = saved
+	}
 
-	return httpService.Start()
+	// The request in progress isn't waited for on stop, its updates aren't confirmed to Telegram
+	// and will be received again after the restart.
+	poller := h.NewPoller(bot, log, offset, r.conf.Timeout)
+	ctx, cancel := context.WithCancel(context.Background())
+	receiver := component{
+		name: "Telegram poller",
+		run:  func() error { return poller.Run(ctx) },
+		stop: func(context.Context) error {
+			cancel()
+
+			return nil
+		},
+	}
+
+	return poller.Updates(), &receiver, nil
+}
+
+// runUntilStopped - builds the component running until the context passed to "run" is canceled by stop,
+// stop waits for "run" to return.
+func runUntilStopped(name string, run func(ctx context.Context) error) component {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	return component{
+		name: name,
+		run: func() error {
+			defer close(done)
+
+			return run(ctx)
+		},
+		stop: func(stopCtx context.Context) error {
+			cancel()
+
+			select {
+			case <-done:
+				return nil
+			case <-stopCtx.Done():
+				return stopCtx.Err()
+			}
+		},
+	}
 }
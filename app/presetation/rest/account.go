@@ -0,0 +1,95 @@
+package rest
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+// accountRequest - represents the body of the account update.
+type accountRequest struct {
+	Account string `json:"account"`
+}
+
+// NewAccount constructor for Account.
+func NewAccount(log Logger, accountUC AccountUC, tokenUC TokenUC, clientInfoUC ClientInfoUC) *Account {
+	return &Account{
+		log:          log,
+		accountUC:    accountUC,
+		tokenUC:      tokenUC,
+		clientInfoUC: clientInfoUC,
+	}
+}
+
+// Account represents account REST handler.
+type Account struct {
+	log          Logger
+	accountUC    AccountUC
+	tokenUC      TokenUC
+	clientInfoUC ClientInfoUC
+}
+
+// GetAccounts returns client info with MonoBank accounts.
+func (a Account) GetAccounts(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDFromContext(r.Context())
+	if !ok {
+		sendProblem(w, r, a.log, codeUnauthorized, "")
+
+		return
+	}
+
+	token, err := a.tokenUC.Get(userID)
+	if err == model.ErrNil {
+		sendProblem(w, r, a.log, codeTokenNotSet, "set the token with PUT /token")
+
+		return
+	}
+
+	if err != nil {
+		sendErr(w, r, a.log, err)
+
+		return
+	}
+
+	clientInfo, err := a.clientInfoUC.GetClientInfo(token)
+	if err != nil {
+		sendErr(w, r, a.log, err)
+
+		return
+	}
+
+	sendJSON(w, a.log, http.StatusOK, clientInfo)
+}
+
+// Put sets MonoBank account used for the reports.
+func (a Account) Put(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDFromContext(r.Context())
+	if !ok {
+		sendProblem(w, r, a.log, codeUnauthorized, "")
+
+		return
+	}
+
+	req := accountRequest{}
+	if err := decodeJSON(r, &req); err != nil {
+		sendProblem(w, r, a.log, codeInvalidRequest, "can't decode body")
+
+		return
+	}
+
+	req.Account = strings.TrimSpace(req.Account)
+	if req.Account == "" {
+		sendProblem(w, r, a.log, codeInvalidRequest, "account can't be empty")
+
+		return
+	}
+
+	if err := a.accountUC.Set(userID, req.Account); err != nil {
+		sendErr(w, r, a.log, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,118 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+const (
+	bearerPrefix    = "Bearer "
+	signatureHeader = "X-Signature"
+	timestampHeader = "X-Timestamp"
+)
+
+type contextKey int
+
+// Context keys.
+const (
+	userIDContextKey contextKey = iota
+	correlationIDContextKey
+)
+
+// APIKeyUC - represents a use-case interface for checking REST API keys.
+type APIKeyUC interface {
+	Authenticate(key string) (model.APIKey, error)
+	VerifySignature(key, timestamp, signature string, payload []byte) error
+}
+
+// NewAuth - constructor for Auth middleware.
+func NewAuth(log Logger, apiKeyUC APIKeyUC) *Auth {
+	return &Auth{log: log, apiKeyUC: apiKeyUC}
+}
+
+// Auth - represents API key authentication middleware.
+type Auth struct {
+	log      Logger
+	apiKeyUC APIKeyUC
+}
+
+// Require - returns middleware that accepts requests with "Authorization: Bearer <key>" header,
+// the key must have the given scope. If the "X-Signature" header is present, the request must be
+// signed: hex(HMAC-SHA256(key, method + "\n" + request URI + "\n" + X-Timestamp + "\n" + hex(SHA256(body)))).
+func (a *Auth) Require(scope string) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authorization := r.Header.Get(authorizationHeader)
+			if !strings.HasPrefix(authorization, bearerPrefix) {
+				sendProblem(w, r, a.log, codeUnauthorized, "Authorization header must contain a Bearer API key.")
+
+				return
+			}
+
+			key := strings.TrimPrefix(authorization, bearerPrefix)
+			apiKey, err := a.apiKeyUC.Authenticate(key)
+			if err == model.ErrNil {
+				sendProblem(w, r, a.log, codeUserNotFound, "")
+
+				return
+			}
+
+			if err != nil {
+				sendErr(w, r, a.log, err)
+
+				return
+			}
+
+			if !apiKey.Allows(scope) {
+				sendProblem(w, r, a.log, codeForbidden, fmt.Sprintf("the request requires %q scope", scope))
+
+				return
+			}
+
+			if signature := r.Header.Get(signatureHeader); signature != "" {
+				if err := a.verifySignature(r, key, signature); err != nil {
+					a.log.Errorf("can't verify request signature: correlation_id=%s err=%s",
+						correlationIDFromContext(r.Context()), err)
+					sendProblem(w, r, a.log, codeInvalidSignature, err.Error())
+
+					return
+				}
+			}
+
+			ctx := context.WithValue(r.Context(), userIDContextKey, apiKey.UserID)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func (a *Auth) verifySignature(r *http.Request, key, signature string) error {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return err
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	timestamp := r.Header.Get(timestampHeader)
+	bodyHash := sha256.Sum256(body)
+	payload := fmt.Sprintf("%s\n%s\n%s\n%s", r.Method, r.URL.RequestURI(), timestamp, hex.EncodeToString(bodyHash[:]))
+
+	return a.apiKeyUC.VerifySignature(key, timestamp, signature, []byte(payload))
+}
+
+// userIDFromContext - returns user ID set by Auth middleware.
+func userIDFromContext(ctx context.Context) (uuid.UUID, bool) {
+	userID, ok := ctx.Value(userIDContextKey).(uuid.UUID)
+
+	return userID, ok
+}
@@ -0,0 +1,62 @@
+package rest
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/jinzhu/now"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+// chartLabels - the text of the charts, the REST API is in English.
+var chartLabels = model.ChartLabels{ //nolint:gochecknoglobals
+	Expenses:   "Expenses",
+	NoExpenses: "There are no expenses in the period.",
+	Other:      "Other",
+}
+
+// GetCurrentMonthChart returns the chart of the current month expenses.
+func (t Transaction) GetCurrentMonthChart(w http.ResponseWriter, r *http.Request) {
+	timeNow := now.New(time.Now().In(t.location(r)))
+	t.handleChart(w, r, timeNow.BeginningOfMonth(), timeNow.EndOfMonth())
+}
+
+// GetCurrentDayChart returns the chart of the current day expenses.
+func (t Transaction) GetCurrentDayChart(w http.ResponseWriter, r *http.Request) {
+	timeNow := now.New(time.Now().In(t.location(r)))
+	t.handleChart(w, r, timeNow.BeginningOfDay(), timeNow.EndOfDay())
+}
+
+// GetChart returns the chart of expenses by date range from, to.
+func (t Transaction) GetChart(w http.ResponseWriter, r *http.Request) {
+	from, to, ok := t.dateRange(w, r)
+	if !ok {
+		return
+	}
+
+	t.handleChart(w, r, from, to)
+}
+
+func (t Transaction) handleChart(w http.ResponseWriter, r *http.Request, from, to time.Time) {
+	userID, account, token, ok := t.credentials(w, r)
+	if !ok {
+		return
+	}
+
+	chart, err := t.transactionUC.Chart(token, account, userID, from, to, chartLabels)
+	if err != nil {
+		sendErr(w, r, t.log, err)
+
+		return
+	}
+
+	contentType := fmt.Sprintf("inline;filename=%s-%s%s", from.Format(dateTimePattern), to.Format(dateTimePattern), ".png")
+	w.Header().Set("Content-Disposition", contentType)
+	w.Header().Set("Content-Type", "image/png")
+	if _, err = io.Copy(w, chart); err != nil {
+		t.log.Error(err)
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
 )
 
 // Logger - represents the application's logger interface.
@@ -15,15 +17,14 @@ type Logger interface {
 
 // TransactionUC - represents a use-case interface for processing business logic of "MonoBank" transactions API.
 type TransactionUC interface {
-	GetTransactions(token, account string, userID uuid.UUID, from time.Time, to time.Time) (io.Reader, error)
-	ParseDate(period string) (from time.Time, to time.Time, err error)
-	Locale() *time.Location
-}
-
-// UserUC represents User use-case interface.
-type UserUC interface {
-	Set(userID uuid.UUID) error
-	CheckUser(userID uuid.UUID) (bool, error)
+	GetTransactions(token, account string, userID uuid.UUID, from time.Time, to time.Time, refresh bool) (
+		io.Reader, error)
+	Chart(token, account string, userID uuid.UUID, from, to time.Time, labels model.ChartLabels) (io.Reader, error)
+	Search(token, account string, userID uuid.UUID, q model.SearchQuery) (model.SearchResult, error)
+	SearchReport(userID uuid.UUID, result model.SearchResult) (io.Reader, error)
+	ParseAmountRange(s string) (min, max int64, err error)
+	ParseDate(userID uuid.UUID, period string) (from time.Time, to time.Time, err error)
+	Location(userID uuid.UUID) *time.Location
 }
 
 // AccountUC - represents a use-case interface for processing business logic "Account" use case.
@@ -34,6 +35,25 @@ type AccountUC interface {
 
 // TokenUC - represents a usecase interface for processing "Token" business logic.
 type TokenUC interface {
-	Set(userID uuid.UUID, token string) error
+	Set(userID uuid.UUID, token string) (model.ClientInfo, error)
 	Get(userID uuid.UUID) (string, error)
 }
+
+// ClientInfoUC - represents Client Info use case.
+type ClientInfoUC interface {
+	GetClientInfo(token string) (model.ClientInfo, error)
+}
+
+// MappingUC - represents a usecase interface for processing category mapping business logic.
+type MappingUC interface {
+	Parse(userID uuid.UUID, r io.Reader) error
+	Set(userID uuid.UUID, mapping []model.CategoryMapping) error
+	Get(userID uuid.UUID) ([]model.CategoryMapping, error)
+}
+
+// SettingsUC - represents a use-case interface for managing the user's time zone and locale.
+type SettingsUC interface {
+	Get(userID uuid.UUID) model.Settings
+	SetTimezone(userID uuid.UUID, name string) (*time.Location, error)
+	SetLocale(userID uuid.UUID, tag string) (model.Locale, error)
+}
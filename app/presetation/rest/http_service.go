@@ -1,39 +1,92 @@
 package rest
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
 	"github.com/gorilla/mux"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
 )
 
 // NewService constructor for HTTP service.
-func NewService(transactionHandler *Transaction, port int) *Service {
+func NewService(log Logger, transactionHandler *Transaction, accountHandler *Account, tokenHandler *Token,
+	mappingHandler *Mapping, settingsHandler *Settings, auth *Auth, port int) *Service {
 	s := Service{
+		log:                log,
 		transactionHandler: transactionHandler,
+		accountHandler:     accountHandler,
+		tokenHandler:       tokenHandler,
+		mappingHandler:     mappingHandler,
+		settingsHandler:    settingsHandler,
+		auth:               auth,
 		router:             mux.NewRouter(),
-		port:               port,
 	}
 
 	s.injectRoutes()
+	s.server = &http.Server{Addr: fmt.Sprintf(":%d", port), Handler: s.router}
 
 	return &s
 }
 
 // Service represents http service.
 type Service struct {
-	port               int
+	server             *http.Server
+	log                Logger
 	transactionHandler *Transaction
+	accountHandler     *Account
+	tokenHandler       *Token
+	mappingHandler     *Mapping
+	settingsHandler    *Settings
+	auth               *Auth
 	router             *mux.Router
 }
 
 func (s *Service) injectRoutes() {
-	s.router.HandleFunc("/transactions/month", s.transactionHandler.GetCurrentMonth)
-	s.router.HandleFunc("/transactions/today", s.transactionHandler.GetCurrentDay)
-	s.router.HandleFunc("/transactions/{from}/{to}", s.transactionHandler.Get)
+	s.router.Use(correlate)
+	s.router.NotFoundHandler = problemHandler(s.log, codeNotFound)
+	s.router.MethodNotAllowedHandler = problemHandler(s.log, codeMethodNotAllowed)
+
+	s.router.HandleFunc(openAPIPath, serveOpenAPI).Methods(http.MethodGet)
+	s.router.HandleFunc(docsPath, serveDocs).Methods(http.MethodGet)
+
+	read := s.router.Methods(http.MethodGet).Subrouter()
+	read.Use(s.auth.Require(model.ScopeReportsRead))
+	read.HandleFunc("/transactions/month", s.transactionHandler.GetCurrentMonth)
+	read.HandleFunc("/transactions/today", s.transactionHandler.GetCurrentDay)
+	read.HandleFunc("/transactions/search", s.transactionHandler.Search)
+	read.HandleFunc("/transactions/{from}/{to}", s.transactionHandler.Get)
+	read.HandleFunc("/charts/month", s.transactionHandler.GetCurrentMonthChart)
+	read.HandleFunc("/charts/today", s.transactionHandler.GetCurrentDayChart)
+	read.HandleFunc("/charts/{from}/{to}", s.transactionHandler.GetChart)
+	read.HandleFunc("/accounts", s.accountHandler.GetAccounts)
+	read.HandleFunc("/mapping", s.mappingHandler.Get)
+	read.HandleFunc("/settings", s.settingsHandler.Get)
+
+	manage := s.router.Methods(http.MethodPut).Subrouter()
+	manage.Use(s.auth.Require(model.ScopeAccountManage))
+	manage.HandleFunc("/account", s.accountHandler.Put)
+	manage.HandleFunc("/token", s.tokenHandler.Put)
+	manage.HandleFunc("/mapping", s.mappingHandler.Put)
+	manage.HandleFunc("/settings", s.settingsHandler.Put)
+}
+
+// HandleWebhook serves Telegram updates on WebhookPath.
+func (s *Service) HandleWebhook(webhook *Webhook) {
+	s.router.Handle(WebhookPath, webhook).Methods(http.MethodPost)
+}
+
+// Start HTTP service, blocks until the service is shut down.
+func (s *Service) Start() error {
+	if err := s.server.ListenAndServe(); err != http.ErrServerClosed {
+		return err
+	}
+
+	return nil
 }
 
-// Start HTTP service.
-func (s Service) Start() error {
-	return http.ListenAndServe(fmt.Sprintf(":%d", s.port), s.router)
+// Shutdown stops accepting connections and waits for active requests until ctx is done.
+func (s *Service) Shutdown(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
 }
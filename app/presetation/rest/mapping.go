@@ -0,0 +1,124 @@
+package rest
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+// categoryMapping - represents JSON form of the category mapping.
+type categoryMapping struct {
+	Mono        string `json:"mono"`
+	Description string `json:"description"`
+	App         string `json:"app"`
+}
+
+// NewMapping constructor for Mapping.
+func NewMapping(log Logger, mappingUC MappingUC) *Mapping {
+	return &Mapping{log: log, mappingUC: mappingUC}
+}
+
+// Mapping represents category mapping REST handler.
+type Mapping struct {
+	log       Logger
+	mappingUC MappingUC
+}
+
+// Get returns category mapping as JSON or as CSV if the client accepts "text/csv".
+func (m Mapping) Get(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDFromContext(r.Context())
+	if !ok {
+		sendProblem(w, r, m.log, codeUnauthorized, "")
+
+		return
+	}
+
+	mapping, err := m.mappingUC.Get(userID)
+	if err == model.ErrNil {
+		sendProblem(w, r, m.log, codeMappingNotSet, "")
+
+		return
+	}
+
+	if err != nil {
+		sendErr(w, r, m.log, err)
+
+		return
+	}
+
+	if strings.Contains(r.Header.Get("Accept"), contentTypeCSV) {
+		w.Header().Set(contentTypeHeader, contentTypeCSV)
+
+		wr := csv.NewWriter(w)
+		for _, c := range mapping {
+			if err := wr.Write([]string{c.Mono, c.Description, c.App}); err != nil {
+				m.log.Error(err)
+
+				return
+			}
+		}
+		wr.Flush()
+
+		return
+	}
+
+	resp := make([]categoryMapping, 0, len(mapping))
+	for _, c := range mapping {
+		resp = append(resp, categoryMapping{Mono: c.Mono, Description: c.Description, App: c.App})
+	}
+
+	sendJSON(w, m.log, http.StatusOK, resp)
+}
+
+// Put replaces category mapping, accepts CSV (the same as "mapping.csv" in the chat) or JSON body,
+// the JSON mappings must have the MonoBank and the application categories.
+func (m Mapping) Put(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDFromContext(r.Context())
+	if !ok {
+		sendProblem(w, r, m.log, codeUnauthorized, "")
+
+		return
+	}
+
+	var err error
+	switch contentType := mediaType(r.Header.Get(contentTypeHeader)); contentType {
+	case contentTypeCSV:
+		err = m.mappingUC.Parse(userID, io.LimitReader(r.Body, maxBodySize))
+	case contentTypeJSON:
+		req := make([]categoryMapping, 0)
+		if err := decodeJSON(r, &req); err != nil {
+			sendProblem(w, r, m.log, codeInvalidRequest, "can't decode body")
+
+			return
+		}
+
+		mapping := make([]model.CategoryMapping, 0, len(req))
+		for i, c := range req {
+			if c.Mono == "" || c.App == "" {
+				sendProblem(w, r, m.log, codeInvalidRequest, fmt.Sprintf("mapping %d should have \"mono\" and \"app\"", i))
+
+				return
+			}
+
+			mapping = append(mapping, model.CategoryMapping{Mono: c.Mono, Description: c.Description, App: c.App})
+		}
+		err = m.mappingUC.Set(userID, mapping)
+	default:
+		detail := fmt.Sprintf("expected %q or %q, got %q", contentTypeCSV, contentTypeJSON, contentType)
+		sendProblem(w, r, m.log, codeUnsupportedMediaType, detail)
+
+		return
+	}
+
+	if err != nil {
+		sendErr(w, r, m.log, err)
+
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
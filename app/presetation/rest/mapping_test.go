@@ -0,0 +1,70 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+// mappingUC - keeps the saved category mapping.
+type mappingUC struct {
+	mapping []model.CategoryMapping
+}
+
+func (m *mappingUC) Parse(uuid.UUID, io.Reader) error { return nil }
+
+func (m *mappingUC) Set(_ uuid.UUID, mapping []model.CategoryMapping) error {
+	m.mapping = mapping
+
+	return nil
+}
+
+func (m *mappingUC) Get(uuid.UUID) ([]model.CategoryMapping, error) { return m.mapping, nil }
+
+func TestMapping_PutJSON(t *testing.T) {
+	RegisterTestingT(t)
+
+	cases := []struct {
+		body   string
+		status int
+		want   []model.CategoryMapping
+	}{
+		{
+			body:   `[{"mono": "4111", "description": "Metro", "app": "Transport"}]`,
+			status: http.StatusNoContent,
+			want:   []model.CategoryMapping{{Mono: "4111", Description: "Metro", App: "Transport"}},
+		},
+		{body: `[{"mono": "4111", "app": "Transport"}, {"mono": "4111"}]`, status: http.StatusBadRequest},
+		{body: `[{"app": "Transport"}]`, status: http.StatusBadRequest},
+	}
+
+	for _, c := range cases {
+		uc := &mappingUC{}
+		m := NewMapping(zap.NewNop().Sugar(), uc)
+
+		r := httptest.NewRequest(http.MethodPut, "/mapping", strings.NewReader(c.body))
+		r.Header.Set(contentTypeHeader, contentTypeJSON)
+		r = r.WithContext(context.WithValue(r.Context(), userIDContextKey, uuid.New()))
+
+		rec := httptest.NewRecorder()
+		m.Put(rec, r)
+		Ω(rec.Code).To(Equal(c.status), rec.Body.String())
+		Ω(uc.mapping).To(Equal(c.want), errNotEqual)
+
+		if c.status == http.StatusBadRequest {
+			p := problem{}
+			Ω(json.Unmarshal(rec.Body.Bytes(), &p)).To(BeNil(), errNotEqual)
+			Ω(p.Code).To(Equal(codeInvalidRequest), errNotEqual)
+		}
+	}
+}
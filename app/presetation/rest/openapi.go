@@ -0,0 +1,706 @@
+package rest
+
+import (
+	"io"
+	"net/http"
+)
+
+// Documentation routes, they aren't described in the specification itself.
+const (
+	openAPIPath = "/openapi.json"
+	docsPath    = "/docs"
+)
+
+// openAPISpec - OpenAPI 3 specification of the REST API, must be kept in sync with Service routes.
+const openAPISpec = `{
+  "openapi": "3.0.3",
+  "info": {
+    "title": "mono-chat REST API",
+    "description": "Loads MonoBank transactions and converts them to the Money Pro CSV report or draws PNG charts of expenses. Errors are returned as RFC 7807 problem details (application/problem+json) with a stable code and the correlation ID.",
+    "version": "1.7.0"
+  },
+  "security": [{"bearerAuth": []}],
+  "paths": {
+    "/transactions/month": {
+      "get": {
+        "summary": "Transactions of the current month",
+        "description": "Returns the transactions from the beginning to the end of the current month in the user's time zone, see /settings. Scope reports:read.",
+        "operationId": "getCurrentMonthTransactions",
+        "tags": ["transactions"],
+        "parameters": [
+          {"$ref": "#/components/parameters/Refresh"},
+          {"$ref": "#/components/parameters/Timestamp"},
+          {"$ref": "#/components/parameters/Signature"}
+        ],
+        "responses": {
+          "200": {"$ref": "#/components/responses/Report"},
+          "401": {"$ref": "#/components/responses/Unauthorized"},
+          "403": {"$ref": "#/components/responses/Forbidden"},
+          "409": {"$ref": "#/components/responses/Conflict"},
+          "422": {"$ref": "#/components/responses/InvalidToken"},
+          "429": {"$ref": "#/components/responses/RateLimited"},
+          "500": {"$ref": "#/components/responses/ServerError"},
+          "502": {"$ref": "#/components/responses/BadGateway"}
+        }
+      }
+    },
+    "/transactions/today": {
+      "get": {
+        "summary": "Transactions of the current day",
+        "description": "Returns the transactions from the beginning to the end of the current day in the user's time zone, see /settings. Scope reports:read.",
+        "operationId": "getCurrentDayTransactions",
+        "tags": ["transactions"],
+        "parameters": [
+          {"$ref": "#/components/parameters/Refresh"},
+          {"$ref": "#/components/parameters/Timestamp"},
+          {"$ref": "#/components/parameters/Signature"}
+        ],
+        "responses": {
+          "200": {"$ref": "#/components/responses/Report"},
+          "401": {"$ref": "#/components/responses/Unauthorized"},
+          "403": {"$ref": "#/components/responses/Forbidden"},
+          "409": {"$ref": "#/components/responses/Conflict"},
+          "422": {"$ref": "#/components/responses/InvalidToken"},
+          "429": {"$ref": "#/components/responses/RateLimited"},
+          "500": {"$ref": "#/components/responses/ServerError"},
+          "502": {"$ref": "#/components/responses/BadGateway"}
+        }
+      }
+    },
+    "/transactions/search": {
+      "get": {
+        "summary": "Search transactions",
+        "description": "Returns the transactions whose description, comment, counterparty name or mapped category contain every word of the text, ignoring case, the latest first. The stored transaction history is searched, the periods out of it are fetched from MonoBank. Scope reports:read.",
+        "operationId": "searchTransactions",
+        "tags": ["transactions"],
+        "parameters": [
+          {"name": "q", "in": "query", "required": true, "description": "Search text.", "schema": {"type": "string"}, "example": "vet"},
+          {"name": "from", "in": "query", "required": false, "description": "First period of the range, the same as in /transactions/{from}/{to}, required with \"to\". The last 31 days are searched by default.", "schema": {"type": "string"}, "example": "2019-08-01"},
+          {"name": "to", "in": "query", "required": false, "description": "Last period of the range, required with \"from\".", "schema": {"type": "string"}, "example": "2019-08-31"},
+          {"name": "min", "in": "query", "required": false, "description": "Minimal absolute amount in the currency units.", "schema": {"type": "number"}, "example": 100},
+          {"name": "max", "in": "query", "required": false, "description": "Maximal absolute amount in the currency units.", "schema": {"type": "number"}, "example": 250.5},
+          {"name": "format", "in": "query", "required": false, "description": "\"csv\" returns the CSV report instead of JSON.", "schema": {"type": "string", "enum": ["csv"]}},
+          {"$ref": "#/components/parameters/Timestamp"},
+          {"$ref": "#/components/parameters/Signature"}
+        ],
+        "responses": {
+          "200": {
+            "description": "Found transactions, the CSV report with format=csv.",
+            "content": {
+              "application/json": {"schema": {"$ref": "#/components/schemas/SearchResult"}},
+              "text/csv": {"schema": {"type": "string"}}
+            }
+          },
+          "400": {"$ref": "#/components/responses/BadRequest"},
+          "401": {"$ref": "#/components/responses/Unauthorized"},
+          "403": {"$ref": "#/components/responses/Forbidden"},
+          "409": {"$ref": "#/components/responses/Conflict"},
+          "422": {"$ref": "#/components/responses/InvalidToken"},
+          "429": {"$ref": "#/components/responses/RateLimited"},
+          "500": {"$ref": "#/components/responses/ServerError"},
+          "502": {"$ref": "#/components/responses/BadGateway"}
+        }
+      }
+    },
+    "/transactions/{from}/{to}": {
+      "get": {
+        "summary": "Transactions of the date range",
+        "description": "Returns the transactions from the beginning of the period \"from\" to the end of the period \"to\" in the user's time zone. Scope reports:read.",
+        "operationId": "getTransactions",
+        "tags": ["transactions"],
+        "parameters": [
+          {
+            "name": "from",
+            "in": "path",
+            "required": true,
+            "description": "First period of the range: a day 2006-01-02 or a period of the /get grammar, e.g. \"last month\", \"q1 2024\", \"march\", \"2024\", the range starts with its beginning.",
+            "schema": {"type": "string", "example": "2019-08-01"}
+          },
+          {
+            "name": "to",
+            "in": "path",
+            "required": true,
+            "description": "Last period of the range (inclusive), the same as \"from\", the range ends with its end.",
+            "schema": {"type": "string", "example": "2019-08-31"}
+          },
+          {"$ref": "#/components/parameters/Refresh"},
+          {"$ref": "#/components/parameters/Timestamp"},
+          {"$ref": "#/components/parameters/Signature"}
+        ],
+        "responses": {
+          "200": {"$ref": "#/components/responses/Report"},
+          "400": {"$ref": "#/components/responses/BadRequest"},
+          "401": {"$ref": "#/components/responses/Unauthorized"},
+          "403": {"$ref": "#/components/responses/Forbidden"},
+          "409": {"$ref": "#/components/responses/Conflict"},
+          "422": {"$ref": "#/components/responses/InvalidToken"},
+          "429": {"$ref": "#/components/responses/RateLimited"},
+          "500": {"$ref": "#/components/responses/ServerError"},
+          "502": {"$ref": "#/components/responses/BadGateway"}
+        }
+      }
+    },
+    "/charts/month": {
+      "get": {
+        "summary": "Chart of the current month",
+        "description": "Returns the chart of expenses from the beginning to the end of the current month in the user's time zone, see /settings. Scope reports:read.",
+        "operationId": "getCurrentMonthChart",
+        "tags": ["charts"],
+        "parameters": [
+          {"$ref": "#/components/parameters/Timestamp"},
+          {"$ref": "#/components/parameters/Signature"}
+        ],
+        "responses": {
+          "200": {"$ref": "#/components/responses/Chart"},
+          "401": {"$ref": "#/components/responses/Unauthorized"},
+          "403": {"$ref": "#/components/responses/Forbidden"},
+          "409": {"$ref": "#/components/responses/Conflict"},
+          "422": {"$ref": "#/components/responses/InvalidToken"},
+          "429": {"$ref": "#/components/responses/RateLimited"},
+          "500": {"$ref": "#/components/responses/ServerError"},
+          "502": {"$ref": "#/components/responses/BadGateway"}
+        }
+      }
+    },
+    "/charts/today": {
+      "get": {
+        "summary": "Chart of the current day",
+        "description": "Returns the chart of expenses from the beginning to the end of the current day in the user's time zone, see /settings. Scope reports:read.",
+        "operationId": "getCurrentDayChart",
+        "tags": ["charts"],
+        "parameters": [
+          {"$ref": "#/components/parameters/Timestamp"},
+          {"$ref": "#/components/parameters/Signature"}
+        ],
+        "responses": {
+          "200": {"$ref": "#/components/responses/Chart"},
+          "401": {"$ref": "#/components/responses/Unauthorized"},
+          "403": {"$ref": "#/components/responses/Forbidden"},
+          "409": {"$ref": "#/components/responses/Conflict"},
+          "422": {"$ref": "#/components/responses/InvalidToken"},
+          "429": {"$ref": "#/components/responses/RateLimited"},
+          "500": {"$ref": "#/components/responses/ServerError"},
+          "502": {"$ref": "#/components/responses/BadGateway"}
+        }
+      }
+    },
+    "/charts/{from}/{to}": {
+      "get": {
+        "summary": "Chart of the date range",
+        "description": "Returns the chart of expenses from the beginning of the period \"from\" to the end of the period \"to\". Scope reports:read.",
+        "operationId": "getChart",
+        "tags": ["charts"],
+        "parameters": [
+          {
+            "name": "from",
+            "in": "path",
+            "required": true,
+            "description": "First period of the range: a day 2006-01-02 or a period of the /get grammar, e.g. \"last month\", \"q1 2024\", \"march\", \"2024\", the range starts with its beginning.",
+            "schema": {"type": "string", "example": "2019-08-01"}
+          },
+          {
+            "name": "to",
+            "in": "path",
+            "required": true,
+            "description": "Last period of the range (inclusive), the same as \"from\", the range ends with its end.",
+            "schema": {"type": "string", "example": "2019-08-31"}
+          },
+          {"$ref": "#/components/parameters/Timestamp"},
+          {"$ref": "#/components/parameters/Signature"}
+        ],
+        "responses": {
+          "200": {"$ref": "#/components/responses/Chart"},
+          "400": {"$ref": "#/components/responses/BadRequest"},
+          "401": {"$ref": "#/components/responses/Unauthorized"},
+          "403": {"$ref": "#/components/responses/Forbidden"},
+          "409": {"$ref": "#/components/responses/Conflict"},
+          "422": {"$ref": "#/components/responses/InvalidToken"},
+          "429": {"$ref": "#/components/responses/RateLimited"},
+          "500": {"$ref": "#/components/responses/ServerError"},
+          "502": {"$ref": "#/components/responses/BadGateway"}
+        }
+      }
+    },
+    "/accounts": {
+      "get": {
+        "summary": "Client info",
+        "description": "Returns MonoBank client info with the list of accounts. Scope reports:read.",
+        "operationId": "getAccounts",
+        "tags": ["settings"],
+        "parameters": [
+          {"$ref": "#/components/parameters/Timestamp"},
+          {"$ref": "#/components/parameters/Signature"}
+        ],
+        "responses": {
+          "200": {
+            "description": "Client info.",
+            "content": {"application/json": {"schema": {"$ref": "#/components/schemas/ClientInfo"}}}
+          },
+          "401": {"$ref": "#/components/responses/Unauthorized"},
+          "403": {"$ref": "#/components/responses/Forbidden"},
+          "409": {"$ref": "#/components/responses/Conflict"},
+          "422": {"$ref": "#/components/responses/InvalidToken"},
+          "429": {"$ref": "#/components/responses/RateLimited"},
+          "500": {"$ref": "#/components/responses/ServerError"},
+          "502": {"$ref": "#/components/responses/BadGateway"}
+        }
+      }
+    },
+    "/account": {
+      "put": {
+        "summary": "Set account",
+        "description": "Sets MonoBank account used for the reports. Scope account:manage.",
+        "operationId": "putAccount",
+        "tags": ["settings"],
+        "parameters": [
+          {"$ref": "#/components/parameters/Timestamp"},
+          {"$ref": "#/components/parameters/Signature"}
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {"application/json": {"schema": {"$ref": "#/components/schemas/AccountRequest"}}}
+        },
+        "responses": {
+          "204": {"description": "Account is set."},
+          "400": {"$ref": "#/components/responses/BadRequest"},
+          "401": {"$ref": "#/components/responses/Unauthorized"},
+          "403": {"$ref": "#/components/responses/Forbidden"},
+          "500": {"$ref": "#/components/responses/ServerError"}
+        }
+      }
+    },
+    "/token": {
+      "put": {
+        "summary": "Set MonoBank token",
+        "description": "Verifies the token by MonoBank client info and saves it. Scope account:manage.",
+        "operationId": "putToken",
+        "tags": ["settings"],
+        "parameters": [
+          {"$ref": "#/components/parameters/Timestamp"},
+          {"$ref": "#/components/parameters/Signature"}
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {"application/json": {"schema": {"$ref": "#/components/schemas/TokenRequest"}}}
+        },
+        "responses": {
+          "200": {
+            "description": "Token is verified and saved.",
+            "content": {"application/json": {"schema": {"$ref": "#/components/schemas/TokenResponse"}}}
+          },
+          "400": {"$ref": "#/components/responses/BadRequest"},
+          "401": {"$ref": "#/components/responses/Unauthorized"},
+          "403": {"$ref": "#/components/responses/Forbidden"},
+          "422": {"$ref": "#/components/responses/InvalidToken"},
+          "429": {"$ref": "#/components/responses/RateLimited"},
+          "500": {"$ref": "#/components/responses/ServerError"},
+          "502": {"$ref": "#/components/responses/BadGateway"}
+        }
+      }
+    },
+    "/mapping": {
+      "get": {
+        "summary": "Category mapping",
+        "description": "Returns the category mapping, as CSV if the Accept header contains text/csv. Scope reports:read.",
+        "operationId": "getMapping",
+        "tags": ["settings"],
+        "parameters": [
+          {"$ref": "#/components/parameters/Timestamp"},
+          {"$ref": "#/components/parameters/Signature"}
+        ],
+        "responses": {
+          "200": {
+            "description": "Category mapping.",
+            "content": {
+              "application/json": {
+                "schema": {"type": "array", "items": {"$ref": "#/components/schemas/CategoryMapping"}}
+              },
+              "text/csv": {"schema": {"$ref": "#/components/schemas/MappingCSV"}}
+            }
+          },
+          "401": {"$ref": "#/components/responses/Unauthorized"},
+          "403": {"$ref": "#/components/responses/Forbidden"},
+          "404": {"$ref": "#/components/responses/NotFound"},
+          "500": {"$ref": "#/components/responses/ServerError"}
+        }
+      },
+      "put": {
+        "summary": "Replace category mapping",
+        "description": "Replaces the category mapping. Scope account:manage.",
+        "operationId": "putMapping",
+        "tags": ["settings"],
+        "parameters": [
+          {"$ref": "#/components/parameters/Timestamp"},
+          {"$ref": "#/components/parameters/Signature"}
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {
+            "application/json": {
+              "schema": {"type": "array", "items": {"$ref": "#/components/schemas/CategoryMapping"}}
+            },
+            "text/csv": {"schema": {"$ref": "#/components/schemas/MappingCSV"}}
+          }
+        },
+        "responses": {
+          "204": {"description": "Mapping is replaced."},
+          "400": {"$ref": "#/components/responses/BadRequest"},
+          "401": {"$ref": "#/components/responses/Unauthorized"},
+          "403": {"$ref": "#/components/responses/Forbidden"},
+          "415": {"$ref": "#/components/responses/UnsupportedMediaType"},
+          "500": {"$ref": "#/components/responses/ServerError"}
+        }
+      }
+    },
+    "/settings": {
+      "get": {
+        "summary": "User settings",
+        "description": "Returns the user's time zone and locale, the defaults if they aren't set. The time zone sets the day boundaries of the reports, the locale sets the date format of the CSV reports and charts. Scope reports:read.",
+        "operationId": "getSettings",
+        "tags": ["settings"],
+        "parameters": [
+          {"$ref": "#/components/parameters/Timestamp"},
+          {"$ref": "#/components/parameters/Signature"}
+        ],
+        "responses": {
+          "200": {
+            "description": "User settings.",
+            "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Settings"}}}
+          },
+          "401": {"$ref": "#/components/responses/Unauthorized"},
+          "403": {"$ref": "#/components/responses/Forbidden"},
+          "500": {"$ref": "#/components/responses/ServerError"}
+        }
+      },
+      "put": {
+        "summary": "Update user settings",
+        "description": "Saves the user's time zone and locale, an empty field keeps the current value. Scope account:manage.",
+        "operationId": "putSettings",
+        "tags": ["settings"],
+        "parameters": [
+          {"$ref": "#/components/parameters/Timestamp"},
+          {"$ref": "#/components/parameters/Signature"}
+        ],
+        "requestBody": {
+          "required": true,
+          "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Settings"}}}
+        },
+        "responses": {
+          "200": {
+            "description": "Updated user settings.",
+            "content": {"application/json": {"schema": {"$ref": "#/components/schemas/Settings"}}}
+          },
+          "400": {"$ref": "#/components/responses/BadRequest"},
+          "401": {"$ref": "#/components/responses/Unauthorized"},
+          "403": {"$ref": "#/components/responses/Forbidden"},
+          "500": {"$ref": "#/components/responses/ServerError"}
+        }
+      }
+    }
+  },
+  "components": {
+    "securitySchemes": {
+      "bearerAuth": {
+        "type": "http",
+        "scheme": "bearer",
+        "description": "API key issued by the Telegram command \"/apikey new\" with the scope reports:read or by \"/apikey new manage\" with the scope account:manage, revoked by \"/apikey revoke\"."
+      }
+    },
+    "parameters": {
+      "Refresh": {
+        "name": "refresh",
+        "in": "query",
+        "required": false,
+        "description": "Fetch the statement from MonoBank instead of the stored transaction history.",
+        "schema": {"type": "boolean", "default": false}
+      },
+      "Timestamp": {
+        "name": "X-Timestamp",
+        "in": "header",
+        "required": false,
+        "description": "Unix time of signing, required with X-Signature. Must not differ from the server time more than 5 minutes.",
+        "schema": {"type": "integer", "format": "int64"}
+      },
+      "Signature": {
+        "name": "X-Signature",
+        "in": "header",
+        "required": false,
+        "description": "Optional request signature: hex(HMAC-SHA256(key, method + \"\\n\" + request URI + \"\\n\" + X-Timestamp + \"\\n\" + hex(SHA256(body)))).",
+        "schema": {"type": "string"}
+      }
+    },
+    "responses": {
+      "Report": {
+        "description": "CSV report with the columns Date, Description, Category, Bank category, Amount.",
+        "headers": {
+          "Content-Disposition": {
+            "description": "Attachment file name built from the range, e.g. attachment;filename=01.08.2019T00.00-31.08.2019T23.59.csv.",
+            "schema": {"type": "string"}
+          }
+        },
+        "content": {"text/csv": {"schema": {"type": "string"}}}
+      },
+      "Chart": {
+        "description": "PNG chart of the expenses: a pie chart by mapped category and a bar chart by day.",
+        "headers": {
+          "Content-Disposition": {
+            "description": "File name built from the range, e.g. inline;filename=01.08.2019T00.00-31.08.2019T23.59.png.",
+            "schema": {"type": "string"}
+          }
+        },
+        "content": {"image/png": {"schema": {"type": "string", "format": "binary"}}}
+      },
+      "BadRequest": {
+        "description": "Parameters or body can't be parsed or are invalid. Codes: invalid_request, invalid_date_range.",
+        "content": {"application/problem+json": {"schema": {"$ref": "#/components/schemas/Problem"}}}
+      },
+      "Unauthorized": {
+        "description": "API key is missing, unknown or revoked, or the request signature is invalid. Codes: unauthorized, user_not_found, invalid_signature.",
+        "content": {"application/problem+json": {"schema": {"$ref": "#/components/schemas/Problem"}}}
+      },
+      "Forbidden": {
+        "description": "API key scope doesn't allow the request. Code: forbidden.",
+        "content": {"application/problem+json": {"schema": {"$ref": "#/components/schemas/Problem"}}}
+      },
+      "NotFound": {
+        "description": "The requested setting isn't set. Code: mapping_not_set.",
+        "content": {"application/problem+json": {"schema": {"$ref": "#/components/schemas/Problem"}}}
+      },
+      "Conflict": {
+        "description": "The request needs a setting that isn't set yet. Codes: account_not_set, token_not_set.",
+        "content": {"application/problem+json": {"schema": {"$ref": "#/components/schemas/Problem"}}}
+      },
+      "UnsupportedMediaType": {
+        "description": "Content-Type of the body isn't supported. Code: unsupported_media_type.",
+        "content": {"application/problem+json": {"schema": {"$ref": "#/components/schemas/Problem"}}}
+      },
+      "InvalidToken": {
+        "description": "MonoBank rejected the token. Code: invalid_token.",
+        "content": {"application/problem+json": {"schema": {"$ref": "#/components/schemas/Problem"}}}
+      },
+      "RateLimited": {
+        "description": "MonoBank rate limit is exceeded. Code: upstream_rate_limited.",
+        "headers": {
+          "Retry-After": {"description": "Seconds to wait before retrying.", "schema": {"type": "integer"}}
+        },
+        "content": {"application/problem+json": {"schema": {"$ref": "#/components/schemas/Problem"}}}
+      },
+      "ServerError": {
+        "description": "Internal error. Code: internal_error.",
+        "content": {"application/problem+json": {"schema": {"$ref": "#/components/schemas/Problem"}}}
+      },
+      "BadGateway": {
+        "description": "MonoBank API is unavailable or returned an unexpected response. Code: upstream_unavailable.",
+        "content": {"application/problem+json": {"schema": {"$ref": "#/components/schemas/Problem"}}}
+      }
+    },
+    "schemas": {
+      "Problem": {
+        "type": "object",
+        "description": "RFC 7807 problem details.",
+        "required": ["type", "title", "status", "instance", "code", "correlationId"],
+        "properties": {
+          "type": {"type": "string", "description": "URN of the problem type.", "example": "urn:mono-chat:problem:account_not_set"},
+          "title": {"type": "string", "description": "Short summary of the problem type.", "example": "MonoBank account is not set."},
+          "status": {"type": "integer", "description": "HTTP status code.", "example": 409},
+          "detail": {"type": "string", "description": "Explanation of this occurrence.", "example": "set the account with PUT /account"},
+          "instance": {"type": "string", "description": "Request path.", "example": "/transactions/month"},
+          "code": {
+            "type": "string",
+            "description": "Stable machine readable error code.",
+            "enum": ["invalid_request", "invalid_date_range", "unauthorized", "user_not_found", "invalid_signature",
+              "forbidden", "not_found", "method_not_allowed", "account_not_set", "token_not_set", "mapping_not_set",
+              "invalid_token", "unsupported_media_type", "upstream_rate_limited", "upstream_unavailable", "internal_error"]
+          },
+          "correlationId": {"type": "string", "description": "The same as X-Correlation-ID response header, include it when reporting problems."}
+        }
+      },
+      "SearchResult": {
+        "type": "object",
+        "properties": {
+          "text": {"type": "string"},
+          "from": {"type": "string", "format": "date-time"},
+          "to": {"type": "string", "format": "date-time"},
+          "transactions": {
+            "type": "array",
+            "items": {
+              "type": "object",
+              "properties": {
+                "time": {"type": "string", "format": "date-time"},
+                "description": {"type": "string"},
+                "comment": {"type": "string"},
+                "counterName": {"type": "string", "description": "Counterparty name."},
+                "category": {"type": "string", "description": "Mapped category, MCC if the transaction isn't mapped."},
+                "mcc": {"type": "integer"},
+                "amount": {"type": "number", "description": "Amount in the currency units, expenses are negative.", "example": -450.5}
+              }
+            }
+          }
+        }
+      },
+      "ClientInfo": {
+        "type": "object",
+        "properties": {
+          "clientId": {"type": "string"},
+          "name": {"type": "string"},
+          "accounts": {
+            "type": "array",
+            "items": {
+              "type": "object",
+              "properties": {
+                "id": {"type": "string"},
+                "currencyCode": {"type": "integer", "description": "ISO 4217 numeric code."},
+                "cashbackType": {"type": "string"},
+                "balance": {"type": "integer", "description": "Balance in minor units."},
+                "creditLimit": {"type": "integer", "description": "Credit limit in minor units."},
+                "maskedPan": {"type": "array", "items": {"type": "string"}},
+                "type": {"type": "string"}
+              }
+            }
+          }
+        }
+      },
+      "AccountRequest": {
+        "type": "object",
+        "required": ["account"],
+        "properties": {"account": {"type": "string", "description": "MonoBank account ID from /accounts."}}
+      },
+      "TokenRequest": {
+        "type": "object",
+        "required": ["token"],
+        "properties": {"token": {"type": "string", "description": "MonoBank personal API token."}}
+      },
+      "TokenResponse": {
+        "type": "object",
+        "properties": {"name": {"type": "string", "description": "MonoBank client name."}}
+      },
+      "CategoryMapping": {
+        "type": "object",
+        "required": ["mono", "app"],
+        "properties": {
+          "mono": {"type": "string", "description": "MonoBank category (MCC).", "example": "4111"},
+          "description": {"type": "string", "description": "Optional transaction description to narrow the mapping."},
+          "app": {"type": "string", "description": "Money Pro category.", "example": "Transport"}
+        }
+      },
+      "MappingCSV": {
+        "type": "string",
+        "description": "CSV without header with the columns: MonoBank category, description, application category.",
+        "example": "4111,,Transport"
+      },
+      "Settings": {
+        "type": "object",
+        "properties": {
+          "timezone": {"type": "string", "description": "IANA time zone name.", "example": "Europe/Warsaw"},
+          "locale": {"type": "string", "enum": ["de", "en-GB", "en-US", "pl", "uk"], "description": "Locale of the report dates.", "example": "en-GB"}
+        }
+      }
+    }
+  }
+}
+`
+
+// docsPage - self-contained page rendering the specification in the Swagger UI manner.
+const docsPage = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>mono-chat REST API</title>
+<style>
+  body { font-family: sans-serif; margin: 0 auto; max-width: 960px; padding: 16px; color: #3b4151; }
+  .op { border: 1px solid #61affe; border-radius: 4px; margin: 12px 0; background: #ebf3fb; }
+  .op summary { cursor: pointer; padding: 8px; font-family: monospace; font-size: 15px; }
+  .method { display: inline-block; min-width: 64px; text-align: center; border-radius: 3px;
+    background: #61affe; color: #fff; font-weight: bold; margin-right: 8px; }
+  .body { background: #fff; padding: 8px 16px; }
+  table { border-collapse: collapse; width: 100%; }
+  td, th { border-bottom: 1px solid #ddd; padding: 4px; text-align: left; vertical-align: top; }
+  code { background: #f0f0f0; padding: 0 2px; }
+</style>
+</head>
+<body>
+<h1 id="title"></h1>
+<p id="description"></p>
+<p>Specification: <a href="` + openAPIPath + `">` + openAPIPath + `</a></p>
+<div id="auth"></div>
+<div id="paths"></div>
+<script>
+function resolve(spec, obj) {
+  while (obj && obj.$ref) {
+    obj = obj.$ref.substring(2).split("/").reduce(function (o, k) { return o[k]; }, spec);
+  }
+  return obj;
+}
+function text(tag, value) {
+  var el = document.createElement(tag);
+  el.textContent = value;
+  return el;
+}
+fetch("` + openAPIPath + `").then(function (r) { return r.json(); }).then(function (spec) {
+  document.getElementById("title").textContent = spec.info.title + " " + spec.info.version;
+  document.getElementById("description").textContent = spec.info.description;
+  var auth = document.getElementById("auth");
+  Object.keys(spec.components.securitySchemes).forEach(function (name) {
+    var s = spec.components.securitySchemes[name];
+    auth.appendChild(text("p", "Authorization: " + s.scheme + " - " + s.description));
+  });
+  var paths = document.getElementById("paths");
+  Object.keys(spec.paths).forEach(function (path) {
+    Object.keys(spec.paths[path]).forEach(function (method) {
+      var op = spec.paths[path][method];
+      var details = document.createElement("details");
+      details.className = "op";
+      var summary = document.createElement("summary");
+      summary.appendChild(text("span", method.toUpperCase())).className = "method";
+      summary.appendChild(text("span", path + "  " + op.summary));
+      details.appendChild(summary);
+      var body = document.createElement("div");
+      body.className = "body";
+      body.appendChild(text("p", op.description));
+      var params = document.createElement("table");
+      params.innerHTML = "<tr><th>Parameter</th><th>In</th><th>Required</th><th>Description</th></tr>";
+      (op.parameters || []).forEach(function (p) {
+        p = resolve(spec, p);
+        var row = params.insertRow();
+        [p.name, p.in, p.required ? "yes" : "no", p.description].forEach(function (v) {
+          row.insertCell().textContent = v;
+        });
+      });
+      body.appendChild(text("h4", "Parameters"));
+      body.appendChild(params);
+      if (op.requestBody) {
+        body.appendChild(text("h4", "Request body"));
+        body.appendChild(text("p", Object.keys(op.requestBody.content).join(", ")));
+      }
+      var responses = document.createElement("table");
+      responses.innerHTML = "<tr><th>Code</th><th>Content type</th><th>Description</th></tr>";
+      Object.keys(op.responses).forEach(function (code) {
+        var resp = resolve(spec, op.responses[code]);
+        var row = responses.insertRow();
+        [code, Object.keys(resp.content || {}).join(", "), resp.description].forEach(function (v) {
+          row.insertCell().textContent = v;
+        });
+      });
+      body.appendChild(text("h4", "Responses"));
+      body.appendChild(responses);
+      details.appendChild(body);
+      paths.appendChild(details);
+    });
+  });
+});
+</script>
+</body>
+</html>
+`
+
+// serveOpenAPI - returns OpenAPI specification of the REST API.
+func serveOpenAPI(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	io.WriteString(w, openAPISpec) //nolint:errcheck // the client has gone, nothing to report
+}
+
+// serveDocs - returns HTML documentation page of the REST API.
+func serveDocs(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	io.WriteString(w, docsPage) //nolint:errcheck // the client has gone, nothing to report
+}
@@ -0,0 +1,95 @@
+package rest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	. "github.com/onsi/gomega"
+)
+
+const errNotEqual = "not equal"
+
+// routeKeys - returns "METHOD path" of every routed endpoint, except the documentation and webhook ones.
+func routeKeys(router *mux.Router) ([]string, error) {
+	var keys []string
+	err := router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		if route.GetHandler() == nil {
+			return nil
+		}
+
+		path, err := route.GetPathTemplate()
+		if err != nil {
+			return err
+		}
+
+		if path == openAPIPath || path == docsPath || path == WebhookPath {
+			return nil
+		}
+
+		methods, err := route.GetMethods()
+		if err != nil {
+			return fmt.Errorf("route %s doesn't restrict HTTP methods", path)
+		}
+
+		for _, method := range methods {
+			keys = append(keys, method+" "+path)
+		}
+
+		return nil
+	})
+	sort.Strings(keys)
+
+	return keys, err
+}
+
+func specKeys(spec []byte) ([]string, error) {
+	doc := struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}{}
+	if err := json.Unmarshal(spec, &doc); err != nil {
+		return nil, err
+	}
+
+	var keys []string
+	for path, operations := range doc.Paths {
+		for method := range operations {
+			keys = append(keys, strings.ToUpper(method)+" "+path)
+		}
+	}
+	sort.Strings(keys)
+
+	return keys, nil
+}
+
+func TestService_OpenAPIInSync(t *testing.T) {
+	RegisterTestingT(t)
+	s := NewService(nil, &Transaction{}, &Account{}, &Token{}, &Mapping{}, &Settings{}, NewAuth(nil, nil), 0)
+	s.HandleWebhook(NewWebhook(nil, "secret"))
+
+	routes, err := routeKeys(s.router)
+	Ω(err).To(BeNil(), errNotEqual)
+
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, openAPIPath, nil))
+	Ω(rec.Code).To(Equal(http.StatusOK), errNotEqual)
+
+	documented, err := specKeys(rec.Body.Bytes())
+	Ω(err).To(BeNil(), errNotEqual)
+	Ω(documented).To(Equal(routes), "OpenAPI specification doesn't match the router")
+}
+
+func TestService_Docs(t *testing.T) {
+	RegisterTestingT(t)
+	s := NewService(nil, &Transaction{}, &Account{}, &Token{}, &Mapping{}, &Settings{}, NewAuth(nil, nil), 0)
+
+	rec := httptest.NewRecorder()
+	s.router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, docsPath, nil))
+	Ω(rec.Code).To(Equal(http.StatusOK), errNotEqual)
+	Ω(rec.Body.String()).To(ContainSubstring(openAPIPath), errNotEqual)
+}
@@ -0,0 +1,159 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+const (
+	contentTypeProblem  = "application/problem+json"
+	correlationIDHeader = "X-Correlation-ID"
+	problemTypePrefix   = "urn:mono-chat:problem:"
+	retryAfterHeader    = "Retry-After"
+	retryAfterSeconds   = "60" // MonoBank allows one statement request per 60 seconds
+)
+
+// errorCode - stable machine readable error code, part of the API contract.
+type errorCode string
+
+// Error codes.
+const (
+	codeInvalidRequest       errorCode = "invalid_request"
+	codeInvalidDateRange     errorCode = "invalid_date_range"
+	codeUnauthorized         errorCode = "unauthorized"
+	codeUserNotFound         errorCode = "user_not_found"
+	codeInvalidSignature     errorCode = "invalid_signature"
+	codeForbidden            errorCode = "forbidden"
+	codeNotFound             errorCode = "not_found"
+	codeMethodNotAllowed     errorCode = "method_not_allowed"
+	codeAccountNotSet        errorCode = "account_not_set"
+	codeTokenNotSet          errorCode = "token_not_set"
+	codeMappingNotSet        errorCode = "mapping_not_set"
+	codeInvalidToken         errorCode = "invalid_token"
+	codeUnsupportedMediaType errorCode = "unsupported_media_type"
+	codeUpstreamRateLimited  errorCode = "upstream_rate_limited"
+	codeUpstreamUnavailable  errorCode = "upstream_unavailable"
+	codeInternal             errorCode = "internal_error"
+)
+
+// problemSpec - HTTP status and title of the error code.
+type problemSpec struct {
+	status int
+	title  string
+}
+
+var problemSpecs = map[errorCode]problemSpec{ //nolint:gochecknoglobals
+	codeInvalidRequest:       {http.StatusBadRequest, "The request is invalid."},
+	codeInvalidDateRange:     {http.StatusBadRequest, "The date range is invalid."},
+	codeUnauthorized:         {http.StatusUnauthorized, "API key is required."},
+	codeUserNotFound:         {http.StatusUnauthorized, "API key doesn't belong to any user."},
+	codeInvalidSignature:     {http.StatusUnauthorized, "Request signature is invalid."},
+	codeForbidden:            {http.StatusForbidden, "API key scope doesn't allow the request."},
+	codeNotFound:             {http.StatusNotFound, "The resource is not found."},
+	codeMethodNotAllowed:     {http.StatusMethodNotAllowed, "The method is not allowed."},
+	codeAccountNotSet:        {http.StatusConflict, "MonoBank account is not set."},
+	codeTokenNotSet:          {http.StatusConflict, "MonoBank token is not set."},
+	codeMappingNotSet:        {http.StatusNotFound, "Category mapping is not set."},
+	codeInvalidToken:         {http.StatusUnprocessableEntity, "MonoBank rejected the token."},
+	codeUnsupportedMediaType: {http.StatusUnsupportedMediaType, "Content type is not supported."},
+	codeUpstreamRateLimited:  {http.StatusTooManyRequests, "MonoBank rate limit exceeded, retry later."},
+	codeUpstreamUnavailable:  {http.StatusBadGateway, "MonoBank API is unavailable."},
+	codeInternal:             {http.StatusInternalServerError, "Internal server error."},
+}
+
+// problem - represents RFC 7807 problem details body.
+type problem struct {
+	Type          string    `json:"type"`
+	Title         string    `json:"title"`
+	Status        int       `json:"status"`
+	Detail        string    `json:"detail,omitempty"`
+	Instance      string    `json:"instance"`
+	Code          errorCode `json:"code"`
+	CorrelationID string    `json:"correlationId"`
+}
+
+// correlate - middleware that assigns the correlation ID to the request, the client's
+// "X-Correlation-ID" is reused if it is present, the ID is returned in the same response header.
+func correlate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		correlationID := r.Header.Get(correlationIDHeader)
+		if correlationID == "" {
+			correlationID = uuid.New().String()
+		}
+
+		w.Header().Set(correlationIDHeader, correlationID)
+		ctx := context.WithValue(r.Context(), correlationIDContextKey, correlationID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+func correlationIDFromContext(ctx context.Context) string {
+	correlationID, _ := ctx.Value(correlationIDContextKey).(string)
+
+	return correlationID
+}
+
+// sendProblem - writes problem details of the error code, "detail" is shown to the client.
+func sendProblem(w http.ResponseWriter, r *http.Request, log Logger, code errorCode, detail string) {
+	spec, ok := problemSpecs[code]
+	if !ok {
+		code, spec = codeInternal, problemSpecs[codeInternal]
+	}
+
+	p := problem{
+		Type:          problemTypePrefix + string(code),
+		Title:         spec.title,
+		Status:        spec.status,
+		Detail:        detail,
+		Instance:      r.URL.Path,
+		Code:          code,
+		CorrelationID: correlationIDFromContext(r.Context()),
+	}
+
+	if code == codeUpstreamRateLimited {
+		w.Header().Set(retryAfterHeader, retryAfterSeconds)
+	}
+
+	w.Header().Set(contentTypeHeader, contentTypeProblem)
+	w.WriteHeader(spec.status)
+
+	if err := json.NewEncoder(w).Encode(p); err != nil {
+		log.Errorf("can't write response: correlation_id=%s err=%s", p.CorrelationID, err)
+	}
+}
+
+// sendErr - maps typed use-case and adapter errors to problem details, logs the error.
+func sendErr(w http.ResponseWriter, r *http.Request, log Logger, err error) {
+	log.Errorf("%s %s: correlation_id=%s err=%+v", r.Method, r.URL.Path, correlationIDFromContext(r.Context()), err)
+
+	cause := errors.Cause(err)
+	if validationErr, ok := cause.(model.ValidationError); ok {
+		sendProblem(w, r, log, codeInvalidRequest, validationErr.Msg)
+
+		return
+	}
+
+	switch cause {
+	case model.ErrInvalidToken:
+		sendProblem(w, r, log, codeInvalidToken, "")
+	case model.ErrRateLimited:
+		sendProblem(w, r, log, codeUpstreamRateLimited, "")
+	case model.ErrUpstream:
+		sendProblem(w, r, log, codeUpstreamUnavailable, "")
+	default:
+		sendProblem(w, r, log, codeInternal, "")
+	}
+}
+
+// problemHandler - returns handler answering with the error code, used for unmatched routes.
+func problemHandler(log Logger, code errorCode) http.Handler {
+	return correlate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sendProblem(w, r, log, code, "")
+	}))
+}
@@ -0,0 +1,90 @@
+package rest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"go.uber.org/zap"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+	"github.com/Kalachevskyi/mono-chat/app/usecases"
+)
+
+func TestSendErr(t *testing.T) {
+	RegisterTestingT(t)
+	log := zap.NewNop().Sugar()
+
+	cases := []struct {
+		err    error
+		code   errorCode
+		status int
+	}{
+		{errors.Wrap(model.ErrInvalidToken, "get client info"), codeInvalidToken, http.StatusUnprocessableEntity},
+		{errors.WithStack(model.ErrRateLimited), codeUpstreamRateLimited, http.StatusTooManyRequests},
+		{errors.Wrap(model.ErrUpstream, "timeout"), codeUpstreamUnavailable, http.StatusBadGateway},
+		{model.NewValidationError("empty token"), codeInvalidRequest, http.StatusBadRequest},
+		{errors.New("redis is down"), codeInternal, http.StatusInternalServerError},
+	}
+
+	for _, c := range cases {
+		rec := httptest.NewRecorder()
+		sendErr(rec, httptest.NewRequest(http.MethodGet, "/accounts", nil), log, c.err)
+
+		p := problem{}
+		Ω(json.Unmarshal(rec.Body.Bytes(), &p)).To(BeNil(), errNotEqual)
+		Ω(rec.Code).To(Equal(c.status), errNotEqual)
+		Ω(rec.Header().Get(contentTypeHeader)).To(Equal(contentTypeProblem), errNotEqual)
+		Ω(p.Code).To(Equal(c.code), errNotEqual)
+		Ω(p.Type).To(Equal(problemTypePrefix+string(c.code)), errNotEqual)
+	}
+}
+
+func TestService_Problem(t *testing.T) {
+	RegisterTestingT(t)
+	log := zap.NewNop().Sugar()
+	s := NewService(log, &Transaction{}, &Account{}, &Token{}, &Mapping{}, &Settings{}, NewAuth(log, nil), 0)
+
+	cases := []struct {
+		method string
+		path   string
+		code   errorCode
+	}{
+		{http.MethodGet, "/accounts", codeUnauthorized},
+		{http.MethodGet, "/unknown", codeNotFound},
+	}
+
+	for _, c := range cases {
+		req := httptest.NewRequest(c.method, c.path, nil)
+		req.Header.Set(correlationIDHeader, "abc")
+		rec := httptest.NewRecorder()
+		s.router.ServeHTTP(rec, req)
+
+		p := problem{}
+		Ω(json.Unmarshal(rec.Body.Bytes(), &p)).To(BeNil(), errNotEqual)
+		Ω(p.Code).To(Equal(c.code), errNotEqual)
+		Ω(p.Instance).To(Equal(c.path), errNotEqual)
+		Ω(p.CorrelationID).To(Equal("abc"), errNotEqual)
+		Ω(rec.Header().Get(correlationIDHeader)).To(Equal("abc"), errNotEqual)
+	}
+}
+
+func TestTransaction_ParseDateRange(t *testing.T) {
+	RegisterTestingT(t)
+	tr := NewTransaction(zap.NewNop().Sugar(), usecases.NewTransaction(nil, nil, nil, usecases.NewDate(time.UTC, nil)),
+		nil, nil)
+
+	rec := httptest.NewRecorder()
+	_, _, ok := tr.parseDateRange(rec, httptest.NewRequest(http.MethodGet, "/transactions", nil), "last moth", "today")
+	Ω(ok).To(BeFalse(), errNotEqual)
+
+	p := problem{}
+	Ω(json.Unmarshal(rec.Body.Bytes(), &p)).To(BeNil(), errNotEqual)
+	Ω(rec.Code).To(Equal(http.StatusBadRequest), errNotEqual)
+	Ω(p.Code).To(Equal(codeInvalidDateRange), errNotEqual)
+	Ω(p.Detail).To(Equal("can't recognize the period: last moth"), errNotEqual)
+}
@@ -0,0 +1,123 @@
+package rest
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+const (
+	formatCSV  = "csv"
+	minorUnits = 100 // minor units (cents) in the currency unit
+)
+
+// searchTransaction - represents the found transaction of the search response, the amount is in the currency units.
+type searchTransaction struct {
+	Time        time.Time `json:"time"`
+	Description string    `json:"description"`
+	Comment     string    `json:"comment,omitempty"`
+	CounterName string    `json:"counterName,omitempty"`
+	Category    string    `json:"category"`
+	Mcc         int       `json:"mcc"`
+	Amount      float64   `json:"amount"`
+}
+
+// searchResponse - represents the search response.
+type searchResponse struct {
+	Text         string              `json:"text"`
+	From         time.Time           `json:"from"`
+	To           time.Time           `json:"to"`
+	Transactions []searchTransaction `json:"transactions"`
+}
+
+// Search returns the transactions matching the text "q" of the range "from", "to" (the last 31 days by default)
+// and the amount range "min", "max" in the currency units, as JSON or as CSV report with "format=csv".
+func (t Transaction) Search(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	q := model.SearchQuery{Text: query.Get(textKey)}
+
+	fromRaw, toRaw := query.Get(fromKey), query.Get(toKey)
+	if fromRaw != "" || toRaw != "" {
+		var ok bool
+		if q.From, q.To, ok = t.parseDateRange(w, r, fromRaw, toRaw); !ok {
+			return
+		}
+	}
+
+	if minRaw, maxRaw := query.Get(minKey), query.Get(maxKey); minRaw != "" || maxRaw != "" {
+		var err error
+		if q.Min, q.Max, err = t.transactionUC.ParseAmountRange(minRaw + ".." + maxRaw); err != nil {
+			sendErr(w, r, t.log, err)
+
+			return
+		}
+	}
+
+	format := query.Get(formatKey)
+	if format != "" && format != formatCSV {
+		sendProblem(w, r, t.log, codeInvalidRequest, "format must be csv or empty")
+
+		return
+	}
+
+	userID, account, token, ok := t.credentials(w, r)
+	if !ok {
+		return
+	}
+
+	result, err := t.transactionUC.Search(token, account, userID, q)
+	if err != nil {
+		sendErr(w, r, t.log, err)
+
+		return
+	}
+
+	if format == formatCSV {
+		t.sendSearchReport(w, r, userID, result)
+
+		return
+	}
+
+	resp := searchResponse{
+		Text:         result.Text,
+		From:         result.From,
+		To:           result.To,
+		Transactions: make([]searchTransaction, 0, len(result.Transactions)),
+	}
+	for _, tr := range result.Transactions {
+		resp.Transactions = append(resp.Transactions, searchTransaction{
+			Time:        tr.Time,
+			Description: tr.Description,
+			Comment:     tr.Comment,
+			CounterName: tr.CounterName,
+			Category:    tr.Category,
+			Mcc:         tr.Mcc,
+			Amount:      float64(tr.Amount) / minorUnits,
+		})
+	}
+
+	sendJSON(w, t.log, http.StatusOK, resp)
+}
+
+func (t Transaction) sendSearchReport(w http.ResponseWriter, r *http.Request, userID uuid.UUID,
+	result model.SearchResult) {
+	report, err := t.transactionUC.SearchReport(userID, result)
+	if err != nil {
+		sendErr(w, r, t.log, err)
+
+		return
+	}
+
+	contentType := fmt.Sprintf("attachment;filename=search-%s-%s%s",
+		result.From.Format(dateTimePattern), result.To.Format(dateTimePattern), ".csv")
+	w.Header().Set("Content-Disposition", contentType)
+	w.Header().Set(contentTypeHeader, contentTypeCSV)
+	if _, err = io.Copy(w, report); err != nil {
+		t.log.Error(err)
+	}
+}
@@ -0,0 +1,76 @@
+package rest
+
+import (
+	"net/http"
+)
+
+// settingsBody - represents the user's settings, the request and the response body.
+type settingsBody struct {
+	Timezone string `json:"timezone"`
+	Locale   string `json:"locale"`
+}
+
+// NewSettings constructor for Settings.
+func NewSettings(log Logger, settingsUC SettingsUC) *Settings {
+	return &Settings{log: log, settingsUC: settingsUC}
+}
+
+// Settings represents the user's time zone and locale REST handler.
+type Settings struct {
+	log        Logger
+	settingsUC SettingsUC
+}
+
+// Get returns the user's time zone and locale, the defaults if they aren't set.
+func (s Settings) Get(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDFromContext(r.Context())
+	if !ok {
+		sendProblem(w, r, s.log, codeUnauthorized, "")
+
+		return
+	}
+
+	settings := s.settingsUC.Get(userID)
+	sendJSON(w, s.log, http.StatusOK, settingsBody{Timezone: settings.Timezone, Locale: settings.Locale})
+}
+
+// Put saves the user's time zone and locale, an empty field keeps the current value.
+func (s Settings) Put(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDFromContext(r.Context())
+	if !ok {
+		sendProblem(w, r, s.log, codeUnauthorized, "")
+
+		return
+	}
+
+	req := settingsBody{}
+	if err := decodeJSON(r, &req); err != nil {
+		sendProblem(w, r, s.log, codeInvalidRequest, "can't decode body")
+
+		return
+	}
+
+	if req.Timezone == "" && req.Locale == "" {
+		sendProblem(w, r, s.log, codeInvalidRequest, "timezone or locale must be set")
+
+		return
+	}
+
+	if req.Timezone != "" {
+		if _, err := s.settingsUC.SetTimezone(userID, req.Timezone); err != nil {
+			sendErr(w, r, s.log, err)
+
+			return
+		}
+	}
+
+	if req.Locale != "" {
+		if _, err := s.settingsUC.SetLocale(userID, req.Locale); err != nil {
+			sendErr(w, r, s.log, err)
+
+			return
+		}
+	}
+
+	s.Get(w, r)
+}
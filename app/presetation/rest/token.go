@@ -0,0 +1,58 @@
+package rest
+
+import (
+	"net/http"
+)
+
+// tokenRequest - represents the body of the token update.
+type tokenRequest struct {
+	Token string `json:"token"`
+}
+
+// tokenResponse - represents the result of the token update.
+type tokenResponse struct {
+	Name string `json:"name"`
+}
+
+// NewToken constructor for Token.
+func NewToken(log Logger, tokenUC TokenUC) *Token {
+	return &Token{log: log, tokenUC: tokenUC}
+}
+
+// Token represents MonoBank token REST handler.
+type Token struct {
+	log     Logger
+	tokenUC TokenUC
+}
+
+// Put verifies MonoBank token and saves it, returns the client name.
+func (t Token) Put(w http.ResponseWriter, r *http.Request) {
+	userID, ok := userIDFromContext(r.Context())
+	if !ok {
+		sendProblem(w, r, t.log, codeUnauthorized, "")
+
+		return
+	}
+
+	req := tokenRequest{}
+	if err := decodeJSON(r, &req); err != nil {
+		sendProblem(w, r, t.log, codeInvalidRequest, "can't decode body")
+
+		return
+	}
+
+	if req.Token == "" {
+		sendProblem(w, r, t.log, codeInvalidRequest, "token can't be empty")
+
+		return
+	}
+
+	clientInfo, err := t.tokenUC.Set(userID, req.Token)
+	if err != nil {
+		sendErr(w, r, t.log, err)
+
+		return
+	}
+
+	sendJSON(w, t.log, http.StatusOK, tokenResponse{Name: clientInfo.Name})
+}
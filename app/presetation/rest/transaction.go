@@ -4,17 +4,18 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	"github.com/jinzhu/now"
+	"github.com/pkg/errors"
 
 	"github.com/Kalachevskyi/mono-chat/app/model"
 )
 
 const (
-	dateFormat          = "2006-01-02"
 	authorizationHeader = "Authorization"
 )
 
@@ -24,12 +25,20 @@ const (
 	toKey   = "to"
 )
 
+// HTTP query keys.
+const (
+	refreshKey = "refresh"
+	textKey    = "q"
+	minKey     = "min"
+	maxKey     = "max"
+	formatKey  = "format"
+)
+
 // NewTransaction constructor for Transaction.
-func NewTransaction(log Logger, transactionUC TransactionUC, userUC UserUC, accountUC AccountUC, tokenUC TokenUC) *Transaction {
+func NewTransaction(log Logger, transactionUC TransactionUC, accountUC AccountUC, tokenUC TokenUC) *Transaction {
 	return &Transaction{
 		log:           log,
 		transactionUC: transactionUC,
-		userUC:        userUC,
 		accountUC:     accountUC,
 		tokenUC:       tokenUC,
 	}
@@ -39,119 +48,171 @@ func NewTransaction(log Logger, transactionUC TransactionUC, userUC UserUC, acco
 type Transaction struct {
 	log           Logger
 	transactionUC TransactionUC
-	userUC        UserUC
 	accountUC     AccountUC
 	tokenUC       TokenUC
 }
 
 // GetCurrentMonth returns current month transactions.
 func (t Transaction) GetCurrentMonth(w http.ResponseWriter, r *http.Request) {
-	timeNow := now.New(time.Now().In(t.transactionUC.Locale()))
+	timeNow := now.New(time.Now().In(t.location(r)))
 	from, to := timeNow.BeginningOfMonth(), timeNow.EndOfMonth()
 	t.handleTransactions(w, r, from, to)
 }
 
 // GetCurrentDay returns current day transactions.
 func (t Transaction) GetCurrentDay(w http.ResponseWriter, r *http.Request) {
-	timeNow := now.New(time.Now().In(t.transactionUC.Locale()))
+	timeNow := now.New(time.Now().In(t.location(r)))
 	from, to := timeNow.BeginningOfDay(), timeNow.EndOfDay()
 	t.handleTransactions(w, r, from, to)
 }
 
 // Get - get by date range from, to.
 func (t Transaction) Get(w http.ResponseWriter, r *http.Request) {
+	from, to, ok := t.dateRange(w, r)
+	if !ok {
+		return
+	}
+
+	t.handleTransactions(w, r, from, to)
+}
+
+// dateRange - returns the date range from the path, sends the problem if it is invalid.
+func (t Transaction) dateRange(w http.ResponseWriter, r *http.Request) (from, to time.Time, ok bool) {
 	vars := mux.Vars(r)
 	fromRaw, ok := vars[fromKey]
 	if !ok {
-		sendBadRequestError(w, t.log, "can't get From parameter")
+		sendProblem(w, r, t.log, codeInvalidRequest, "can't get From parameter")
 
-		return
+		return from, to, false
 	}
 
 	toRaw, ok := vars[toKey]
 	if !ok {
-		sendBadRequestError(w, t.log, "can't get To parameter")
+		sendProblem(w, r, t.log, codeInvalidRequest, "can't get To parameter")
 
-		return
+		return from, to, false
 	}
 
-	from, err := time.Parse(dateFormat, fromRaw)
+	return t.parseDateRange(w, r, fromRaw, toRaw)
+}
+
+// parseDateRange - parses the periods "from" and "to" of the range in the user's time zone, the range is
+// from the beginning of "from" to the end of "to", e.g. "2019-08-01", "last month" or "q1 2024",
+// sends the problem if it is invalid.
+func (t Transaction) parseDateRange(w http.ResponseWriter, r *http.Request, fromRaw, toRaw string) (
+	from, to time.Time, ok bool) {
+	userID, _ := userIDFromContext(r.Context())
+	from, _, err := t.transactionUC.ParseDate(userID, fromRaw)
 	if err != nil {
-		sendBadRequestError(w, t.log, "can't parse From parameter")
+		t.sendDateRangeErr(w, r, err)
 
-		return
+		return from, to, false
 	}
 
-	to, err := time.Parse(dateFormat, toRaw)
+	_, to, err = t.transactionUC.ParseDate(userID, toRaw)
 	if err != nil {
-		sendBadRequestError(w, t.log, "can't parse To parameter")
+		t.sendDateRangeErr(w, r, err)
 
-		return
+		return from, to, false
 	}
-	to = now.New(to).EndOfDay()
 
-	t.handleTransactions(w, r, from, to)
+	if from.After(to) {
+		sendProblem(w, r, t.log, codeInvalidDateRange, "From parameter must not be after To parameter")
+
+		return from, to, false
+	}
+
+	return from, to, true
 }
 
-func (t Transaction) handleTransactions(w http.ResponseWriter, r *http.Request, from, to time.Time) {
-	userRaw := r.Header.Get(authorizationHeader)
-	if userRaw == "" {
-		sendUserUnauthorizedError(w, t.log)
+// sendDateRangeErr - sends the problem of the period which can't be parsed, the text of the validation error
+// is the detail, e.g. "can't recognize the period: last moth".
+func (t Transaction) sendDateRangeErr(w http.ResponseWriter, r *http.Request, err error) {
+	if validationErr, ok := errors.Cause(err).(model.ValidationError); ok {
+		sendProblem(w, r, t.log, codeInvalidDateRange, validationErr.Msg)
 
 		return
 	}
 
-	userID, err := uuid.Parse(userRaw)
-	if err != nil {
-		sendWrongUUIDError(w, t.log, userRaw)
+	sendErr(w, r, t.log, err)
+}
+
+// location - returns the time zone of the authenticated user.
+func (t Transaction) location(r *http.Request) *time.Location {
+	userID, _ := userIDFromContext(r.Context())
+
+	return t.transactionUC.Location(userID)
+}
+
+// handleTransactions - sends the CSV report of the period, the "refresh" query parameter makes
+// the statement fetched from MonoBank instead of the history.
+func (t Transaction) handleTransactions(w http.ResponseWriter, r *http.Request, from, to time.Time) {
+	refresh := false
+	if raw := r.URL.Query().Get(refreshKey); raw != "" {
+		var err error
+		if refresh, err = strconv.ParseBool(raw); err != nil {
+			sendProblem(w, r, t.log, codeInvalidRequest, "can't parse refresh parameter")
+
+			return
+		}
+	}
 
+	userID, account, token, ok := t.credentials(w, r)
+	if !ok {
 		return
 	}
 
-	ok, err := t.userUC.CheckUser(userID)
+	fileResp, err := t.transactionUC.GetTransactions(token, account, userID, from, to, refresh)
 	if err != nil {
-		sendServerError(w, t.log, err.Error())
+		sendErr(w, r, t.log, err)
 
 		return
 	}
 
+	contentType := fmt.Sprintf("attachment;filename=%s-%s%s", from.Format(dateTimePattern), to.Format(dateTimePattern), ".csv")
+	w.Header().Set("Content-Disposition", contentType)
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Transfer-Encoding", "chunked")
+	if _, err = io.Copy(w, fileResp); err != nil {
+		t.log.Error(err)
+	}
+}
+
+// credentials - returns the user's account and token, sends the problem if any of them isn't available.
+func (t Transaction) credentials(w http.ResponseWriter, r *http.Request) (
+	userID uuid.UUID, account, token string, ok bool) {
+	userID, ok = userIDFromContext(r.Context())
 	if !ok {
-		sendCantFindUserError(w, t.log, userID)
+		sendProblem(w, r, t.log, codeUnauthorized, "")
 
-		return
+		return userID, "", "", false
 	}
 
 	account, err := t.accountUC.Get(userID)
 	if err == model.ErrNil {
-		sendCantFindUserError(w, t.log, userID)
+		sendProblem(w, r, t.log, codeAccountNotSet, "set the account with PUT /account")
 
-		return
+		return userID, "", "", false
 	}
 
 	if err != nil {
-		sendServerError(w, t.log, err.Error())
+		sendErr(w, r, t.log, err)
 
-		return
+		return userID, "", "", false
 	}
 
-	token, err := t.tokenUC.Get(userID)
-	if err != nil {
-		sendServerError(w, t.log, err.Error())
+	token, err = t.tokenUC.Get(userID)
+	if err == model.ErrNil {
+		sendProblem(w, r, t.log, codeTokenNotSet, "set the token with PUT /token")
 
-		return
+		return userID, "", "", false
 	}
-	fileResp, err := t.transactionUC.GetTransactions(token, account, userID, from, to)
+
 	if err != nil {
-		sendServerError(w, t.log, err.Error())
+		sendErr(w, r, t.log, err)
 
-		return
+		return userID, "", "", false
 	}
 
-	contentType := fmt.Sprintf("attachment;filename=%s-%s%s", from.Format(dateTimePattern), to.Format(dateTimePattern), ".csv")
-	w.Header().Set("Content-Disposition", contentType)
-	w.Header().Set("Content-Type", "text/csv")
-	w.Header().Set("Transfer-Encoding", "chunked")
-	if _, err = io.Copy(w, fileResp); err != nil {
-		t.log.Error(err)
-	}
+	return userID, account, token, true
 }
@@ -1,35 +1,39 @@
 package rest
 
 import (
-	"fmt"
+	"encoding/json"
+	"io"
 	"net/http"
-
-	"github.com/google/uuid"
+	"strings"
 )
 
-const dateTimePattern = "02.01.2006T15.04"
+const (
+	dateTimePattern = "02.01.2006T15.04"
+	maxBodySize     = 1 << 20 // limit of the request body, 1 MiB
+)
 
-func sendUserUnauthorizedError(w http.ResponseWriter, log Logger) {
-	http.Error(w, "user unauthorized", http.StatusUnauthorized)
-	log.Error("user unauthorized")
-}
+// Content types.
+const (
+	contentTypeHeader = "Content-Type"
+	contentTypeJSON   = "application/json"
+	contentTypeCSV    = "text/csv"
+)
 
-func sendWrongUUIDError(w http.ResponseWriter, log Logger, user string) {
-	http.Error(w, "can't parse user to UUID", http.StatusBadRequest)
-	log.Errorf("can't parse user to UUID: %s", user)
-}
+func sendJSON(w http.ResponseWriter, log Logger, status int, v interface{}) {
+	w.Header().Set(contentTypeHeader, contentTypeJSON)
+	w.WriteHeader(status)
 
-func sendServerError(w http.ResponseWriter, log Logger, msg string) {
-	http.Error(w, "internal server error", http.StatusInternalServerError)
-	log.Error(msg)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Errorf("can't write response: err=%s", err)
+	}
 }
 
-func sendCantFindUserError(w http.ResponseWriter, log Logger, user uuid.UUID) {
-	http.Error(w, "can't find user", http.StatusUnauthorized)
-	log.Errorf("can't find user: %v", user)
+// decodeJSON - decodes JSON request body limited by maxBodySize.
+func decodeJSON(r *http.Request, v interface{}) error {
+	return json.NewDecoder(io.LimitReader(r.Body, maxBodySize)).Decode(v)
 }
 
-func sendBadRequestError(w http.ResponseWriter, log Logger, msg string) {
-	http.Error(w, fmt.Sprintf("bad request: %s", msg), http.StatusBadRequest)
-	log.Errorf("bad request: %s", msg)
+// mediaType - returns media type of the header value without parameters.
+func mediaType(value string) string {
+	return strings.TrimSpace(strings.Split(value, ";")[0])
 }
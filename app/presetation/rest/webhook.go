@@ -0,0 +1,61 @@
+package rest
+
+import (
+	"crypto/subtle"
+	"net/http"
+
+	tg "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+const (
+	// WebhookPath - route receiving Telegram updates, it isn't a part of the public API.
+	WebhookPath = "/telegram/webhook"
+
+	secretTokenHeader = "X-Telegram-Bot-Api-Secret-Token" //nolint:gosec // header name, not a credential
+	webhookBufferSize = 100
+)
+
+// NewWebhook constructor for Webhook, "secret" must be the same as the one passed to Telegram "setWebhook".
+func NewWebhook(log Logger, secret string) *Webhook {
+	return &Webhook{
+		log:     log,
+		secret:  secret,
+		updates: make(chan tg.Update, webhookBufferSize),
+	}
+}
+
+// Webhook represents Telegram webhook handler, received updates are available in the Updates channel.
+type Webhook struct {
+	log     Logger
+	secret  string
+	updates chan tg.Update
+}
+
+// Updates returns the channel of updates delivered by Telegram.
+func (h *Webhook) Updates() tg.UpdatesChannel {
+	return h.updates
+}
+
+// ServeHTTP accepts the update if the request has the secret token header.
+func (h *Webhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	secret := r.Header.Get(secretTokenHeader)
+	if subtle.ConstantTimeCompare([]byte(secret), []byte(h.secret)) != 1 {
+		sendProblem(w, r, h.log, codeUnauthorized, "secret token is invalid")
+
+		return
+	}
+
+	u := tg.Update{}
+	if err := decodeJSON(r, &u); err != nil {
+		sendProblem(w, r, h.log, codeInvalidRequest, "can't decode update")
+
+		return
+	}
+
+	select {
+	case h.updates <- u:
+		w.WriteHeader(http.StatusOK)
+	case <-r.Context().Done(): // Telegram will redeliver the update
+		h.log.Errorf("webhook update isn't accepted: update=%d err=%s", u.UpdateID, r.Context().Err())
+	}
+}
@@ -0,0 +1,54 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+)
+
+const fakeUpdate = `{
+  "update_id": 10001,
+  "message": {
+    "message_id": 7,
+    "date": 1565000000,
+    "chat": {"id": 42, "type": "private"},
+    "from": {"id": 42, "first_name": "Test"},
+    "text": "/month",
+    "entities": [{"type": "bot_command", "offset": 0, "length": 6}]
+  }
+}`
+
+func TestWebhook(t *testing.T) {
+	RegisterTestingT(t)
+	log := zap.NewNop().Sugar()
+	s := NewService(log, &Transaction{}, &Account{}, &Token{}, &Mapping{}, &Settings{}, NewAuth(log, nil), 0)
+	webhook := NewWebhook(log, "secret")
+	s.HandleWebhook(webhook)
+
+	cases := []struct {
+		secret string
+		status int
+	}{
+		{"", http.StatusUnauthorized},
+		{"wrong", http.StatusUnauthorized},
+		{"secret", http.StatusOK},
+	}
+
+	for _, c := range cases {
+		req := httptest.NewRequest(http.MethodPost, WebhookPath, strings.NewReader(fakeUpdate))
+		req.Header.Set(secretTokenHeader, c.secret)
+		rec := httptest.NewRecorder()
+		s.router.ServeHTTP(rec, req)
+		Ω(rec.Code).To(Equal(c.status), errNotEqual)
+	}
+
+	Ω(webhook.Updates()).To(HaveLen(1), errNotEqual)
+	u := <-webhook.Updates()
+	Ω(u.UpdateID).To(Equal(10001), errNotEqual)
+	Ω(u.Message.Chat.ID).To(Equal(int64(42)), errNotEqual)
+	Ω(u.Message.Command()).To(Equal("month"), errNotEqual)
+}
@@ -1,7 +1,6 @@
 package telegram
 
 import (
-	tg "github.com/go-telegram-bot-api/telegram-bot-api"
 	"github.com/google/uuid"
 )
 
@@ -12,35 +11,31 @@ type AccountUC interface {
 }
 
 // NewAccount - builds "NewAccount" internal handler.
-func NewAccount(accountUC AccountUC, chatUserUC ChatUserUC, botWrapper *BotWrapper) *Account {
+func NewAccount(accountUC AccountUC, botWrapper *BotWrapper) *Account {
 	return &Account{
 		accountUC:  accountUC,
-		chatUserUC: chatUserUC,
 		BotWrapper: botWrapper,
 	}
 }
 
 // Account - represents an internal handler for processing "Account".
 type Account struct {
-	accountUC  AccountUC
-	chatUserUC ChatUserUC
+	accountUC AccountUC
 	*BotWrapper
 }
 
-// Handle - process the "Token", send the result to the user.
-func (a *Account) Handle(u tg.Update) { // nolint:dupl
-	userID, err := a.chatUserUC.GetChatUserID(u.Message.Chat.ID)
-	if err != nil {
-		a.sendDefaultErr(u.Message.Chat.ID, err)
-
-		return
-	}
+// Requires - the account is set for the user of the chat.
+func (a *Account) Requires() Requirement {
+	return RequireUser
+}
 
-	if err := a.accountUC.Set(userID, u.Message.CommandArguments()); err != nil {
-		a.sendDefaultErr(u.Message.Chat.ID, err)
+// Handle - process the "Token", send the result to the user.
+func (a *Account) Handle(r *Request) {
+	if err := a.accountUC.Set(r.UserID, r.Message.CommandArguments()); err != nil {
+		a.sendDefaultErr(r.ChatID, r.Lang, err)
 
 		return
 	}
 
-	a.sendMSG(tg.NewMessage(u.Message.Chat.ID, "successfully set account"))
+	a.sendText(r.ChatID, r.Lang, msgAccountSet, nil)
 }
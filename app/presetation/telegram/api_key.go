@@ -0,0 +1,83 @@
+package telegram
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+const (
+	apiKeyNewArg    = "new"
+	apiKeyRevokeArg = "revoke"
+	apiKeyManageArg = "manage"
+)
+
+// APIKeyUC - represents a use-case interface for managing REST API keys.
+type APIKeyUC interface {
+	New(userID uuid.UUID, scope string) (string, error)
+	Revoke(userID uuid.UUID) error
+}
+
+// NewAPIKey - builds "APIKey" internal handler.
+func NewAPIKey(apiKeyUC APIKeyUC, botWrapper *BotWrapper) *APIKey {
+	return &APIKey{
+		apiKeyUC:   apiKeyUC,
+		BotWrapper: botWrapper,
+	}
+}
+
+// APIKey - represents an internal handler for issuing and revoking REST API keys.
+type APIKey struct {
+	apiKeyUC APIKeyUC
+	*BotWrapper
+}
+
+// Requires - the keys are issued for the user of the chat.
+func (a *APIKey) Requires() Requirement {
+	return RequireUser
+}
+
+// Handle - process the "apikey" command, send the result to the user.
+func (a *APIKey) Handle(r *Request) {
+	chatID, lang, userID := r.ChatID, r.Lang, r.UserID
+	var action, option string
+	if args := strings.Fields(r.Message.CommandArguments()); len(args) > 0 {
+		action, option = args[0], strings.Join(args[1:], " ")
+	}
+
+	switch action {
+	case apiKeyNewArg:
+		scope, scopeName := model.ScopeReportsRead, msgAPIKeyRead
+		if option == apiKeyManageArg {
+			scope, scopeName = model.ScopeAccountManage, msgAPIKeyManage
+		}
+
+		key, err := a.apiKeyUC.New(userID, scope)
+		if err != nil {
+			a.sendDefaultErr(chatID, lang, err)
+
+			return
+		}
+
+		a.sendText(chatID, lang, msgAPIKeyNew, msgArgs{"Scope": translate(lang, scopeName, nil), "Key": key})
+	case apiKeyRevokeArg:
+		err := a.apiKeyUC.Revoke(userID)
+		if err == model.ErrNil {
+			a.sendText(chatID, lang, msgAPIKeyNone, nil)
+
+			return
+		}
+
+		if err != nil {
+			a.sendDefaultErr(chatID, lang, err)
+
+			return
+		}
+
+		a.sendText(chatID, lang, msgAPIKeyRevoked, nil)
+	default:
+		a.sendText(chatID, lang, msgAPIKeyUsage, nil)
+	}
+}
@@ -0,0 +1,182 @@
+package telegram
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jinzhu/now"
+	"github.com/pkg/errors"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+const (
+	budgetStatusArg = "status"
+	budgetDeleteArg = "delete"
+	budgetBarWidth  = 10
+)
+
+// BudgetUC - represents a use-case interface for managing monthly category budgets.
+type BudgetUC interface {
+	Set(userID uuid.UUID, chatID int64, category, limit string) (model.Budget, error)
+	Delete(userID uuid.UUID, category string) error
+	Status(userID uuid.UUID, spending map[string]int64) ([]model.BudgetStatus, error)
+	Users() ([]uuid.UUID, error)
+	Alerts(userID uuid.UUID, spending map[string]int64, now time.Time) ([]model.BudgetAlert, error)
+	AlertSent(alert model.BudgetAlert) error
+}
+
+// NewBudget - builds "Budget" internal handler.
+func NewBudget(budgetUC BudgetUC, tokenUC TokenUC, accountUC AccountUC, transactionUC TransactionUC,
+	botWrapper *BotWrapper) *Budget {
+	return &Budget{
+		budgetUC:      budgetUC,
+		tokenUC:       tokenUC,
+		accountUC:     accountUC,
+		transactionUC: transactionUC,
+		BotWrapper:    botWrapper,
+	}
+}
+
+// Budget - represents an internal handler for managing monthly category budgets.
+type Budget struct {
+	budgetUC      BudgetUC
+	tokenUC       TokenUC
+	accountUC     AccountUC
+	transactionUC TransactionUC
+	*BotWrapper
+}
+
+// Requires - the budgets are saved for the user of the chat, the token and the account
+// are loaded by the status only, the budgets are set without them.
+func (b *Budget) Requires() Requirement {
+	return RequireUser
+}
+
+// Handle - process the "budget" command, send the result to the user.
+func (b *Budget) Handle(r *Request) {
+	chatID, lang, userID := r.ChatID, r.Lang, r.UserID
+	fields := strings.Fields(r.Message.CommandArguments())
+
+	switch {
+	case len(fields) == 0 || (len(fields) == 1 && fields[0] == budgetStatusArg):
+		b.status(chatID, lang, userID)
+	case fields[0] == budgetDeleteArg && len(fields) > 1:
+		category := strings.Join(fields[1:], " ")
+		err := b.budgetUC.Delete(userID, category)
+		if err == model.ErrNil {
+			b.sendText(chatID, lang, msgBudgetUnknown, msgArgs{"Category": category})
+
+			return
+		}
+
+		if err != nil {
+			b.sendDefaultErr(chatID, lang, err)
+
+			return
+		}
+
+		b.sendText(chatID, lang, msgBudgetDeleted, nil)
+	case len(fields) > 1:
+		category, limit := strings.Join(fields[:len(fields)-1], " "), fields[len(fields)-1]
+		budget, err := b.budgetUC.Set(userID, chatID, category, limit)
+		if validationErr, ok := errors.Cause(err).(model.ValidationError); ok {
+			b.sendText(chatID, lang, msgInvalidUsage, msgArgs{
+				"Error": validationText(lang, validationErr),
+				"Usage": translate(lang, msgBudgetUsage, nil),
+			})
+
+			return
+		}
+
+		if err != nil {
+			b.sendDefaultErr(chatID, lang, err)
+
+			return
+		}
+
+		b.sendText(chatID, lang, msgBudgetSet, msgArgs{"Category": budget.Category, "Limit": formatAmount(budget.Limit)})
+	default:
+		b.sendText(chatID, lang, msgBudgetUsage, nil)
+	}
+}
+
+func (b *Budget) status(chatID int64, lang string, userID uuid.UUID) {
+	statuses, err := b.budgetUC.Status(userID, nil)
+	if err != nil {
+		b.sendDefaultErr(chatID, lang, err)
+
+		return
+	}
+
+	if len(statuses) == 0 {
+		b.sendText(chatID, lang, msgBudgetNone, msgArgs{"Usage": translate(lang, msgBudgetUsage, nil)})
+
+		return
+	}
+
+	token, err := b.tokenUC.Get(userID)
+	if err != nil {
+		b.sendDefaultErr(chatID, lang, err)
+
+		return
+	}
+
+	account, err := b.accountUC.Get(userID)
+	if err == model.ErrNil {
+		b.sendText(chatID, lang, msgSetAccount, nil)
+
+		return
+	}
+
+	if err != nil {
+		b.sendDefaultErr(chatID, lang, err)
+
+		return
+	}
+
+	timeNow := now.New(time.Now().In(b.transactionUC.Location(userID)))
+	spending, err := b.transactionUC.Spending(token, account, userID, timeNow.BeginningOfMonth(), timeNow.EndOfMonth())
+	if err != nil {
+		b.sendDefaultErr(chatID, lang, err)
+
+		return
+	}
+
+	if statuses, err = b.budgetUC.Status(userID, spending); err != nil {
+		b.sendDefaultErr(chatID, lang, err)
+
+		return
+	}
+
+	lines := make([]string, 0, len(statuses))
+	for _, s := range statuses {
+		lines = append(lines, formatBudgetStatus(s, lang))
+	}
+
+	b.sendText(chatID, lang, msgBudgets, msgArgs{
+		"Month":    timeNow.Month(),
+		"Year":     timeNow.Year(),
+		"Statuses": strings.Join(lines, "\n\n"),
+	})
+}
+
+// formatBudgetStatus - renders the budget status in the language,
+// e.g. "Groceries\n▓▓▓▓▓▓░░░░ 62% 4 960.00 of 8 000.00".
+func formatBudgetStatus(s model.BudgetStatus, lang string) string {
+	filled := int(s.Percent() * budgetBarWidth / 100)
+	if filled > budgetBarWidth {
+		filled = budgetBarWidth
+	}
+
+	bar := strings.Repeat("▓", filled) + strings.Repeat("░", budgetBarWidth-filled)
+
+	return translate(lang, msgBudgetStatus, msgArgs{
+		"Category": s.Category,
+		"Bar":      bar,
+		"Percent":  s.Percent(),
+		"Spent":    formatAmount(s.Spent),
+		"Limit":    formatAmount(s.Limit),
+	})
+}
@@ -0,0 +1,21 @@
+package telegram
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+func TestFormatBudgetStatus(t *testing.T) {
+	RegisterTestingT(t)
+	budget := model.Budget{Category: "Groceries", Limit: 800000}
+
+	Ω(formatBudgetStatus(model.BudgetStatus{Budget: budget, Spent: 496000}, "en")).
+		To(Equal("Groceries\n▓▓▓▓▓▓░░░░ 62% 4 960.00 of 8 000.00"), errNotEqual)
+	Ω(formatBudgetStatus(model.BudgetStatus{Budget: budget}, "en")).
+		To(Equal("Groceries\n░░░░░░░░░░ 0% 0.00 of 8 000.00"), errNotEqual)
+	Ω(formatBudgetStatus(model.BudgetStatus{Budget: budget, Spent: 1200000}, "en")).
+		To(Equal("Groceries\n▓▓▓▓▓▓▓▓▓▓ 150% 12 000.00 of 8 000.00"), errNotEqual)
+}
@@ -0,0 +1,128 @@
+package telegram
+
+import (
+	"context"
+	"time"
+
+	tg "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/google/uuid"
+	"github.com/jinzhu/now"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+// budgetCheckInterval - MonoBank allows one statement request per minute, so the spending is polled rarely.
+const budgetCheckInterval = 15 * time.Minute
+
+// NewBudgetWatcher - builds "BudgetWatcher" sending budget alerts.
+func NewBudgetWatcher(budgetUC BudgetUC, tokenUC TokenUC, accountUC AccountUC, transactionUC TransactionUC,
+	botWrapper *BotWrapper) *BudgetWatcher {
+	return &BudgetWatcher{
+		budgetUC:      budgetUC,
+		tokenUC:       tokenUC,
+		accountUC:     accountUC,
+		transactionUC: transactionUC,
+		BotWrapper:    botWrapper,
+	}
+}
+
+// BudgetWatcher - polls the spending of the users having budgets and alerts
+// when 80% and 100% of a monthly budget are spent.
+type BudgetWatcher struct {
+	budgetUC      BudgetUC
+	tokenUC       TokenUC
+	accountUC     AccountUC
+	transactionUC TransactionUC
+	*BotWrapper
+}
+
+// Run - checks the budgets until ctx is canceled, the first check is made at once.
+func (w *BudgetWatcher) Run(ctx context.Context) error {
+	ticker := time.NewTicker(budgetCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		w.check(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *BudgetWatcher) check(ctx context.Context) {
+	users, err := w.budgetUC.Users()
+	if err != nil {
+		w.log.Errorf("can't get budget users: err=%+v", err)
+
+		return
+	}
+
+	for _, userID := range users {
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err := w.checkUser(userID, time.Now().In(w.transactionUC.Location(userID))); err != nil {
+			w.log.Errorf("can't check budgets: user=%v err=%+v", userID, err)
+		}
+	}
+}
+
+// checkUser - sends the user's budget alerts, the users without token or account are skipped.
+func (w *BudgetWatcher) checkUser(userID uuid.UUID, at time.Time) error {
+	token, err := w.tokenUC.Get(userID)
+	if err == model.ErrNil {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	account, err := w.accountUC.Get(userID)
+	if err == model.ErrNil {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	spending, err := w.transactionUC.Spending(token, account, userID, now.New(at).BeginningOfMonth(), at)
+	if err != nil {
+		return err
+	}
+
+	alerts, err := w.budgetUC.Alerts(userID, spending, at)
+	if err != nil {
+		return err
+	}
+
+	for _, alert := range alerts {
+		w.sendMSG(tg.NewMessage(alert.Status.ChatID, formatBudgetAlert(alert, w.userLanguage(userID))))
+
+		if err := w.budgetUC.AlertSent(alert); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func formatBudgetAlert(a model.BudgetAlert, lang string) string {
+	s := a.Status
+	key := msgBudgetAlert
+	if a.Threshold >= model.BudgetExceededThreshold {
+		key = msgBudgetExceeded
+	}
+
+	return translate(lang, key, msgArgs{
+		"Category": s.Category,
+		"Percent":  s.Percent(),
+		"Spent":    formatAmount(s.Spent),
+		"Limit":    formatAmount(s.Limit),
+	})
+}
@@ -0,0 +1,148 @@
+package telegram
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	tg "github.com/go-telegram-bot-api/telegram-bot-api"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+// Values of the calendar buttons, they follow the step of the dialog in the data of the buttons.
+const (
+	calendarPrefix      = "#"
+	calendarNoop        = calendarPrefix
+	calendarMonth       = calendarPrefix + "m"
+	calendarDay         = calendarPrefix + "d"
+	calendarMonthLayout = "2006-01"
+	calendarDayLayout   = "2006-01-02"
+	calendarWeekDays    = 7
+	// calendarRange - separates the days of the picked range, see the date range grammar.
+	calendarRange = ".."
+)
+
+// newCalendar - builds the calendar answering the step of the dialog, "header" returns the rows
+// shown above the calendar, e.g. the quick ranges.
+func newCalendar(step string, header func(lang string) [][]tg.InlineKeyboardButton,
+	botWrapper *BotWrapper) *calendar {
+	return &calendar{step: step, header: header, BotWrapper: botWrapper}
+}
+
+// calendar - represents an inline keyboard calendar picking a range of days with the month navigation,
+// the buttons answer the step of the dialog and the first picked day is kept in the data of the dialog.
+type calendar struct {
+	step   string
+	header func(lang string) [][]tg.InlineKeyboardButton
+	*BotWrapper
+}
+
+// isCalendarValue - whether the answer to the step is a pressed button of the calendar.
+func isCalendarValue(value string) bool {
+	return strings.HasPrefix(value, calendarPrefix)
+}
+
+// keyboard - returns the header rows and the calendar of the month, the picked first day is marked.
+func (c *calendar) keyboard(month time.Time, from, lang string) tg.InlineKeyboardMarkup {
+	var rows [][]tg.InlineKeyboardButton
+	if c.header != nil {
+		rows = append(rows, c.header(lang)...)
+	}
+
+	return tg.NewInlineKeyboardMarkup(append(rows, c.rows(month, from, lang)...)...)
+}
+
+// rows - returns the calendar of the month: the navigation, the weekdays and the weeks from Monday.
+func (c *calendar) rows(month time.Time, from, lang string) [][]tg.InlineKeyboardButton {
+	first := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.UTC)
+	rows := [][]tg.InlineKeyboardButton{tg.NewInlineKeyboardRow(
+		dialogButton("‹", c.step, calendarMonth+first.AddDate(0, -1, 0).Format(calendarMonthLayout)),
+		dialogButton(translate(lang, msgCalendarMonth, msgArgs{"Month": first.Month(), "Year": first.Year()}),
+			c.step, calendarNoop),
+		dialogButton("›", c.step, calendarMonth+first.AddDate(0, 1, 0).Format(calendarMonthLayout)),
+	)}
+
+	weekdays := make([]tg.InlineKeyboardButton, 0, calendarWeekDays)
+	for _, name := range strings.Fields(translate(lang, msgCalendarDays, nil)) {
+		weekdays = append(weekdays, dialogButton(name, c.step, calendarNoop))
+	}
+	rows = append(rows, weekdays)
+
+	week := make([]tg.InlineKeyboardButton, 0, calendarWeekDays)
+	for i := 0; i < (int(first.Weekday())+calendarWeekDays-1)%calendarWeekDays; i++ {
+		week = append(week, dialogButton(" ", c.step, calendarNoop))
+	}
+
+	for day := first; day.Month() == first.Month(); day = day.AddDate(0, 0, 1) {
+		value, text := day.Format(calendarDayLayout), strconv.Itoa(day.Day())
+		if value == from {
+			text = "[" + text + "]"
+		}
+
+		week = append(week, dialogButton(text, c.step, calendarDay+value))
+		if len(week) == calendarWeekDays {
+			rows = append(rows, week)
+			week = make([]tg.InlineKeyboardButton, 0, calendarWeekDays)
+		}
+	}
+
+	if len(week) > 0 {
+		for len(week) < calendarWeekDays {
+			week = append(week, dialogButton(" ", c.step, calendarNoop))
+		}
+		rows = append(rows, week)
+	}
+
+	return rows
+}
+
+// answer - processes the pressed button of the calendar: the navigation shows another month, the first day
+// waits for the last one, the last one returns the range of the days in the format of the date range grammar,
+// e.g. "2024-03-01..2024-03-15". The empty range means the calendar waits for another button.
+func (c *calendar) answer(r *Request, value string) (string, error) {
+	if r.CallbackQuery == nil || r.CallbackQuery.Message == nil {
+		return "", model.NewValidationError("can't recognize the period: %s", value)
+	}
+
+	msgID, fromKey := r.CallbackQuery.Message.MessageID, c.step+"_from"
+	from := r.Dialog.Data[fromKey]
+	switch {
+	case value == calendarNoop:
+		return "", nil
+	case strings.HasPrefix(value, calendarMonth):
+		month, err := time.Parse(calendarMonthLayout, strings.TrimPrefix(value, calendarMonth))
+		if err != nil {
+			return "", model.NewValidationError("can't recognize the period: %s", value)
+		}
+
+		c.sendMSG(tg.NewEditMessageReplyMarkup(r.ChatID, msgID, c.keyboard(month, from, r.Lang)))
+
+		return "", nil
+	case strings.HasPrefix(value, calendarDay):
+		day := strings.TrimPrefix(value, calendarDay)
+		picked, err := time.Parse(calendarDayLayout, day)
+		if err != nil {
+			return "", model.NewValidationError("can't recognize the period: %s", value)
+		}
+
+		if from == "" {
+			r.Dialog.Data[fromKey] = day
+			edit := tg.NewEditMessageText(r.ChatID, msgID, translate(r.Lang, msgCalendarTo, msgArgs{"From": day}))
+			keyboard := c.keyboard(picked, day, r.Lang)
+			edit.ReplyMarkup = &keyboard
+			c.sendMSG(edit)
+
+			return "", nil
+		}
+
+		delete(r.Dialog.Data, fromKey)
+		if day < from {
+			from, day = day, from
+		}
+
+		return from + calendarRange + day, nil
+	default:
+		return "", model.NewValidationError("can't recognize the period: %s", value)
+	}
+}
@@ -0,0 +1,53 @@
+package telegram
+
+import (
+	"testing"
+	"time"
+
+	tg "github.com/go-telegram-bot-api/telegram-bot-api"
+	. "github.com/onsi/gomega"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+func TestCalendar_Rows(t *testing.T) {
+	RegisterTestingT(t)
+	c := newCalendar(exportPeriodStep, nil, nil)
+
+	rows := c.rows(time.Date(2024, time.March, 15, 0, 0, 0, 0, time.UTC), "2024-03-01", "en")
+	Ω(rows).To(HaveLen(2+5), errNotEqual) // March 2024 starts on Friday and ends on Sunday
+	Ω(rows[0][0].CallbackData).To(Equal(stringPtr("period:#m2024-02")), errNotEqual)
+	Ω(rows[0][1].Text).To(Equal("March 2024"), errNotEqual)
+	Ω(rows[0][2].CallbackData).To(Equal(stringPtr("period:#m2024-04")), errNotEqual)
+	Ω(rows[1][0].Text).To(Equal("Mo"), errNotEqual)
+	Ω(rows[2][3].CallbackData).To(Equal(stringPtr("period:#")), errNotEqual)
+	Ω(rows[2][4].Text).To(Equal("[1]"), errNotEqual)
+	Ω(rows[2][4].CallbackData).To(Equal(stringPtr("period:#d2024-03-01")), errNotEqual)
+	Ω(rows[6][0].Text).To(Equal("25"), errNotEqual)
+	Ω(rows[6][6].Text).To(Equal("31"), errNotEqual)
+}
+
+func TestCalendar_Answer(t *testing.T) {
+	RegisterTestingT(t)
+	c := newCalendar(exportPeriodStep, nil, nil)
+	r := &Request{
+		Update: tg.Update{CallbackQuery: &tg.CallbackQuery{Message: &tg.Message{MessageID: 1}}},
+		Dialog: &model.Dialog{Step: exportPeriodStep, Data: map[string]string{"period_from": "2024-03-15"}},
+	}
+
+	days, err := c.answer(r, calendarNoop)
+	Ω(err).To(BeNil(), errNotEqual)
+	Ω(days).To(BeEmpty(), errNotEqual)
+
+	days, err = c.answer(r, calendarDay+"2024-03-01")
+	Ω(err).To(BeNil(), errNotEqual)
+	Ω(days).To(Equal("2024-03-01..2024-03-15"), errNotEqual)
+	Ω(r.Dialog.Data).NotTo(HaveKey("period_from"), errNotEqual)
+
+	_, err = c.answer(r, calendarDay+"2024-13-01")
+	Ω(err).To(BeAssignableToTypeOf(model.ValidationError{}), errNotEqual)
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
@@ -0,0 +1,65 @@
+package telegram
+
+import (
+	tg "github.com/go-telegram-bot-api/telegram-bot-api"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+// NewChart - builds "Chart" internal handler.
+func NewChart(tr TransactionUC, b *BotWrapper) *Chart {
+	return &Chart{
+		transactionUC: tr,
+		BotWrapper:    b,
+	}
+}
+
+// Chart - represents an internal handler sending the spending chart as a photo.
+type Chart struct {
+	transactionUC TransactionUC
+	*BotWrapper
+}
+
+// Requires - the chart is built from the statement of the account of the user.
+func (c *Chart) Requires() Requirement {
+	return RequireToken | RequireAccount
+}
+
+// Handle - process the "chart" command, see parsePeriod for the period format.
+func (c *Chart) Handle(r *Request) {
+	chatID, lang, userID := r.ChatID, r.Lang, r.UserID
+	from, to, err := parsePeriod(c.transactionUC, userID, r.Message.CommandArguments())
+	if err != nil {
+		c.sendInputErr(chatID, lang, err)
+
+		return
+	}
+
+	chart, err := c.transactionUC.Chart(r.Token, r.Account, userID, from, to, chartLabels(lang))
+	if err != nil {
+		c.sendDefaultErr(chatID, lang, err)
+
+		return
+	}
+
+	msg := tg.NewPhotoUpload(chatID, tg.FileReader{
+		Name:   reportName(from, to, ".png"),
+		Reader: chart,
+		Size:   -1,
+	})
+	locale := c.transactionUC.Locale(userID)
+	msg.Caption = translate(lang, msgChartCaption, msgArgs{
+		"From": from.Format(locale.DateTime),
+		"To":   to.Format(locale.DateTime),
+	})
+	c.sendMSG(msg)
+}
+
+// chartLabels - returns the text of the chart in the language.
+func chartLabels(lang string) model.ChartLabels {
+	return model.ChartLabels{
+		Expenses:   translate(lang, msgChartExpenses, nil),
+		NoExpenses: translate(lang, msgChartNoExpenses, nil),
+		Other:      translate(lang, msgChartOther, nil),
+	}
+}
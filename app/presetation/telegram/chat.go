@@ -1,14 +1,16 @@
 package telegram
 
 import (
+	"context"
+	"time"
+
 	tg "github.com/go-telegram-bot-api/telegram-bot-api"
 )
 
-// Error messages.
-const defaultErrMSG = "Sorry, I can't process this message, view the logs or contact the owner of the service."
-
 const dateTimePattern = "02.01.2006T15.04"
 
+const offsetSaveInterval = 10 * time.Second
+
 // Command messages.
 const (
 	getCommand          = "get"
@@ -18,86 +20,186 @@ const (
 	accountCommand      = "account"
 	infoCommand         = "info"
 	userCommand         = "user"
+	apiKeyCommand       = "apikey"
+	scheduleCommand     = "schedule"
+	summaryCommand      = "summary"
+	chartCommand        = "chart"
+	budgetCommand       = "budget"
+	subscriptionCommand = "subscriptions"
+	searchCommand       = "search"
+	timezoneCommand     = "timezone"
+	localeCommand       = "locale"
+	langCommand         = "lang"
+	helpCommand         = "help"
+	startCommand        = "start"
+	exportCommand       = "export"
+	mappingCommand      = "mapping"
+	cancelCommand       = "cancel"
 )
 
 // Logger - represents the application's logger interface.
 type Logger interface {
+	Infof(template string, args ...interface{})
 	Error(args ...interface{})
 	Errorf(template string, args ...interface{})
 }
 
-// Handler - represents internal handler  interface.
+// Handler - represents internal handler interface, the router loads what the handler requires
+// into the request before calling it.
 type Handler interface {
-	Handle(u tg.Update)
+	Requires() Requirement
+	Handle(r *Request)
+}
+
+// OffsetUC - represents a use-case interface for saving the offset of the next update to process.
+type OffsetUC interface {
+	Set(offset int) error
 }
 
-// NewChat - builds main chat handler.
-func NewChat(updates tg.UpdatesChannel, handlers map[HandlerKey]Handler, botWrapper *BotWrapper) *Chat {
-	return &Chat{
+// NewChat - builds main chat handler, updates are processed by "workers" goroutines,
+// each of them queues up to "queueSize" updates.
+func NewChat(updates tg.UpdatesChannel, router *Router, botWrapper *BotWrapper, offsetUC OffsetUC,
+	workers, queueSize int) *Chat {
+	c := &Chat{
 		updates:    updates,
-		handlers:   handlers,
+		router:     router,
+		offsetUC:   offsetUC,
 		BotWrapper: botWrapper,
 	}
+	c.pool = newWorkerPool(workers, queueSize, c.route, botWrapper.log)
+
+	return c
 }
 
 // Chat - main chat handler.
 type Chat struct {
-	updates  tg.UpdatesChannel
-	handlers map[HandlerKey]Handler
+	updates     tg.UpdatesChannel
+	router      *Router
+	offsetUC    OffsetUC
+	savedOffset int
+	pool        *workerPool
 	*BotWrapper
 }
 
-// Handle - routes between internal handlers depending on the type of message.
-func (c *Chat) Handle() {
-	for u := range c.updates {
-		if u.Message == nil { // ignore any non-Message Updates
-			continue
+// Handle - dispatches updates to the workers until ctx is canceled or the updates channel is closed,
+// then waits for the queued updates to be processed. The updates already in the channel are dispatched
+// before stopping: the webhook has confirmed them to Telegram, so they wouldn't be delivered again,
+// the source of the updates must be stopped before ctx is canceled. The offset of the next update
+// to process is saved periodically and on return.
+func (c *Chat) Handle(ctx context.Context) {
+	c.pool.start()
+
+	ticker := time.NewTicker(offsetSaveInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			c.drain()
+			c.stop()
+
+			return
+		case <-ticker.C:
+			c.saveOffset()
+		case u, ok := <-c.updates:
+			if !ok {
+				c.stop()
+
+				return
+			}
+
+			c.dispatch(u)
 		}
+	}
+}
+
+func (c *Chat) dispatch(u tg.Update) {
+	if u.Message == nil && u.InlineQuery == nil && u.CallbackQuery == nil { // ignore the other updates
+		c.pool.skip(u)
 
-		if u.Message.Document != nil {
-			switch u.Message.Document.FileName {
-			case "mapping.csv":
-				c.handle(MappingHandler, u)
+		return
+	}
 
-				continue
-			default:
-				c.handle(FileReportHandler, u)
+	if !c.pool.dispatch(u) {
+		c.log.Errorf("chat queue is full, update is dropped: chat=%d update=%d", updateChatID(u), u.UpdateID)
+		if u.Message != nil { // the inline query is just left unanswered
+			c.sendText(u.Message.Chat.ID, c.language(u), msgBusy, nil)
+		}
+	}
+}
 
-				continue
+// drain - dispatches the updates left in the channel.
+func (c *Chat) drain() {
+	for {
+		select {
+		case u, ok := <-c.updates:
+			if !ok {
+				return
 			}
+
+			c.dispatch(u)
+		default:
+			return
 		}
+	}
+}
 
-		if u.Message.Command() != "" {
-			switch u.Message.Command() {
-			case getCommand, todayCommand, currentMonthCommand:
-				c.handle(TransactionsHandler, u)
+func (c *Chat) stop() {
+	c.pool.stop()
+	c.saveOffset()
+}
 
-				continue
-			case tokenCommand:
-				c.handle(TokenHandler, u)
+func (c *Chat) saveOffset() {
+	offset := c.pool.offset()
+	if offset == 0 || offset == c.savedOffset {
+		return
+	}
 
-				continue
-			case accountCommand:
-				c.handle(AccountHandler, u)
+	if err := c.offsetUC.Set(offset); err != nil {
+		c.log.Errorf("can't save offset: offset=%d err=%+v", offset, err)
 
-				continue
-			case infoCommand:
-				c.handle(ClientInfoHandler, u)
+		return
+	}
 
-				continue
-			case userCommand:
-				c.handle(ChatUserHandler, u)
+	c.savedOffset = offset
+}
 
-				continue
-			}
+// route - routes between internal handlers depending on the type of message, the commands are routed
+// by the command registry, the pressed buttons and the text messages go to the dialogs.
+func (c *Chat) route(u tg.Update) {
+	if u.InlineQuery != nil {
+		c.handle(InlineQueryHandler, u)
+
+		return
+	}
+
+	if isDialogAnswer(u) {
+		c.handle(DialogHandler, u)
+
+		return
+	}
+
+	if u.Message.Document != nil {
+		switch u.Message.Document.FileName {
+		case "mapping.csv":
+			c.handle(MappingHandler, u)
+		default:
+			c.handle(FileReportHandler, u)
 		}
 
-		c.sendMSG(tg.NewMessage(u.Message.Chat.ID, defaultErrMSG))
+		return
+	}
+
+	cmd, ok := findCommand(u.Message.Command())
+	if !ok {
+		c.sendText(u.Message.Chat.ID, c.language(u), msgUnknownCommand, nil)
+
+		return
 	}
+
+	c.handle(cmd.handler, u)
 }
 
 func (c *Chat) handle(key HandlerKey, u tg.Update) {
-	if h, ok := c.handlers[key]; ok {
-		h.Handle(u)
-	}
+	c.router.serve(key, u)
 }
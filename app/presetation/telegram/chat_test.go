@@ -0,0 +1,38 @@
+package telegram
+
+import (
+	"context"
+	"testing"
+
+	tg "github.com/go-telegram-bot-api/telegram-bot-api"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+)
+
+type offsets []int
+
+func (o *offsets) Set(offset int) error {
+	*o = append(*o, offset)
+
+	return nil
+}
+
+func TestChat_HandleDrainsUpdates(t *testing.T) {
+	RegisterTestingT(t)
+	botWrapper := NewBotWrapper(nil, zap.NewNop().Sugar(), nil, nil)
+	updates := make(chan tg.Update, 3)
+	for id := 1; id <= 3; id++ {
+		updates <- tg.Update{UpdateID: id, InlineQuery: &tg.InlineQuery{From: &tg.User{ID: 1}}}
+	}
+
+	saved := &offsets{}
+	chat := NewChat(updates, NewRouter(map[HandlerKey]Handler{}, nil, nil, nil, nil, botWrapper), botWrapper,
+		saved, 1, 10)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	chat.Handle(ctx)
+
+	Ω(updates).To(BeEmpty(), errNotEqual)
+	Ω(*saved).To(Equal(offsets{4}), errNotEqual)
+}
@@ -1,7 +1,6 @@
 package telegram
 
 import (
-	tg "github.com/go-telegram-bot-api/telegram-bot-api"
 	"github.com/google/uuid"
 )
 
@@ -25,20 +24,25 @@ type ChatUser struct {
 	*BotWrapper
 }
 
+// Requires - nothing, the handler binds the chat to the user.
+func (a *ChatUser) Requires() Requirement {
+	return 0
+}
+
 // Handle - process the "ChatUser ID", send the result to the user.
-func (a *ChatUser) Handle(u tg.Update) {
-	userID, err := uuid.Parse(u.Message.CommandArguments())
+func (a *ChatUser) Handle(r *Request) {
+	userID, err := uuid.Parse(r.Message.CommandArguments())
 	if err != nil {
-		a.sendDefaultErr(u.Message.Chat.ID, err)
+		a.sendDefaultErr(r.ChatID, r.Lang, err)
 
 		return
 	}
 
-	if err := a.userUC.SetChatUserID(u.Message.Chat.ID, userID); err != nil {
-		a.sendDefaultErr(u.Message.Chat.ID, err)
+	if err := a.userUC.SetChatUserID(r.ChatID, userID); err != nil {
+		a.sendDefaultErr(r.ChatID, r.Lang, err)
 
 		return
 	}
 
-	a.sendMSG(tg.NewMessage(u.Message.Chat.ID, "successfully set ChatUser ID"))
+	a.sendText(r.ChatID, r.Lang, msgChatUserSet, nil)
 }
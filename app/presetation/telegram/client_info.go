@@ -1,8 +1,6 @@
 package telegram
 
 import (
-	"fmt"
-
 	tg "github.com/go-telegram-bot-api/telegram-bot-api"
 
 	"github.com/Kalachevskyi/mono-chat/app/model"
@@ -16,53 +14,42 @@ type ClientInfoUC interface {
 }
 
 // NewClientInfo - represents ClientInfo constructor.
-func NewClientInfo(tokenUC TokenUC, clientInfoUC ClientInfoUC, chatUserUC ChatUserUC, botWrapper *BotWrapper) *ClientInfo {
+func NewClientInfo(clientInfoUC ClientInfoUC, botWrapper *BotWrapper) *ClientInfo {
 	return &ClientInfo{
-		tokenUC:      tokenUC,
 		clientInfoUC: clientInfoUC,
-		chatUserUC:   chatUserUC,
 		BotWrapper:   botWrapper,
 	}
 }
 
 // ClientInfo - represents ClientInfo handler struct.
 type ClientInfo struct {
-	tokenUC      TokenUC
 	clientInfoUC ClientInfoUC
-	chatUserUC   ChatUserUC
 	*BotWrapper
 }
 
-// Handle  - represents ClientInfo handler.
-func (c *ClientInfo) Handle(u tg.Update) {
-	userID, err := c.chatUserUC.GetChatUserID(u.Message.Chat.ID)
-	if err != nil {
-		c.sendDefaultErr(u.Message.Chat.ID, err)
-
-		return
-	}
-
-	chatID := u.Message.Chat.ID
-	token, err := c.tokenUC.Get(userID)
-	if err != nil {
-		c.sendDefaultErr(chatID, err)
-
-		return
-	}
+// Requires - the client info is requested by the token of the user.
+func (c *ClientInfo) Requires() Requirement {
+	return RequireToken
+}
 
-	clientInfo, err := c.clientInfoUC.GetClientInfo(token)
+// Handle  - represents ClientInfo handler.
+func (c *ClientInfo) Handle(r *Request) {
+	chatID, lang := r.ChatID, r.Lang
+	clientInfo, err := c.clientInfoUC.GetClientInfo(r.Token)
 	if err != nil {
-		c.sendDefaultErr(chatID, err)
+		c.sendDefaultErr(chatID, lang, err)
 
 		return
 	}
 
 	var resp string
 	for _, val := range clientInfo.Accounts {
-		resp = fmt.Sprintf("%sid: %s\n", resp, val.ID)
-		resp = fmt.Sprintf("%s    currency code: %v\n", resp, val.CurrencyCode)
-		resp = fmt.Sprintf("%s    balance: %v\n", resp, val.Balance/accuracy)
-		resp = fmt.Sprintf("%s    type: %v\n\n", resp, val.Type)
+		resp += translate(lang, msgClientAccount, msgArgs{
+			"ID":           val.ID,
+			"CurrencyCode": val.CurrencyCode,
+			"Balance":      val.Balance / accuracy,
+			"Type":         val.Type,
+		})
 	}
 
 	msg := tg.NewMessage(chatID, resp)
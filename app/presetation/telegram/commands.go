@@ -0,0 +1,105 @@
+package telegram
+
+import (
+	"encoding/json"
+	"net/url"
+
+	"github.com/pkg/errors"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+// command - represents a bot command: the handler processing it, the description of the command menu
+// and the usage examples of "/help".
+type command struct {
+	name        string
+	handler     HandlerKey
+	description msgKey
+	examples    []string
+}
+
+// commands - the command registry, the command menu and "/help" follow its order.
+var commands = []command{ //nolint:gochecknoglobals
+	{name: startCommand, handler: StartHandler, description: msgCmdStart, examples: []string{"/start"}},
+	{name: helpCommand, handler: HelpHandler, description: msgCmdHelp, examples: []string{"/help", "/help search"}},
+	{name: getCommand, handler: TransactionsHandler, description: msgCmdGet,
+		examples: []string{"/get 1-15", "/get last month", "/get 2024-03-01..2024-03-15", "/get q1 2024 --refresh"}},
+	{name: todayCommand, handler: TransactionsHandler, description: msgCmdToday,
+		examples: []string{"/today", "/today --refresh"}},
+	{name: currentMonthCommand, handler: TransactionsHandler, description: msgCmdMonth, examples: []string{"/month"}},
+	{name: summaryCommand, handler: SummaryHandler, description: msgCmdSummary,
+		examples: []string{"/summary", "/summary last week", "/summary march"}},
+	{name: chartCommand, handler: ChartHandler, description: msgCmdChart,
+		examples: []string{"/chart", "/chart last month"}},
+	{name: searchCommand, handler: SearchHandler, description: msgCmdSearch,
+		examples: []string{"/search coffee", "/search vet last month 100..500", "/search taxi 2024 --csv"}},
+	{name: exportCommand, handler: ExportHandler, description: msgCmdExport, examples: []string{"/export"}},
+	{name: budgetCommand, handler: BudgetHandler, description: msgCmdBudget,
+		examples: []string{"/budget Groceries 8000", "/budget status", "/budget delete Groceries"}},
+	{name: subscriptionCommand, handler: SubscriptionHandler, description: msgCmdSubscription,
+		examples: []string{"/subscriptions", "/subscriptions off"}},
+	{name: scheduleCommand, handler: ScheduleHandler, description: msgCmdSchedule,
+		examples: []string{"/schedule daily 21:00 summary", "/schedule weekly mon 09:00 csv", "/schedule list",
+			"/schedule delete <id>"}},
+	{name: infoCommand, handler: ClientInfoHandler, description: msgCmdInfo, examples: []string{"/info"}},
+	{name: accountCommand, handler: AccountHandler, description: msgCmdAccount,
+		examples: []string{"/account <account id of /info>"}},
+	{name: mappingCommand, handler: MappingHandler, description: msgCmdMapping,
+		examples: []string{"/mapping", "mapping.csv file: MonoBank category, description, application category"}},
+	{name: tokenCommand, handler: TokenHandler, description: msgCmdToken,
+		examples: []string{"/token", "/token <token of https://api.monobank.ua>"}},
+	{name: userCommand, handler: ChatUserHandler, description: msgCmdUser,
+		examples: []string{"/user 123e4567-e89b-12d3-a456-426614174000"}},
+	{name: apiKeyCommand, handler: APIKeyHandler, description: msgCmdAPIKey,
+		examples: []string{"/apikey new", "/apikey new manage", "/apikey revoke"}},
+	{name: timezoneCommand, handler: SettingsHandler, description: msgCmdTimezone,
+		examples: []string{"/timezone", "/timezone Europe/Warsaw"}},
+	{name: localeCommand, handler: SettingsHandler, description: msgCmdLocale,
+		examples: []string{"/locale", "/locale en-GB"}},
+	{name: langCommand, handler: SettingsHandler, description: msgCmdLang, examples: []string{"/lang", "/lang en"}},
+	{name: cancelCommand, handler: DialogHandler, description: msgCmdCancel, examples: []string{"/cancel"}},
+}
+
+// findCommand - returns the command of the registry by the name.
+func findCommand(name string) (command, bool) {
+	for _, c := range commands {
+		if c.name == name {
+			return c, true
+		}
+	}
+
+	return command{}, false
+}
+
+// menuCommand - represents a command of the menu, see the "setMyCommands" method of the Bot API.
+type menuCommand struct {
+	Command     string `json:"command"`
+	Description string `json:"description"`
+}
+
+// RegisterCommands - sets the command menu of the registry: in Ukrainian for the Ukrainian users,
+// in English for the others.
+func (c *Chat) RegisterCommands() error {
+	for _, lang := range []string{model.LanguageEN, model.LanguageUK} {
+		menu := make([]menuCommand, 0, len(commands))
+		for _, cmd := range commands {
+			menu = append(menu, menuCommand{Command: cmd.name, Description: translate(lang, cmd.description, nil)})
+		}
+
+		raw, err := json.Marshal(menu)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+
+		params := url.Values{"commands": {string(raw)}}
+		if lang != model.LanguageEN {
+			params.Set("language_code", lang)
+		}
+
+		if _, err := c.bot.MakeRequest("setMyCommands", params); err != nil {
+			return errors.Wrapf(err, "can't set %s commands", lang)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package telegram
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestCommands(t *testing.T) {
+	RegisterTestingT(t)
+	names := make(map[string]bool)
+	for _, cmd := range commands {
+		Ω(names[cmd.name]).To(BeFalse(), "duplicated command: "+cmd.name)
+		Ω(cmd.examples).NotTo(BeEmpty(), "no examples: "+cmd.name)
+		names[cmd.name] = true
+	}
+
+	cmd, ok := findCommand(searchCommand)
+	Ω(ok).To(BeTrue(), errNotEqual)
+	Ω(cmd.handler).To(Equal(SearchHandler), errNotEqual)
+
+	_, ok = findCommand("unknown")
+	Ω(ok).To(BeFalse(), errNotEqual)
+
+	cmd, _ = findCommand(todayCommand)
+	Ω(formatCommandHelp(cmd, "en")).To(Equal("/today - CSV report of today\n    /today\n    /today --refresh\n"),
+		errNotEqual)
+}
+
+func TestFormatSetup(t *testing.T) {
+	RegisterTestingT(t)
+	steps := []setupStep{
+		{name: msgStepUser, done: true, how: msgStepUserHow},
+		{name: msgStepToken, how: msgStepTokenHow},
+		{name: msgStepAccount, how: msgStepAccountHow},
+	}
+
+	text := formatSetup(steps, "en")
+	Ω(text).To(ContainSubstring("✅ the chat is bound to a user\n⬜ the MonoBank token is set\n"), errNotEqual)
+	Ω(text).To(HaveSuffix("/token <token>"), errNotEqual)
+
+	steps[1].done, steps[2].done = true, true
+	Ω(formatSetup(steps, "en")).To(HaveSuffix(translate("en", msgStartReady, nil)), errNotEqual)
+}
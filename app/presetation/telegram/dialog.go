@@ -0,0 +1,230 @@
+package telegram
+
+import (
+	"strings"
+
+	tg "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/pkg/errors"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+// The flows of the dialogs.
+const (
+	flowExport  = "export"
+	flowToken   = "token"
+	flowMapping = "mapping"
+)
+
+const (
+	// dialogEnd - the step returned by the last answer of the flow.
+	dialogEnd = ""
+	// callbackSeparator - separates the step and the value in the data of the dialog buttons.
+	callbackSeparator = ":"
+)
+
+// dialogFlows - the handlers resuming the dialogs by the flow.
+var dialogFlows = map[string]HandlerKey{ //nolint:gochecknoglobals
+	flowExport:  ExportHandler,
+	flowToken:   TokenHandler,
+	flowMapping: MappingHandler,
+}
+
+// DialogUC - represents a use-case interface keeping the state of the multi-step dialogs of the chats.
+type DialogUC interface {
+	Get(chatID int64) (model.Dialog, error)
+	Set(chatID int64, dialog model.Dialog) error
+	Delete(chatID int64) error
+}
+
+// dialogStep - a state of the dialog flow: "ask" sends the question of the step, "answer" processes
+// the answer and returns the next step, dialogEnd ends the dialog and the same step waits for another answer
+// without asking again, e.g. the navigation of the calendar.
+type dialogStep struct {
+	ask    func(r *Request) error
+	answer func(r *Request, answer string) (next string, err error)
+}
+
+// newDialogFlow - builds the state machine of the flow.
+func newDialogFlow(name string, steps map[string]dialogStep, dialogUC DialogUC, botWrapper *BotWrapper) *dialogFlow {
+	return &dialogFlow{name: name, steps: steps, dialogUC: dialogUC, BotWrapper: botWrapper}
+}
+
+// dialogFlow - represents the state machine of a multi-step dialog, the state is kept by DialogUC,
+// so the answers can be processed by any worker and after restarts.
+type dialogFlow struct {
+	name     string
+	steps    map[string]dialogStep
+	dialogUC DialogUC
+	*BotWrapper
+}
+
+// start - begins the dialog with the step, the previous dialog of the chat is replaced.
+func (f *dialogFlow) start(r *Request, step string) {
+	r.Dialog = &model.Dialog{Flow: f.name, Data: make(map[string]string)}
+	f.enter(r, step)
+}
+
+// resume - processes the answer to the current step: the pressed button of the step or the text message.
+// The invalid answer is reported and the step waits for another one.
+func (f *dialogFlow) resume(r *Request) {
+	step, ok := f.steps[r.Dialog.Step]
+	if !ok {
+		f.abort(r, errors.Errorf("unknown step: flow=%s step=%s", f.name, r.Dialog.Step))
+
+		return
+	}
+
+	answer, ok := dialogAnswer(r)
+	if !ok { // the button of a previous question
+		return
+	}
+
+	if r.Dialog.Data == nil {
+		r.Dialog.Data = make(map[string]string)
+	}
+
+	next, err := step.answer(r, answer)
+	if _, ok := errors.Cause(err).(model.ValidationError); ok {
+		f.sendInputErr(r.ChatID, r.Lang, err)
+		f.save(r)
+
+		return
+	}
+
+	if err != nil {
+		f.abort(r, err)
+
+		return
+	}
+
+	if next == r.Dialog.Step {
+		f.save(r)
+
+		return
+	}
+
+	if r.CallbackQuery != nil && r.CallbackQuery.Message != nil {
+		f.sendMSG(tg.NewEditMessageReplyMarkup(r.ChatID, r.CallbackQuery.Message.MessageID,
+			tg.InlineKeyboardMarkup{InlineKeyboard: make([][]tg.InlineKeyboardButton, 0)}))
+	}
+
+	f.enter(r, next)
+}
+
+// enter - saves the dialog waiting for the answer to the step and asks the question of it,
+// dialogEnd ends the dialog.
+func (f *dialogFlow) enter(r *Request, step string) {
+	if step == dialogEnd {
+		if err := f.dialogUC.Delete(r.ChatID); err != nil && err != model.ErrNil {
+			f.log.Errorf("can't end dialog: chat=%d err=%+v", r.ChatID, err)
+		}
+
+		return
+	}
+
+	r.Dialog.Step = step
+	if !f.save(r) {
+		return
+	}
+
+	if err := f.steps[step].ask(r); err != nil {
+		f.abort(r, err)
+	}
+}
+
+// save - saves the dialog prolonging it, the error is reported to the chat.
+func (f *dialogFlow) save(r *Request) bool {
+	if err := f.dialogUC.Set(r.ChatID, *r.Dialog); err != nil {
+		f.sendDefaultErr(r.ChatID, r.Lang, err)
+
+		return false
+	}
+
+	return true
+}
+
+// abort - ends the dialog because of the error.
+func (f *dialogFlow) abort(r *Request, err error) {
+	if err := f.dialogUC.Delete(r.ChatID); err != nil && err != model.ErrNil {
+		f.log.Errorf("can't end dialog: chat=%d err=%+v", r.ChatID, err)
+	}
+
+	f.sendDefaultErr(r.ChatID, r.Lang, err)
+}
+
+// dialogButton - returns the button answering the step with the value.
+func dialogButton(text, step, value string) tg.InlineKeyboardButton {
+	return tg.NewInlineKeyboardButtonData(text, step+callbackSeparator+value)
+}
+
+// dialogAnswer - returns the answer to the current step of the dialog: the value of the pressed button
+// or the text of the message, false if the button answers another step.
+func dialogAnswer(r *Request) (string, bool) {
+	if r.CallbackQuery == nil {
+		return strings.TrimSpace(r.Message.Text), true
+	}
+
+	parts := strings.SplitN(r.CallbackQuery.Data, callbackSeparator, 2)
+	if len(parts) != 2 || parts[0] != r.Dialog.Step {
+		return "", false
+	}
+
+	return parts[1], true
+}
+
+// isDialogAnswer - whether the update can answer a dialog: a pressed button or a text message
+// which isn't a command.
+func isDialogAnswer(u tg.Update) bool {
+	if u.CallbackQuery != nil {
+		return true
+	}
+
+	return u.Message != nil && u.Message.Document == nil && u.Message.Text != "" && !u.Message.IsCommand()
+}
+
+// NewDialog - builds "Dialog" internal handler.
+func NewDialog(dialogUC DialogUC, botWrapper *BotWrapper) *Dialog {
+	return &Dialog{
+		dialogUC:   dialogUC,
+		BotWrapper: botWrapper,
+	}
+}
+
+// Dialog - represents an internal handler cancelling the dialogs, it gets the answers
+// when the chat has no dialog too, the answers to the dialogs go to the handlers of the flows.
+type Dialog struct {
+	dialogUC DialogUC
+	*BotWrapper
+}
+
+// Requires - nothing, the dialog of any chat can be cancelled.
+func (d *Dialog) Requires() Requirement {
+	return 0
+}
+
+// Handle - process the "cancel" command ending the dialog of the chat, the buttons and the text
+// without a dialog are answered that there is nothing to answer.
+func (d *Dialog) Handle(r *Request) {
+	switch {
+	case r.CallbackQuery != nil:
+		d.sendText(r.ChatID, r.Lang, msgDialogExpired, nil)
+	case r.Message.Command() != cancelCommand:
+		d.sendText(r.ChatID, r.Lang, msgUnknownCommand, nil)
+	default:
+		err := d.dialogUC.Delete(r.ChatID)
+		if err == model.ErrNil {
+			d.sendText(r.ChatID, r.Lang, msgCancelNothing, nil)
+
+			return
+		}
+
+		if err != nil {
+			d.sendDefaultErr(r.ChatID, r.Lang, err)
+
+			return
+		}
+
+		d.sendText(r.ChatID, r.Lang, msgCancelled, nil)
+	}
+}
@@ -0,0 +1,221 @@
+package telegram
+
+import (
+	"strconv"
+	"time"
+
+	tg "github.com/go-telegram-bot-api/telegram-bot-api"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+// Steps of the export dialog.
+const (
+	exportAccountStep = "account"
+	exportPeriodStep  = "period"
+	exportFormatStep  = "format"
+)
+
+// Formats of the export.
+const (
+	exportCSV     = "csv"
+	exportSummary = "summary"
+	exportChart   = "chart"
+)
+
+// exportPeriods - the quick buttons of the export period above the calendar: the periods of the date range
+// grammar and the names of the buttons.
+var exportPeriods = [][]struct { //nolint:gochecknoglobals
+	period string
+	name   msgKey
+}{
+	{{"today", msgExportToday}, {"week", msgExportWeek}, {"month", msgExportMonth}},
+	{{"last month", msgExportLastMonth}, {"year", msgExportYear}},
+}
+
+// NewExport - builds "Export" internal handler.
+func NewExport(clientInfoUC ClientInfoUC, transactionUC TransactionUC, dialogUC DialogUC,
+	botWrapper *BotWrapper) *Export {
+	e := &Export{
+		clientInfoUC:  clientInfoUC,
+		transactionUC: transactionUC,
+		BotWrapper:    botWrapper,
+	}
+	e.calendar = newCalendar(exportPeriodStep, exportPeriodRows, botWrapper)
+	e.flow = newDialogFlow(flowExport, map[string]dialogStep{
+		exportAccountStep: {ask: e.askAccount, answer: e.answerAccount},
+		exportPeriodStep:  {ask: e.askPeriod, answer: e.answerPeriod},
+		exportFormatStep:  {ask: e.askFormat, answer: e.answerFormat},
+	}, dialogUC, botWrapper)
+
+	return e
+}
+
+// Export - represents an internal handler of the "/export" dialog: choose the account,
+// the period and the format of the report.
+type Export struct {
+	clientInfoUC  ClientInfoUC
+	transactionUC TransactionUC
+	calendar      *calendar
+	flow          *dialogFlow
+	*BotWrapper
+}
+
+// Requires - the accounts are requested by the token of the user.
+func (e *Export) Requires() Requirement {
+	return RequireToken
+}
+
+// Handle - starts the export dialog or processes the answer to it.
+func (e *Export) Handle(r *Request) {
+	if r.Dialog != nil {
+		e.flow.resume(r)
+
+		return
+	}
+
+	e.flow.start(r, exportAccountStep)
+}
+
+func (e *Export) askAccount(r *Request) error {
+	info, err := e.clientInfoUC.GetClientInfo(r.Token)
+	if err != nil {
+		return err
+	}
+
+	rows := make([][]tg.InlineKeyboardButton, 0, len(info.Accounts))
+	for _, account := range info.Accounts {
+		card := account.Type
+		if len(account.MaskedPan) > 0 {
+			card = account.MaskedPan[0]
+		}
+
+		currency, ok := currencyNames[account.CurrencyCode]
+		if !ok {
+			currency = strconv.Itoa(account.CurrencyCode)
+		}
+
+		rows = append(rows, tg.NewInlineKeyboardRow(dialogButton(card+" "+currency, exportAccountStep, account.ID)))
+	}
+
+	msg := tg.NewMessage(r.ChatID, translate(r.Lang, msgExportAccount, nil))
+	msg.ReplyMarkup = tg.NewInlineKeyboardMarkup(rows...)
+	e.sendMSG(msg)
+
+	return nil
+}
+
+func (e *Export) answerAccount(r *Request, account string) (string, error) {
+	if r.CallbackQuery == nil || account == "" {
+		return "", model.NewValidationError("choose the account with the buttons")
+	}
+
+	r.Dialog.Data[exportAccountStep] = account
+
+	return exportPeriodStep, nil
+}
+
+// exportPeriodRows - returns the quick buttons of the export period.
+func exportPeriodRows(lang string) [][]tg.InlineKeyboardButton {
+	rows := make([][]tg.InlineKeyboardButton, 0, len(exportPeriods))
+	for _, periods := range exportPeriods {
+		row := make([]tg.InlineKeyboardButton, 0, len(periods))
+		for _, p := range periods {
+			row = append(row, dialogButton(translate(lang, p.name, nil), exportPeriodStep, p.period))
+		}
+		rows = append(rows, row)
+	}
+
+	return rows
+}
+
+// askPeriod - sends the quick ranges and the calendar of the current month.
+func (e *Export) askPeriod(r *Request) error {
+	msg := tg.NewMessage(r.ChatID, translate(r.Lang, msgExportPeriod, nil))
+	msg.ReplyMarkup = e.calendar.keyboard(time.Now().In(e.transactionUC.Location(r.UserID)), "", r.Lang)
+	e.sendMSG(msg)
+
+	return nil
+}
+
+// answerPeriod - keeps the quick range, the typed period or the range of the days picked in the calendar.
+func (e *Export) answerPeriod(r *Request, period string) (string, error) {
+	if isCalendarValue(period) {
+		days, err := e.calendar.answer(r, period)
+		if err != nil || days == "" {
+			return exportPeriodStep, err
+		}
+		period = days
+	}
+
+	if _, _, err := e.transactionUC.ParseDate(r.UserID, period); err != nil {
+		return "", err
+	}
+
+	r.Dialog.Data[exportPeriodStep] = period
+
+	return exportFormatStep, nil
+}
+
+func (e *Export) askFormat(r *Request) error {
+	msg := tg.NewMessage(r.ChatID, translate(r.Lang, msgExportFormat, nil))
+	msg.ReplyMarkup = tg.NewInlineKeyboardMarkup(tg.NewInlineKeyboardRow(
+		dialogButton("CSV", exportFormatStep, exportCSV),
+		dialogButton(translate(r.Lang, msgExportSummary, nil), exportFormatStep, exportSummary),
+		dialogButton(translate(r.Lang, msgExportChart, nil), exportFormatStep, exportChart),
+	))
+	e.sendMSG(msg)
+
+	return nil
+}
+
+// answerFormat - sends the report of the chosen account, period and format, it ends the dialog.
+func (e *Export) answerFormat(r *Request, format string) (string, error) {
+	account := r.Dialog.Data[exportAccountStep]
+	from, to, err := e.transactionUC.ParseDate(r.UserID, r.Dialog.Data[exportPeriodStep])
+	if err != nil {
+		return "", err
+	}
+
+	locale := e.transactionUC.Locale(r.UserID)
+	switch format {
+	case exportCSV:
+		report, err := e.transactionUC.GetTransactions(r.Token, account, r.UserID, from, to, false)
+		if err != nil {
+			return "", err
+		}
+
+		e.sendMSG(tg.NewDocumentUpload(r.ChatID, tg.FileReader{
+			Name:   reportName(from, to, ".csv"),
+			Reader: report,
+			Size:   -1,
+		}))
+	case exportSummary:
+		summary, err := e.transactionUC.Summary(r.Token, account, r.UserID, from, to)
+		if err != nil {
+			return "", err
+		}
+
+		e.sendMSG(tg.NewMessage(r.ChatID, formatSummary(summary, r.Lang, locale)))
+	case exportChart:
+		chart, err := e.transactionUC.Chart(r.Token, account, r.UserID, from, to, chartLabels(r.Lang))
+		if err != nil {
+			return "", err
+		}
+
+		msg := tg.NewPhotoUpload(r.ChatID, tg.FileReader{
+			Name:   reportName(from, to, ".png"),
+			Reader: chart,
+			Size:   -1,
+		})
+		msg.Caption = translate(r.Lang, msgChartCaption, msgArgs{
+			"From": from.Format(locale.DateTime),
+			"To":   to.Format(locale.DateTime),
+		})
+		e.sendMSG(msg)
+	default:
+		return "", model.NewValidationError("choose the format with the buttons")
+	}
+
+	return dialogEnd, nil
+}
@@ -3,12 +3,14 @@ package telegram
 import (
 	"io"
 	"net/url"
+	"path/filepath"
+	"strings"
 
 	tg "github.com/go-telegram-bot-api/telegram-bot-api"
 	"github.com/google/uuid"
 )
 
-// CsvUC - represents a usecase interface for processing business logic of a CSV report.
+// CsvUC - represents a usecase interface for converting a CSV, XLS or XLSX statement to a CSV report.
 type CsvUC interface {
 	Validate(name string) error
 	GetFile(u *url.URL) (io.ReadCloser, error)
@@ -16,72 +18,70 @@ type CsvUC interface {
 }
 
 // NewFileReport - builds "FileReport" internal handler.
-func NewFileReport(csvUC CsvUC, chatUserUC ChatUserUC, botWrapper *BotWrapper) *FileReport {
+func NewFileReport(csvUC CsvUC, botWrapper *BotWrapper) *FileReport {
 	return &FileReport{
 		csvUC:      csvUC,
-		chatUserUC: chatUserUC,
 		BotWrapper: botWrapper,
 	}
 }
 
-// FileReport - represents an internal handler for processing a CSV report.
+// FileReport - represents an internal handler for processing a CSV, XLS or XLSX statement.
 type FileReport struct {
-	csvUC      CsvUC
-	chatUserUC ChatUserUC
+	csvUC CsvUC
 	*BotWrapper
 }
 
-// Handle - process the CSV MonoBank report, send processed result to the user.
-func (f *FileReport) Handle(u tg.Update) {
-	if err := f.csvUC.Validate(u.Message.Document.FileName); err != nil {
-		f.sendDefaultErr(u.Message.Chat.ID, err)
+// Requires - the report is processed with the category mapping of the user.
+func (f *FileReport) Requires() Requirement {
+	return RequireUser
+}
+
+// Handle - process the MonoBank statement, send the CSV report to the user.
+func (f *FileReport) Handle(r *Request) {
+	chatID, lang := r.ChatID, r.Lang
+	if err := f.csvUC.Validate(r.Message.Document.FileName); err != nil {
+		f.sendInputErr(chatID, lang, err)
 
 		return
 	}
 
-	fileTG, err := f.bot.GetFile(tg.FileConfig{FileID: u.Message.Document.FileID})
+	fileTG, err := f.bot.GetFile(tg.FileConfig{FileID: r.Message.Document.FileID})
 	if err != nil {
-		f.sendDefaultErr(u.Message.Chat.ID, err)
+		f.sendDefaultErr(chatID, lang, err)
 
 		return
 	}
 
 	fileURL, err := url.Parse(fileTG.Link(f.bot.Token))
 	if err != nil {
-		f.sendDefaultErr(u.Message.Chat.ID, err)
+		f.sendDefaultErr(chatID, lang, err)
 
 		return
 	}
 
 	file, err := f.csvUC.GetFile(fileURL)
 	if err != nil {
-		f.sendDefaultErr(u.Message.Chat.ID, err)
-
-		return
-	}
-
-	userID, err := f.chatUserUC.GetChatUserID(u.Message.Chat.ID)
-	if err != nil {
-		f.sendDefaultErr(u.Message.Chat.ID, err)
+		f.sendDefaultErr(chatID, lang, err)
 
 		return
 	}
 
-	fileResp, err := f.csvUC.Parse(userID, u.Message.Document.FileName, file)
+	fileResp, err := f.csvUC.Parse(r.UserID, r.Message.Document.FileName, file)
 	if err != nil {
-		f.sendDefaultErr(u.Message.Chat.ID, err)
+		f.sendInputErr(chatID, lang, err)
 
 		return
 	}
 
-	name := u.Message.Document.FileName
+	name := r.Message.Document.FileName
+	name = strings.TrimSuffix(name, filepath.Ext(name)) + ".csv"
 	reader := tg.FileReader{
 		Name:   name,
 		Reader: fileResp,
 		Size:   -1,
 	}
-	msg := tg.NewDocumentUpload(u.Message.Chat.ID, reader)
+	msg := tg.NewDocumentUpload(chatID, reader)
 	f.sendMSG(msg)
 
-	close(file, f.log)
+	closeBody(file, f.log)
 }
@@ -0,0 +1,62 @@
+package telegram
+
+import (
+	"strings"
+
+	tg "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// NewHelp - builds "Help" internal handler.
+func NewHelp(botWrapper *BotWrapper) *Help {
+	return &Help{BotWrapper: botWrapper}
+}
+
+// Help - represents an internal handler listing the commands of the registry.
+type Help struct {
+	*BotWrapper
+}
+
+// Requires - nothing, the help is available before the setup.
+func (h *Help) Requires() Requirement {
+	return 0
+}
+
+// Handle - process the "help" command: every command with the usage examples,
+// "/help <command>" shows the command only.
+func (h *Help) Handle(r *Request) {
+	chatID, lang := r.ChatID, r.Lang
+	name := strings.TrimPrefix(strings.TrimSpace(r.Message.CommandArguments()), "/")
+	if name == "" {
+		b := &strings.Builder{}
+		b.WriteString(translate(lang, msgHelp, nil))
+		for _, cmd := range commands {
+			b.WriteString("\n")
+			b.WriteString(formatCommandHelp(cmd, lang))
+		}
+
+		h.sendMSG(tg.NewMessage(chatID, b.String()))
+
+		return
+	}
+
+	cmd, ok := findCommand(name)
+	if !ok {
+		h.sendText(chatID, lang, msgHelpUnknown, msgArgs{"Command": name})
+
+		return
+	}
+
+	h.sendMSG(tg.NewMessage(chatID, formatCommandHelp(cmd, lang)))
+}
+
+// formatCommandHelp - renders the command with the description and the usage examples,
+// e.g. "/today - CSV report of today\n    /today\n    /today --refresh\n".
+func formatCommandHelp(cmd command, lang string) string {
+	b := &strings.Builder{}
+	b.WriteString("/" + cmd.name + " - " + translate(lang, cmd.description, nil) + "\n")
+	for _, example := range cmd.examples {
+		b.WriteString("    " + example + "\n")
+	}
+
+	return b.String()
+}
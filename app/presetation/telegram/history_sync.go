@@ -0,0 +1,105 @@
+package telegram
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+// historySyncInterval - every sync makes one statement request per user, so the history is collected
+// gradually, MonoBank allows one statement request per minute.
+const historySyncInterval = 5 * time.Minute
+
+// HistoryUC - represents a use-case interface for keeping the history of the account's transactions.
+type HistoryUC interface {
+	Sync(userID uuid.UUID, token, account string, now time.Time) (model.HistoryState, error)
+	State(account string, now time.Time) (model.HistoryState, error)
+	Get(account string, from, to time.Time) ([]model.Transaction, error)
+	Users() ([]uuid.UUID, error)
+}
+
+// NewHistorySync - builds "HistorySync" keeping the transaction history of the users up to date.
+func NewHistorySync(historyUC HistoryUC, tokenUC TokenUC, accountUC AccountUC, log Logger) *HistorySync {
+	return &HistorySync{
+		historyUC: historyUC,
+		tokenUC:   tokenUC,
+		accountUC: accountUC,
+		log:       log,
+	}
+}
+
+// HistorySync - fetches the transactions since the last sync of the users who requested the reports
+// or the subscriptions, so the reports are built from the history.
+type HistorySync struct {
+	historyUC HistoryUC
+	tokenUC   TokenUC
+	accountUC AccountUC
+	log       Logger
+}
+
+// Run - syncs the history until ctx is canceled, the first sync is made at once.
+func (s *HistorySync) Run(ctx context.Context) error {
+	ticker := time.NewTicker(historySyncInterval)
+	defer ticker.Stop()
+
+	for {
+		s.sync(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *HistorySync) sync(ctx context.Context) {
+	users, err := s.historyUC.Users()
+	if err != nil {
+		s.log.Errorf("can't get history users: err=%+v", err)
+
+		return
+	}
+
+	for _, userID := range users {
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err := s.syncUser(userID, time.Now()); err != nil {
+			s.log.Errorf("can't sync history: user=%v err=%+v", userID, err)
+		}
+	}
+}
+
+// syncUser - syncs the user's history, the users without token or account are skipped,
+// the rate limited request is made on the next sync.
+func (s *HistorySync) syncUser(userID uuid.UUID, now time.Time) error {
+	token, err := s.tokenUC.Get(userID)
+	if err == model.ErrNil {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	account, err := s.accountUC.Get(userID)
+	if err == model.ErrNil {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if _, err := s.historyUC.Sync(userID, token, account, now); errors.Cause(err) != model.ErrRateLimited {
+		return err
+	}
+
+	return nil
+}
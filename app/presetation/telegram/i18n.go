@@ -0,0 +1,102 @@
+package telegram
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+// msgKey - the key of a message of the catalog.
+type msgKey string
+
+// msgArgs - the named arguments of a message template.
+type msgArgs map[string]interface{}
+
+// LanguageUC - represents a use-case interface for the user's language of the bot messages.
+type LanguageUC interface {
+	Language(userID uuid.UUID) (string, error)
+}
+
+//nolint:gochecknoglobals
+var templates = parseCatalog()
+
+// parseCatalog - parses the message templates of every language, the templates have the functions:
+// "plural" selecting the plural form by the number, "month" and "weekday" naming time.Month and time.Weekday.
+func parseCatalog() map[string]map[msgKey]*template.Template {
+	funcs := map[string]template.FuncMap{
+		model.LanguageEN: {
+			"plural":  pluralEN,
+			"month":   func(m time.Month) string { return m.String() },
+			"weekday": func(d time.Weekday) string { return d.String() },
+		},
+		model.LanguageUK: {
+			"plural":  pluralUK,
+			"month":   func(m time.Month) string { return monthsUK[m-1] },
+			"weekday": func(d time.Weekday) string { return weekdaysUK[d] },
+		},
+	}
+
+	parsed := make(map[string]map[msgKey]*template.Template, len(catalog))
+	for lang, messages := range catalog {
+		parsed[lang] = make(map[msgKey]*template.Template, len(messages))
+		for key, text := range messages {
+			parsed[lang][key] = template.Must(template.New(string(key)).
+				Funcs(funcs[lang]).Option("missingkey=error").Parse(text))
+		}
+	}
+
+	return parsed
+}
+
+// translate - renders the message in the language, in English if the language isn't supported.
+// The key is returned if the message can't be rendered, e.g. an argument is missing.
+func translate(lang string, key msgKey, a msgArgs) string {
+	t, ok := templates[lang][key]
+	if !ok {
+		t, ok = templates[model.LanguageEN][key]
+	}
+
+	b := &strings.Builder{}
+	if !ok || t.Execute(b, a) != nil {
+		return string(key)
+	}
+
+	return b.String()
+}
+
+// validationText - translates the message of the validation error, the use-cases build it in English.
+func validationText(lang string, err model.ValidationError) string {
+	format, ok := validationCatalog[lang][err.Format]
+	if !ok {
+		return err.Msg
+	}
+
+	return fmt.Sprintf(format, err.Args...)
+}
+
+// pluralEN - selects the English plural form: one (1) or other (0, 2, 5).
+func pluralEN(n int, one, other string) string {
+	if n == 1 {
+		return one
+	}
+
+	return other
+}
+
+// pluralUK - selects the Ukrainian plural form: one (1, 21), few (2-4, 22-24) or many (0, 5-20, 25).
+func pluralUK(n int, one, few, many string) string {
+	n = int(abs(int64(n)))
+	switch {
+	case n%10 == 1 && n%100 != 11:
+		return one
+	case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+		return few
+	default:
+		return many
+	}
+}
@@ -0,0 +1,219 @@
+package telegram
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+//nolint:gochecknoglobals
+var (
+	templateFieldRegexp = regexp.MustCompile(`\.([A-Z]\w*)`)
+	formatVerbRegexp    = regexp.MustCompile(`%[-+# 0-9.]*[a-zA-Z]`)
+)
+
+func TestCatalog(t *testing.T) {
+	RegisterTestingT(t)
+
+	keys := messageKeys(t)
+	Ω(keys).ShouldNot(BeEmpty(), errNotEqual)
+	Ω(catalog).Should(HaveLen(2), errNotEqual)
+
+	for lang, messages := range catalog {
+		for key := range keys {
+			Ω(messages).Should(HaveKey(key), "missing %q translation of %q", lang, key)
+		}
+
+		for key := range messages {
+			Ω(keys).Should(HaveKey(key), "%q translation of the unknown message %q", lang, key)
+		}
+	}
+
+	for key, text := range catalog[model.LanguageUK] {
+		fields := templateFields(catalog[model.LanguageEN][key])
+		for field := range templateFields(text) {
+			Ω(fields).Should(HaveKey(field), "%q: unknown argument %q", key, field)
+		}
+	}
+}
+
+func TestValidationCatalog(t *testing.T) {
+	RegisterTestingT(t)
+
+	formats := validationFormats(t)
+	Ω(formats).ShouldNot(BeEmpty(), errNotEqual)
+
+	for format := range formats {
+		translated, ok := validationCatalog[model.LanguageUK][format]
+		Ω(ok).Should(BeTrue(), "missing translation of %q", format)
+		Ω(formatVerbRegexp.FindAllString(translated, -1)).
+			Should(Equal(formatVerbRegexp.FindAllString(format, -1)), "%q: verbs don't match", format)
+	}
+
+	for format := range validationCatalog[model.LanguageUK] {
+		Ω(formats).Should(HaveKey(format), "translation of the unknown format %q", format)
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	RegisterTestingT(t)
+
+	Ω(translate(model.LanguageEN, msgSubsFooter, nil)).ShouldNot(BeEmpty(), errNotEqual)
+	Ω(translate("fr", msgTokenNever, nil)).To(Equal(translate(model.LanguageEN, msgTokenNever, nil)), errNotEqual)
+	Ω(translate(model.LanguageEN, msgSubsTotal, nil)).To(Equal(string(msgSubsTotal)), errNotEqual)
+	Ω(translate(model.LanguageEN, "unknown", nil)).To(Equal("unknown"), errNotEqual)
+
+	format := "unknown language %q, the languages are: %s, %s"
+	err, ok := model.NewValidationError(format, "fr", "uk", "en").(model.ValidationError)
+	Ω(ok).Should(BeTrue(), errNotEqual)
+	Ω(validationText(model.LanguageEN, err)).To(Equal(err.Msg), errNotEqual)
+	Ω(validationText(model.LanguageUK, err)).ShouldNot(Equal(err.Msg), errNotEqual)
+	Ω(validationText(model.LanguageUK, err)).Should(ContainSubstring(`"fr"`), errNotEqual)
+}
+
+func TestPlural(t *testing.T) {
+	RegisterTestingT(t)
+
+	Ω(pluralEN(1, "one", "other")).To(Equal("one"), errNotEqual)
+	Ω(pluralEN(0, "one", "other")).To(Equal("other"), errNotEqual)
+	Ω(pluralEN(5, "one", "other")).To(Equal("other"), errNotEqual)
+
+	for n, form := range map[int]string{
+		1: "one", 21: "one", 101: "one", -1: "one",
+		2: "few", 4: "few", 22: "few", 34: "few",
+		0: "many", 5: "many", 11: "many", 12: "many", 14: "many", 20: "many", 111: "many",
+	} {
+		Ω(pluralUK(n, "one", "few", "many")).To(Equal(form), "n=%d", n)
+	}
+}
+
+// messageKeys - returns the msgKey constants of the package.
+func messageKeys(t *testing.T) map[msgKey]bool {
+	keys := make(map[msgKey]bool)
+
+	for _, file := range parseFiles(t, ".") {
+		ast.Inspect(file, func(n ast.Node) bool {
+			spec, ok := n.(*ast.ValueSpec)
+			if !ok || spec.Type == nil {
+				return true
+			}
+
+			if ident, ok := spec.Type.(*ast.Ident); !ok || ident.Name != "msgKey" {
+				return true
+			}
+
+			for _, v := range spec.Values {
+				if value, ok := stringLiteral(v); ok {
+					keys[msgKey(value)] = true
+				}
+			}
+
+			return true
+		})
+	}
+
+	return keys
+}
+
+// validationFormats - returns the formats of the model.NewValidationError calls of the application.
+func validationFormats(t *testing.T) map[string]bool {
+	formats := make(map[string]bool)
+
+	err := filepath.Walk("../..", func(path string, info os.FileInfo, err error) error {
+		if err != nil || !info.IsDir() {
+			return err
+		}
+
+		for _, file := range parseFiles(t, path) {
+			ast.Inspect(file, func(n ast.Node) bool {
+				call, ok := n.(*ast.CallExpr)
+				if !ok || len(call.Args) == 0 {
+					return true
+				}
+
+				if sel, ok := call.Fun.(*ast.SelectorExpr); !ok || sel.Sel.Name != "NewValidationError" {
+					return true
+				}
+
+				if format, ok := stringLiteral(call.Args[0]); ok {
+					formats[format] = true
+				}
+
+				return true
+			})
+		}
+
+		return nil
+	})
+	Ω(err).To(BeNil(), errNotEqual)
+
+	return formats
+}
+
+// parseFiles - parses the non-test Go files of the directory.
+func parseFiles(t *testing.T, dir string) []*ast.File {
+	pkgs, err := parser.ParseDir(token.NewFileSet(), dir, func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var files []*ast.File
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			files = append(files, file)
+		}
+	}
+
+	return files
+}
+
+// stringLiteral - returns the value of the string literal, concatenated by "+" too.
+func stringLiteral(expr ast.Expr) (string, bool) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		if e.Kind != token.STRING {
+			return "", false
+		}
+
+		value, err := strconv.Unquote(e.Value)
+
+		return value, err == nil
+	case *ast.BinaryExpr:
+		if e.Op != token.ADD {
+			return "", false
+		}
+
+		x, ok := stringLiteral(e.X)
+		if !ok {
+			return "", false
+		}
+
+		y, ok := stringLiteral(e.Y)
+
+		return x + y, ok
+	default:
+		return "", false
+	}
+}
+
+// templateFields - returns the argument names used by the message template.
+func templateFields(text string) map[string]bool {
+	fields := make(map[string]bool)
+	for _, m := range templateFieldRegexp.FindAllStringSubmatch(text, -1) {
+		fields[m[1]] = true
+	}
+
+	return fields
+}
@@ -0,0 +1,232 @@
+package telegram
+
+import (
+	"strconv"
+	"strings"
+
+	tg "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+const (
+	// inlineCacheTime - the seconds Telegram caches the answer of the same query of the user.
+	inlineCacheTime = 60
+	// inlineMaxResults - the number of the articles of the answer, Telegram allows up to 50.
+	inlineMaxResults = 20
+	// inlineStartParameter - the parameter of the "/start" command sent by the button of the answer.
+	inlineStartParameter = "inline"
+)
+
+// balanceQueries - the inline queries answered with the balances.
+var balanceQueries = map[string]bool{"": true, "balance": true, "баланс": true} //nolint:gochecknoglobals
+
+// currencyNames - the ISO 4217 codes of the currencies of MonoBank accounts.
+var currencyNames = map[int]string{ //nolint:gochecknoglobals
+	980: "UAH", 840: "USD", 978: "EUR", 985: "PLN", 826: "GBP",
+}
+
+// InlineUC - represents a use-case interface answering the inline queries from the cached MonoBank data.
+type InlineUC interface {
+	ClientInfo(token string) (model.ClientInfo, error)
+	Search(token, account string, userID uuid.UUID, q model.SearchQuery) (model.SearchResult, error)
+}
+
+// NewInlineQuery - builds "InlineQuery" internal handler.
+func NewInlineQuery(inlineUC InlineUC, tokenUC TokenUC, accountUC AccountUC, transactionUC TransactionUC,
+	botWrapper *BotWrapper) *InlineQuery {
+	return &InlineQuery{
+		inlineUC:      inlineUC,
+		tokenUC:       tokenUC,
+		accountUC:     accountUC,
+		transactionUC: transactionUC,
+		BotWrapper:    botWrapper,
+	}
+}
+
+// InlineQuery - represents an internal handler of the inline queries "@bot balance", "@bot today",
+// "@bot coffee", so the user can check or share the numbers from any chat.
+type InlineQuery struct {
+	inlineUC      InlineUC
+	tokenUC       TokenUC
+	accountUC     AccountUC
+	transactionUC TransactionUC
+	*BotWrapper
+}
+
+// Requires - nothing, the inline query is answered with the setup button if the token or the account
+// isn't set, so the handler loads them itself.
+func (q *InlineQuery) Requires() Requirement {
+	return 0
+}
+
+// Handle - answers the inline query: the balances if it is empty or "balance", the transactions
+// of the period if it is a period, e.g. "today", otherwise the found transactions, see parseSearch.
+func (q *InlineQuery) Handle(r *Request) {
+	query, lang, userID := r.InlineQuery, r.Lang, r.UserID
+	if userID == uuid.Nil {
+		q.answerErr(query.ID, lang, model.ErrNil)
+
+		return
+	}
+
+	token, err := q.tokenUC.Get(userID)
+	if err != nil {
+		q.answerErr(query.ID, lang, err)
+
+		return
+	}
+
+	text := strings.TrimSpace(query.Query)
+	if balanceQueries[strings.ToLower(text)] {
+		info, err := q.inlineUC.ClientInfo(token)
+		if err != nil {
+			q.answerErr(query.ID, lang, err)
+
+			return
+		}
+
+		q.answer(tg.InlineConfig{InlineQueryID: query.ID, Results: balanceResults(info, lang)})
+
+		return
+	}
+
+	account, err := q.accountUC.Get(userID)
+	if err != nil {
+		q.answerErr(query.ID, lang, err)
+
+		return
+	}
+
+	var search model.SearchQuery
+	if from, to, err := q.transactionUC.ParseDate(userID, text); err == nil {
+		search.From, search.To = from, to
+	} else if search, err = parseSearch(q.transactionUC, userID, text); err != nil {
+		q.answerErr(query.ID, lang, err)
+
+		return
+	}
+
+	result, err := q.inlineUC.Search(token, account, userID, search)
+	if err != nil {
+		q.answerErr(query.ID, lang, err)
+
+		return
+	}
+
+	q.answer(tg.InlineConfig{
+		InlineQueryID: query.ID,
+		Results:       searchResults(result, lang, q.transactionUC.Locale(userID)),
+	})
+}
+
+// answer - sends the personal answer cached by Telegram for inlineCacheTime.
+func (q *InlineQuery) answer(config tg.InlineConfig) {
+	config.CacheTime = inlineCacheTime
+	config.IsPersonal = true
+	if config.Results == nil {
+		config.Results = make([]interface{}, 0)
+	}
+
+	if _, err := q.bot.AnswerInlineQuery(config); err != nil {
+		q.log.Errorf("can't answer inline query: err=%+v", err)
+	}
+}
+
+// answerErr - answers without results, the button opens the chat with the bot: to set up the token
+// and the account if they aren't set, otherwise it explains the error.
+func (q *InlineQuery) answerErr(queryID, lang string, err error) {
+	var text string
+	switch cause := errors.Cause(err).(type) {
+	case model.ValidationError:
+		text = validationText(lang, cause)
+	default:
+		switch cause {
+		case model.ErrNil:
+			text = translate(lang, msgInlineSetup, nil)
+		case model.ErrRateLimited:
+			text = translate(lang, msgInlineBusy, nil)
+		default:
+			q.log.Error(ErrStack(err))
+			text = translate(lang, msgInlineFailed, nil)
+		}
+	}
+
+	q.answer(tg.InlineConfig{InlineQueryID: queryID, SwitchPMText: text, SwitchPMParameter: inlineStartParameter})
+}
+
+// balanceResults - returns an article with the balance of every account.
+func balanceResults(info model.ClientInfo, lang string) []interface{} {
+	results := make([]interface{}, 0, len(info.Accounts))
+	for _, account := range info.Accounts {
+		card := account.Type
+		if len(account.MaskedPan) > 0 {
+			card = account.MaskedPan[0]
+		}
+
+		currency, ok := currencyNames[account.CurrencyCode]
+		if !ok {
+			currency = strconv.Itoa(account.CurrencyCode)
+		}
+
+		text := translate(lang, msgInlineBalance, msgArgs{
+			"Card":     card,
+			"Balance":  formatAmount(int64(account.Balance)),
+			"Currency": currency,
+		})
+		results = append(results, tg.NewInlineQueryResultArticle(account.ID, text, text))
+	}
+
+	return results
+}
+
+// searchResults - returns the article with all the found transactions followed by an article
+// with every transaction, the latest first.
+func searchResults(r model.SearchResult, lang string, locale model.Locale) []interface{} {
+	if len(r.Transactions) == 0 {
+		text := translate(lang, msgSearchNothing, msgArgs{
+			"Text": r.Text,
+			"From": r.From.Format(locale.DateTime),
+			"To":   r.To.Format(locale.DateTime),
+		})
+
+		return []interface{}{tg.NewInlineQueryResultArticle("nothing", translate(lang, msgInlineNothing, nil), text)}
+	}
+
+	var total int64
+	for _, tr := range r.Transactions {
+		total += tr.Amount
+	}
+
+	from, to := r.From.Format(locale.DateTime), r.To.Format(locale.DateTime)
+	a := msgArgs{"Count": len(r.Transactions), "Total": formatAmount(total), "Text": r.Text, "From": from, "To": to}
+	b := &strings.Builder{}
+	b.WriteString(translate(lang, msgInlineSummary, a))
+
+	articles := make([]interface{}, 0, inlineMaxResults)
+	for i, tr := range r.Transactions {
+		if i == searchMaxLines {
+			b.WriteString(translate(lang, msgInlineMore, msgArgs{"Count": len(r.Transactions) - searchMaxLines}))
+
+			break
+		}
+
+		line := formatFound(tr, locale)
+		b.WriteString(line)
+		b.WriteString("\n")
+
+		if len(articles) < inlineMaxResults-1 {
+			article := tg.NewInlineQueryResultArticle(strconv.Itoa(i), foundDescription(tr)+" "+formatAmount(tr.Amount),
+				line)
+			article.Description = tr.Time.Format(locale.DateTime) + " " + tr.Category
+			articles = append(articles, article)
+		}
+	}
+
+	summary := tg.NewInlineQueryResultArticle("summary", translate(lang, msgInlineFound, a), b.String())
+	summary.Description = from + " - " + to
+
+	return append([]interface{}{summary}, articles...)
+}
@@ -0,0 +1,55 @@
+package telegram
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	tg "github.com/go-telegram-bot-api/telegram-bot-api"
+	. "github.com/onsi/gomega"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+func TestBalanceResults(t *testing.T) {
+	RegisterTestingT(t)
+	var info model.ClientInfo
+	err := json.Unmarshal([]byte(`{"accounts": [
+		{"id": "a1", "currencyCode": 980, "balance": 123456, "maskedPan": ["537541******1234"], "type": "black"},
+		{"id": "a2", "currencyCode": 36, "balance": -500, "type": "iron"}
+	]}`), &info)
+	Ω(err).To(BeNil(), errNotEqual)
+
+	results := balanceResults(info, "en")
+	Ω(results).To(HaveLen(2), errNotEqual)
+	Ω(results[0].(tg.InlineQueryResultArticle).Title).To(Equal("537541******1234: 1 234.56 UAH"), errNotEqual)
+	Ω(results[1].(tg.InlineQueryResultArticle).Title).To(Equal("iron: -5.00 36"), errNotEqual)
+}
+
+func TestSearchResults(t *testing.T) {
+	RegisterTestingT(t)
+	from := time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2020, 3, 1, 23, 59, 0, 0, time.UTC)
+	uk, _ := model.FindLocale("uk")
+	result := model.SearchResult{SearchQuery: model.SearchQuery{From: from, To: to}}
+
+	results := searchResults(result, "en", uk)
+	Ω(results).To(HaveLen(1), errNotEqual)
+	Ω(results[0].(tg.InlineQueryResultArticle).Title).To(Equal("Nothing found"), errNotEqual)
+
+	result.Transactions = []model.FoundTransaction{
+		{Time: from.Add(12 * time.Hour), Description: "Coffee House", Category: "Cafe", Amount: -6500},
+		{Time: from.Add(9 * time.Hour), Description: "Coffee House", Category: "Cafe", Amount: -7000},
+	}
+	results = searchResults(result, "en", uk)
+	Ω(results).To(HaveLen(3), errNotEqual)
+
+	summary := results[0].(tg.InlineQueryResultArticle)
+	Ω(summary.Title).To(Equal("2 transactions, -135.00"), errNotEqual)
+	Ω(summary.InputMessageContent).To(Equal(tg.InputTextMessageContent{
+		Text: "01.03.2020 00:00 - 01.03.2020 23:59: 2 transactions, -135.00\n" +
+			"01.03.2020 12:00 Coffee House [Cafe] -65.00\n" +
+			"01.03.2020 09:00 Coffee House [Cafe] -70.00\n",
+	}), errNotEqual)
+	Ω(results[1].(tg.InlineQueryResultArticle).Title).To(Equal("Coffee House -65.00"), errNotEqual)
+}
@@ -1,11 +1,17 @@
 package telegram
 
 import (
+	"fmt"
 	"io"
 	"net/url"
+	"strconv"
+	"strings"
 
 	tg "github.com/go-telegram-bot-api/telegram-bot-api"
 	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
 )
 
 // MappingUC - represents a usecase interface for processing category mapping business logic.
@@ -13,64 +19,185 @@ type MappingUC interface {
 	Validate(name string) error
 	Parse(userID uuid.UUID, r io.Reader) error
 	GetFile(u *url.URL) (io.ReadCloser, error)
+	Get(userID uuid.UUID) ([]model.CategoryMapping, error)
+	Set(userID uuid.UUID, mapping []model.CategoryMapping) error
 }
 
+// Steps of the mapping dialog.
+const (
+	mappingCategoryStep = "category"
+	mappingAppStep      = "app"
+	// the keys of the chosen category in the dialog data.
+	mappingMonoKey = "mono"
+	mappingDescKey = "description"
+)
+
 // NewMapping - builds "NewMapping" internal handler.
-func NewMapping(mappingUC MappingUC, chatUserUC ChatUserUC, botWrapper *BotWrapper) *Mapping {
-	return &Mapping{
+func NewMapping(mappingUC MappingUC, dialogUC DialogUC, botWrapper *BotWrapper) *Mapping {
+	m := &Mapping{
 		mappingUC:  mappingUC,
-		chatUserUC: chatUserUC,
 		BotWrapper: botWrapper,
 	}
+	m.flow = newDialogFlow(flowMapping, map[string]dialogStep{
+		mappingCategoryStep: {ask: m.askCategory, answer: m.answerCategory},
+		mappingAppStep:      {ask: m.askApp, answer: m.answerApp},
+	}, dialogUC, botWrapper)
+
+	return m
 }
 
-// Mapping - represents an internal handler for processing category mapping.
+// Mapping - represents an internal handler for processing category mapping: the uploaded file
+// replaces the mapping, "/mapping" edits the category of it.
 type Mapping struct {
-	mappingUC  MappingUC
-	chatUserUC ChatUserUC
+	mappingUC MappingUC
+	flow      *dialogFlow
 	*BotWrapper
 }
 
+// Requires - the mapping is saved for the user of the chat.
+func (m *Mapping) Requires() Requirement {
+	return RequireUser
+}
+
 // Handle - process category mapping, send the result to the user.
-func (m *Mapping) Handle(u tg.Update) {
-	if err := m.mappingUC.Validate(u.Message.Document.FileName); err != nil {
-		m.sendDefaultErr(u.Message.Chat.ID, err)
+func (m *Mapping) Handle(r *Request) {
+	switch {
+	case r.Dialog != nil:
+		m.flow.resume(r)
+	case r.Message.Document != nil:
+		m.upload(r)
+	default:
+		m.edit(r)
+	}
+}
+
+// upload - replaces the mapping by the uploaded file.
+func (m *Mapping) upload(r *Request) {
+	chatID, lang := r.ChatID, r.Lang
+	if err := m.mappingUC.Validate(r.Message.Document.FileName); err != nil {
+		m.sendDefaultErr(chatID, lang, err)
 
 		return
 	}
 
-	fileTG, err := m.bot.GetFile(tg.FileConfig{FileID: u.Message.Document.FileID})
+	fileTG, err := m.bot.GetFile(tg.FileConfig{FileID: r.Message.Document.FileID})
 	if err != nil {
-		m.sendDefaultErr(u.Message.Chat.ID, err)
+		m.sendDefaultErr(chatID, lang, err)
 
 		return
 	}
 
 	fileURL, err := url.Parse(fileTG.Link(m.bot.Token))
 	if err != nil {
-		m.sendDefaultErr(u.Message.Chat.ID, err)
+		m.sendDefaultErr(chatID, lang, err)
 
 		return
 	}
 
 	file, err := m.mappingUC.GetFile(fileURL)
 	if err != nil {
-		m.sendDefaultErr(u.Message.Chat.ID, err)
+		m.sendDefaultErr(chatID, lang, err)
 
 		return
 	}
 
-	userID, err := m.chatUserUC.GetChatUserID(u.Message.Chat.ID)
-	if err != nil {
-		m.sendDefaultErr(u.Message.Chat.ID, err)
+	if err := m.mappingUC.Parse(r.UserID, file); err != nil {
+		m.sendDefaultErr(chatID, lang, err)
 
 		return
 	}
+	m.sendText(chatID, lang, msgMappingLoaded, nil)
+}
 
-	if err := m.mappingUC.Parse(userID, file); err != nil {
-		m.sendDefaultErr(u.Message.Chat.ID, err)
+// edit - starts the dialog editing the category of the mapping.
+func (m *Mapping) edit(r *Request) {
+	mapping, err := m.mappingUC.Get(r.UserID)
+	if err == model.ErrNil || err == nil && len(mapping) == 0 {
+		m.sendText(r.ChatID, r.Lang, msgMappingNone, nil)
 
 		return
 	}
-	m.sendMSG(tg.NewMessage(u.Message.Chat.ID, "mapping successfully loaded"))
+
+	if err != nil {
+		m.sendDefaultErr(r.ChatID, r.Lang, err)
+
+		return
+	}
+
+	m.flow.start(r, mappingCategoryStep)
+}
+
+func (m *Mapping) askCategory(r *Request) error {
+	mapping, err := m.mappingUC.Get(r.UserID)
+	if err != nil {
+		return err
+	}
+
+	var b strings.Builder
+	b.WriteString(translate(r.Lang, msgMappingAsk, nil))
+	for i, c := range mapping {
+		fmt.Fprintf(&b, "\n%d. %s %s → %s", i+1, c.Mono, c.Description, c.App)
+	}
+	m.sendMSG(tg.NewMessage(r.ChatID, b.String()))
+
+	return nil
+}
+
+// answerCategory - keeps the category chosen by the number of the list, the category is kept
+// instead of the number, so the changes of the mapping during the dialog don't change the choice.
+func (m *Mapping) answerCategory(r *Request, answer string) (string, error) {
+	mapping, err := m.mappingUC.Get(r.UserID)
+	if err != nil {
+		return "", err
+	}
+
+	n, err := strconv.Atoi(answer)
+	if err != nil || n < 1 || n > len(mapping) {
+		return "", model.NewValidationError("the number must be from 1 to %d", len(mapping))
+	}
+
+	r.Dialog.Data[mappingMonoKey] = mapping[n-1].Mono
+	r.Dialog.Data[mappingDescKey] = mapping[n-1].Description
+
+	return mappingAppStep, nil
+}
+
+func (m *Mapping) askApp(r *Request) error {
+	m.sendText(r.ChatID, r.Lang, msgMappingAskApp, msgArgs{
+		"Category": strings.TrimSpace(r.Dialog.Data[mappingMonoKey] + " " + r.Dialog.Data[mappingDescKey]),
+	})
+
+	return nil
+}
+
+// answerApp - saves the new application category of the chosen category, it ends the dialog.
+func (m *Mapping) answerApp(r *Request, app string) (string, error) {
+	if app == "" {
+		return "", model.NewValidationError("the category must not be empty")
+	}
+
+	mapping, err := m.mappingUC.Get(r.UserID)
+	if err != nil {
+		return "", err
+	}
+
+	mono, desc := r.Dialog.Data[mappingMonoKey], r.Dialog.Data[mappingDescKey]
+	found := false
+	for i := range mapping {
+		if mapping[i].Mono == mono && mapping[i].Description == desc {
+			mapping[i].App, found = app, true
+		}
+	}
+
+	if !found {
+		return "", errors.Errorf("category isn't found: mono=%s description=%s", mono, desc)
+	}
+
+	if err := m.mappingUC.Set(r.UserID, mapping); err != nil {
+		return "", err
+	}
+
+	m.sendText(r.ChatID, r.Lang, msgMappingChanged, msgArgs{"App": app})
+
+	return dialogEnd, nil
 }
@@ -0,0 +1,579 @@
+package telegram
+
+import (
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+// Messages of the catalog, every message must be translated to every language, see TestCatalog.
+const (
+	msgDefaultErr      msgKey = "default_err"
+	msgBusy            msgKey = "busy"
+	msgInvalidInput    msgKey = "invalid_input"
+	msgInvalidUsage    msgKey = "invalid_usage"
+	msgSetAccount      msgKey = "set_account"
+	msgRateLimited     msgKey = "rate_limited"
+	msgNotAvailable    msgKey = "not_available"
+	msgPeriodDaily     msgKey = "period_daily"
+	msgPeriodWeekly    msgKey = "period_weekly"
+	msgPeriodMonthly   msgKey = "period_monthly"
+	msgAccountSet      msgKey = "account_set"
+	msgChatUserSet     msgKey = "chat_user_set"
+	msgMappingLoaded   msgKey = "mapping_loaded"
+	msgClientAccount   msgKey = "client_account"
+	msgTokenRejected   msgKey = "token_rejected"
+	msgTokenSet        msgKey = "token_set"
+	msgTokenNotSet     msgKey = "token_not_set"
+	msgTokenStatus     msgKey = "token_status"
+	msgTokenNever      msgKey = "token_never"
+	msgAPIKeyNew       msgKey = "api_key_new"
+	msgAPIKeyRead      msgKey = "api_key_read"
+	msgAPIKeyManage    msgKey = "api_key_manage"
+	msgAPIKeyNone      msgKey = "api_key_none"
+	msgAPIKeyRevoked   msgKey = "api_key_revoked"
+	msgAPIKeyUsage     msgKey = "api_key_usage"
+	msgScheduleUsage   msgKey = "schedule_usage"
+	msgScheduleUnknown msgKey = "schedule_unknown"
+	msgScheduleDeleted msgKey = "schedule_deleted"
+	msgScheduleAdded   msgKey = "schedule_added"
+	msgScheduleNone    msgKey = "schedule_none"
+	msgScheduleDaily   msgKey = "schedule_daily"
+	msgScheduleWeekly  msgKey = "schedule_weekly"
+	msgScheduleMonthly msgKey = "schedule_monthly"
+	msgSchedule        msgKey = "schedule"
+	msgScheduledToken  msgKey = "scheduled_token"
+	msgScheduledAcc    msgKey = "scheduled_account"
+	msgScheduledReport msgKey = "scheduled_report"
+	msgChartCaption    msgKey = "chart_caption"
+	msgChartExpenses   msgKey = "chart_expenses"
+	msgChartNoExpenses msgKey = "chart_no_expenses"
+	msgChartOther      msgKey = "chart_other"
+	msgSummary         msgKey = "summary"
+	msgSummaryTop      msgKey = "summary_top"
+	msgSummaryCategory msgKey = "summary_category"
+	msgSummaryBiggest  msgKey = "summary_biggest"
+	msgSummaryPurchase msgKey = "summary_purchase"
+	msgSummaryNoPrev   msgKey = "summary_no_previous"
+	msgSummaryPrev     msgKey = "summary_previous"
+	msgSearchUsage     msgKey = "search_usage"
+	msgSearchNothing   msgKey = "search_nothing"
+	msgSearchFound     msgKey = "search_found"
+	msgSearchMore      msgKey = "search_more"
+	msgInlineSetup     msgKey = "inline_setup"
+	msgInlineBusy      msgKey = "inline_busy"
+	msgInlineFailed    msgKey = "inline_failed"
+	msgInlineBalance   msgKey = "inline_balance"
+	msgInlineNothing   msgKey = "inline_nothing"
+	msgInlineFound     msgKey = "inline_found"
+	msgInlineSummary   msgKey = "inline_summary"
+	msgInlineMore      msgKey = "inline_more"
+	msgUnknownCommand  msgKey = "unknown_command"
+	msgTooManyRequests msgKey = "too_many_requests"
+	msgNoUser          msgKey = "no_user"
+	msgNoToken         msgKey = "no_token"
+	msgHelp            msgKey = "help"
+	msgHelpUnknown     msgKey = "help_unknown"
+	msgStart           msgKey = "start"
+	msgStartReady      msgKey = "start_ready"
+	msgStepUser        msgKey = "step_user"
+	msgStepUserHow     msgKey = "step_user_how"
+	msgStepToken       msgKey = "step_token"
+	msgStepTokenHow    msgKey = "step_token_how"
+	msgStepAccount     msgKey = "step_account"
+	msgStepAccountHow  msgKey = "step_account_how"
+	msgStepMapping     msgKey = "step_mapping"
+	msgStepMappingHow  msgKey = "step_mapping_how"
+	msgCmdStart        msgKey = "cmd_start"
+	msgCmdHelp         msgKey = "cmd_help"
+	msgCmdGet          msgKey = "cmd_get"
+	msgCmdToday        msgKey = "cmd_today"
+	msgCmdMonth        msgKey = "cmd_month"
+	msgCmdSummary      msgKey = "cmd_summary"
+	msgCmdChart        msgKey = "cmd_chart"
+	msgCmdSearch       msgKey = "cmd_search"
+	msgCmdBudget       msgKey = "cmd_budget"
+	msgCmdSubscription msgKey = "cmd_subscriptions"
+	msgCmdSchedule     msgKey = "cmd_schedule"
+	msgCmdInfo         msgKey = "cmd_info"
+	msgCmdAccount      msgKey = "cmd_account"
+	msgCmdToken        msgKey = "cmd_token"
+	msgCmdUser         msgKey = "cmd_user"
+	msgCmdAPIKey       msgKey = "cmd_apikey"
+	msgCmdTimezone     msgKey = "cmd_timezone"
+	msgCmdLocale       msgKey = "cmd_locale"
+	msgCmdLang         msgKey = "cmd_lang"
+	msgCmdExport       msgKey = "cmd_export"
+	msgCmdMapping      msgKey = "cmd_mapping"
+	msgCmdCancel       msgKey = "cmd_cancel"
+	msgBudgetUsage     msgKey = "budget_usage"
+	msgBudgetUnknown   msgKey = "budget_unknown"
+	msgBudgetDeleted   msgKey = "budget_deleted"
+	msgBudgetSet       msgKey = "budget_set"
+	msgBudgetNone      msgKey = "budget_none"
+	msgBudgets         msgKey = "budgets"
+	msgBudgetStatus    msgKey = "budget_status"
+	msgBudgetExceeded  msgKey = "budget_exceeded"
+	msgBudgetAlert     msgKey = "budget_alert"
+	msgSubsOff         msgKey = "subscriptions_off"
+	msgSubsNone        msgKey = "subscriptions_none"
+	msgSubs            msgKey = "subscriptions"
+	msgSub             msgKey = "subscription"
+	msgSubsTotal       msgKey = "subscriptions_total"
+	msgSubsPartial     msgKey = "subscriptions_partial"
+	msgSubsFooter      msgKey = "subscriptions_footer"
+	msgSubPriceNote    msgKey = "subscription_price_note"
+	msgSubMissedNote   msgKey = "subscription_missed_note"
+	msgSubMissed       msgKey = "subscription_missed"
+	msgSubPrice        msgKey = "subscription_price"
+	msgTimezone        msgKey = "timezone"
+	msgTimezoneSet     msgKey = "timezone_set"
+	msgLocale          msgKey = "locale"
+	msgLocaleSet       msgKey = "locale_set"
+	msgLanguage        msgKey = "language"
+	msgLanguageSet     msgKey = "language_set"
+	msgCancelled       msgKey = "cancelled"
+	msgCancelNothing   msgKey = "cancel_nothing"
+	msgDialogExpired   msgKey = "dialog_expired"
+	msgExportAccount   msgKey = "export_account"
+	msgExportPeriod    msgKey = "export_period"
+	msgExportFormat    msgKey = "export_format"
+	msgExportToday     msgKey = "export_today"
+	msgExportWeek      msgKey = "export_week"
+	msgExportMonth     msgKey = "export_month"
+	msgExportLastMonth msgKey = "export_last_month"
+	msgExportYear      msgKey = "export_year"
+	msgExportSummary   msgKey = "export_summary"
+	msgExportChart     msgKey = "export_chart"
+	msgTokenAsk        msgKey = "token_ask"
+	msgMappingNone     msgKey = "mapping_none"
+	msgMappingAsk      msgKey = "mapping_ask"
+	msgMappingAskApp   msgKey = "mapping_ask_app"
+	msgMappingChanged  msgKey = "mapping_changed"
+	msgCalendarMonth   msgKey = "calendar_month"
+	msgCalendarDays    msgKey = "calendar_days"
+	msgCalendarTo      msgKey = "calendar_to"
+)
+
+// periodMessages - the names of the schedule and subscription periods.
+var periodMessages = map[string]msgKey{ //nolint:gochecknoglobals
+	model.PeriodDaily:   msgPeriodDaily,
+	model.PeriodWeekly:  msgPeriodWeekly,
+	model.PeriodMonthly: msgPeriodMonthly,
+}
+
+//nolint:gochecknoglobals
+var catalog = map[string]map[msgKey]string{
+	model.LanguageEN: {
+		msgDefaultErr:    "Sorry, I can't process this message, view the logs or contact the owner of the service.",
+		msgBusy:          "Sorry, I'm still processing your previous messages, please try again later.",
+		msgInvalidInput:  "{{.Error}}.",
+		msgInvalidUsage:  "{{.Error}}.\n\n{{.Usage}}",
+		msgSetAccount:    "Please set account.",
+		msgRateLimited:   "MonoBank allows one statement request per minute, please try again later.",
+		msgNotAvailable:  "n/a",
+		msgPeriodDaily:   "daily",
+		msgPeriodWeekly:  "weekly",
+		msgPeriodMonthly: "monthly",
+		msgAccountSet:    "successfully set account",
+		msgChatUserSet:   "successfully set ChatUser ID",
+		msgMappingLoaded: "mapping successfully loaded",
+		msgClientAccount: "id: {{.ID}}\n    currency code: {{.CurrencyCode}}\n    balance: {{.Balance}}\n" +
+			"    type: {{.Type}}\n\n",
+		msgTokenRejected: "MonoBank rejected the token, please check it and try again.",
+		msgTokenSet:      "successfully set token, client: {{.Client}}",
+		msgTokenNotSet:   "Token is not set.",
+		msgTokenStatus:   "token: {{.Token}}\nlast verified: {{.VerifiedAt}}",
+		msgTokenNever:    "never",
+		msgAPIKeyNew: "New {{.Scope}} API key, it is shown only once:\n{{.Key}}\n\n" +
+			"Send it in the header \"Authorization: Bearer <key>\", the previous key is revoked.",
+		msgAPIKeyRead:    "read-only",
+		msgAPIKeyManage:  "full access",
+		msgAPIKeyNone:    "There is no active API key.",
+		msgAPIKeyRevoked: "API key successfully revoked.",
+		msgAPIKeyUsage:   "Usage: /apikey new [manage] or /apikey revoke",
+		msgScheduleUsage: "Usage:\n" +
+			"/schedule daily 21:00 [csv|xlsx|summary]\n" +
+			"/schedule weekly mon 09:00 [csv|xlsx|summary]\n" +
+			"/schedule monthly 1 09:00 [csv|xlsx|summary]\n" +
+			"/schedule list\n" +
+			"/schedule delete <id>",
+		msgScheduleUnknown: "There is no schedule {{printf \"%q\" .ID}}.",
+		msgScheduleDeleted: "Schedule successfully deleted.",
+		msgScheduleAdded:   "Schedule successfully added:\n{{.Schedule}}",
+		msgScheduleNone:    "There are no schedules.\n\n{{.Usage}}",
+		msgScheduleDaily:   "daily",
+		msgScheduleWeekly:  "weekly on {{weekday .Weekday}}",
+		msgScheduleMonthly: "monthly on day {{.Day}}",
+		msgSchedule:        "{{.ID}}: {{.When}} at {{.Time}} ({{.Location}}), {{.Format}}",
+		msgScheduledToken:  "Scheduled report isn't sent: please set token.",
+		msgScheduledAcc:    "Scheduled report isn't sent: please set account.",
+		msgScheduledReport: "Scheduled {{.Period}} report ({{.ID}})",
+		msgChartCaption:    "Expenses {{.From}} - {{.To}}",
+		msgChartExpenses:   "Expenses",
+		msgChartNoExpenses: "There are no expenses in the period.",
+		msgChartOther:      "Other",
+		msgSummary: "Summary {{.From}} - {{.To}}\n" +
+			"Income: {{.Income}}\nExpenses: {{.Expenses}}\nNet: {{.Net}}\n",
+		msgSummaryTop:      "\nTop categories:\n",
+		msgSummaryCategory: "{{.N}}. {{.Category}} - {{.Amount}} ({{.Share}}%)\n",
+		msgSummaryBiggest:  "\nBiggest purchases:\n",
+		msgSummaryPurchase: "{{.Time}} {{.Description}} ({{.Category}}) - {{.Amount}}\n",
+		msgSummaryNoPrev: "\nComparison with the previous period is unavailable: " +
+			"MonoBank allows one statement request per minute, try a shorter period.",
+		msgSummaryPrev: "\nCompared with {{.From}} - {{.To}}:\n" +
+			"Income: {{.IncomeChange}} ({{.Income}})\nExpenses: {{.ExpensesChange}} ({{.Expenses}})\n" +
+			"Net: {{.NetChange}} ({{.Net}})",
+		msgSearchUsage: "Usage: /search <text> [period] [min..max] [--csv], " +
+			"e.g. /search vet 01.01.2020-31.01.2020 100..500",
+		msgSearchNothing: "Nothing found for {{printf \"%q\" .Text}}, {{.From}} - {{.To}}.",
+		msgSearchFound: "Found {{.Count}} {{plural .Count \"transaction\" \"transactions\"}} " +
+			"for {{printf \"%q\" .Text}}, {{.From}} - {{.To}}:\n",
+		msgSearchMore:    "...and {{.Count}} more, add {{.Flag}} to get all of them.\n",
+		msgInlineSetup:   "Set up the bot to see your account",
+		msgInlineBusy:    "MonoBank is busy, try again in a minute",
+		msgInlineFailed:  "Something went wrong, try again later",
+		msgInlineBalance: "{{.Card}}: {{.Balance}} {{.Currency}}",
+		msgInlineNothing: "Nothing found",
+		msgInlineFound:   "{{.Count}} {{plural .Count \"transaction\" \"transactions\"}}, {{.Total}}",
+		msgInlineSummary: "{{.From}} - {{.To}}{{if .Text}}, {{printf \"%q\" .Text}}{{end}}: " +
+			"{{.Count}} {{plural .Count \"transaction\" \"transactions\"}}, {{.Total}}\n",
+		msgInlineMore:      "...and {{.Count}} more.\n",
+		msgUnknownCommand:  "Sorry, I don't know this command, see /help.",
+		msgTooManyRequests: "Too many messages, please wait a minute.",
+		msgNoUser:          "The chat isn't bound to a user yet, see /start.",
+		msgNoToken:         "The MonoBank token isn't set yet, see /start.",
+		msgHelp:            "The commands and the usage examples, /help <command> shows one command:\n",
+		msgHelpUnknown:     "There is no command {{printf \"%q\" .Command}}, see /help.",
+		msgStart: "Hi! I make reports of your MonoBank statement. " +
+			"The setup steps:\n\n",
+		msgStartReady:  "Everything is set up, see /help for what I can do.",
+		msgStepUser:    "the chat is bound to a user",
+		msgStepUserHow: "Next step: bind the chat to a user, e.g. /user {{.UserID}}",
+		msgStepToken:   "the MonoBank token is set",
+		msgStepTokenHow: "Next step: get the personal token at https://api.monobank.ua and send it: " +
+			"/token <token>",
+		msgStepAccount:    "the account is chosen",
+		msgStepAccountHow: "Next step: list your accounts with /info and choose one: /account <account id>",
+		msgStepMapping:    "the categories are mapped (optional)",
+		msgStepMappingHow: "Optional step: send mapping.csv to map the MonoBank categories to yours, " +
+			"the reports already work, see /help.",
+		msgCmdStart:        "Setup steps and what to do next",
+		msgCmdHelp:         "Commands and usage examples",
+		msgCmdGet:          "CSV report of a period",
+		msgCmdToday:        "CSV report of today",
+		msgCmdMonth:        "CSV report of the current month",
+		msgCmdSummary:      "Spending by category",
+		msgCmdChart:        "Spending chart",
+		msgCmdSearch:       "Search the transactions",
+		msgCmdBudget:       "Monthly budgets by category",
+		msgCmdSubscription: "Recurring payments",
+		msgCmdSchedule:     "Scheduled reports",
+		msgCmdInfo:         "MonoBank accounts",
+		msgCmdAccount:      "Choose the account",
+		msgCmdToken:        "Set or check the MonoBank token",
+		msgCmdUser:         "Bind the chat to a user",
+		msgCmdAPIKey:       "REST API keys",
+		msgCmdTimezone:     "Time zone",
+		msgCmdLocale:       "Date and number format",
+		msgCmdLang:         "Language of the bot",
+		msgCmdExport:       "Export a report step by step",
+		msgCmdMapping:      "Change a category of the mapping",
+		msgCmdCancel:       "Cancel the current dialog",
+		msgBudgetUsage: "Usage:\n" +
+			"/budget <category> <monthly limit>, e.g. /budget Groceries 8000\n" +
+			"/budget status\n" +
+			"/budget delete <category>\n" +
+			"The categories are the application's categories of mapping.csv.",
+		msgBudgetUnknown:  "There is no budget of {{printf \"%q\" .Category}}.",
+		msgBudgetDeleted:  "Budget successfully deleted.",
+		msgBudgetSet:      "Monthly budget of {{printf \"%q\" .Category}} is set to {{.Limit}}.",
+		msgBudgetNone:     "There are no budgets.\n\n{{.Usage}}",
+		msgBudgets:        "Budgets of {{month .Month}} {{.Year}}:\n\n{{.Statuses}}",
+		msgBudgetStatus:   "{{.Category}}\n{{.Bar}} {{.Percent}}% {{.Spent}} of {{.Limit}}",
+		msgBudgetExceeded: "Budget of {{printf \"%q\" .Category}} is exceeded: {{.Spent}} of {{.Limit}} spent ({{.Percent}}%).",
+		msgBudgetAlert:    "{{.Percent}}% of the budget of {{printf \"%q\" .Category}} is spent: {{.Spent}} of {{.Limit}}.",
+		msgSubsOff:        "Subscription warnings are disabled.",
+		msgSubsNone:       "No subscriptions found.\n",
+		msgSubs:           "Subscriptions:\n",
+		msgSub: "{{.Description}} - {{.Amount}} {{.Period}}, {{.MonthlyCost}} a month, " +
+			"next {{.Next}}{{if .Notes}} ({{.Notes}}){{end}}\n",
+		msgSubsTotal:     "Total: {{.Total}} a month.\n",
+		msgSubsPartial:   "\nThe history is collected since {{.From}}, more subscriptions may be found later.\n",
+		msgSubsFooter:    "\nYou'll be warned about price changes and missed charges, /subscriptions off disables the warnings.",
+		msgSubPriceNote:  "price changed from {{.Previous}}",
+		msgSubMissedNote: "the expected charge didn't happen",
+		msgSubMissed:     "The {{.Period}} charge of {{.Description}} ({{.Amount}}) expected on {{.Date}} didn't happen.",
+		msgSubPrice:      "The price of {{.Description}} is changed from {{.Previous}} to {{.Amount}} ({{.Change}}).",
+		msgTimezone: "Your time zone is {{.Timezone}}.\n\n" +
+			"Usage: /timezone <IANA name>, e.g. /timezone Europe/Warsaw",
+		msgTimezoneSet: "Time zone is set to {{.Timezone}}, the time there is {{.Time}}.",
+		msgLocale:      "Your locale is {{.Locale}}.\n\nUsage: /locale <tag>, the locales are:\n{{.Locales}}",
+		msgLocaleSet:   "Locale is set to {{.Tag}} ({{.Name}}).",
+		msgLanguage: "The messages are in English.\n\n" +
+			"Usage: /lang <language>, the languages are: uk - Українська, en - English.",
+		msgLanguageSet:   "The messages are in English now.",
+		msgCancelled:     "Cancelled.",
+		msgCancelNothing: "There is nothing to cancel.",
+		msgDialogExpired: "This question has expired, please start again.",
+		msgExportAccount: "Choose the account, /cancel to stop.",
+		msgExportPeriod: "Choose a quick range, or the first and the last day in the calendar, " +
+			"or type the period, e.g. \"last 7 days\".",
+		msgExportFormat:    "Choose the format.",
+		msgExportToday:     "Today",
+		msgExportWeek:      "This week",
+		msgExportMonth:     "This month",
+		msgExportLastMonth: "Last month",
+		msgExportYear:      "This year",
+		msgExportSummary:   "Summary",
+		msgExportChart:     "Chart",
+		msgTokenAsk: "Send me the personal token of https://api.monobank.ua, the message will be removed " +
+			"right away. /cancel to stop.",
+		msgMappingNone:    "Category mapping isn't set, send the mapping.csv file.",
+		msgMappingAsk:     "Send the number of the category to change, /cancel to stop:\n",
+		msgMappingAskApp:  "Send the new application category of {{.Category}}.",
+		msgMappingChanged: "The category is changed to {{.App}}.",
+		msgCalendarMonth:  "{{month .Month}} {{.Year}}",
+		msgCalendarDays:   "Mo Tu We Th Fr Sa Su",
+		msgCalendarTo:     "The first day is {{.From}}, choose the last one.",
+	},
+	model.LanguageUK: {
+		msgDefaultErr:    "Вибачте, не вдалося обробити повідомлення, перегляньте журнали або зверніться до власника сервісу.",
+		msgBusy:          "Вибачте, я ще обробляю ваші попередні повідомлення, спробуйте пізніше.",
+		msgInvalidInput:  "{{.Error}}.",
+		msgInvalidUsage:  "{{.Error}}.\n\n{{.Usage}}",
+		msgSetAccount:    "Будь ласка, вкажіть рахунок.",
+		msgRateLimited:   "MonoBank дозволяє один запит виписки на хвилину, спробуйте пізніше.",
+		msgNotAvailable:  "н/д",
+		msgPeriodDaily:   "щодня",
+		msgPeriodWeekly:  "щотижня",
+		msgPeriodMonthly: "щомісяця",
+		msgAccountSet:    "рахунок успішно встановлено",
+		msgChatUserSet:   "ID користувача чату успішно встановлено",
+		msgMappingLoaded: "мапінг успішно завантажено",
+		msgClientAccount: "id: {{.ID}}\n    код валюти: {{.CurrencyCode}}\n    баланс: {{.Balance}}\n" +
+			"    тип: {{.Type}}\n\n",
+		msgTokenRejected: "MonoBank відхилив токен, перевірте його і спробуйте ще раз.",
+		msgTokenSet:      "токен успішно встановлено, клієнт: {{.Client}}",
+		msgTokenNotSet:   "Токен не встановлено.",
+		msgTokenStatus:   "токен: {{.Token}}\nостання перевірка: {{.VerifiedAt}}",
+		msgTokenNever:    "ніколи",
+		msgAPIKeyNew: "Новий API ключ ({{.Scope}}), він показується лише один раз:\n{{.Key}}\n\n" +
+			"Надсилайте його в заголовку \"Authorization: Bearer <key>\", попередній ключ відкликано.",
+		msgAPIKeyRead:    "лише читання",
+		msgAPIKeyManage:  "повний доступ",
+		msgAPIKeyNone:    "Немає активного API ключа.",
+		msgAPIKeyRevoked: "API ключ успішно відкликано.",
+		msgAPIKeyUsage:   "Використання: /apikey new [manage] або /apikey revoke",
+		msgScheduleUsage: "Використання:\n" +
+			"/schedule daily 21:00 [csv|xlsx|summary]\n" +
+			"/schedule weekly mon 09:00 [csv|xlsx|summary]\n" +
+			"/schedule monthly 1 09:00 [csv|xlsx|summary]\n" +
+			"/schedule list\n" +
+			"/schedule delete <id>",
+		msgScheduleUnknown: "Немає розкладу {{printf \"%q\" .ID}}.",
+		msgScheduleDeleted: "Розклад успішно видалено.",
+		msgScheduleAdded:   "Розклад успішно додано:\n{{.Schedule}}",
+		msgScheduleNone:    "Немає розкладів.\n\n{{.Usage}}",
+		msgScheduleDaily:   "щодня",
+		msgScheduleWeekly:  "щотижня в {{weekday .Weekday}}",
+		msgScheduleMonthly: "щомісяця {{.Day}} числа",
+		msgSchedule:        "{{.ID}}: {{.When}} о {{.Time}} ({{.Location}}), {{.Format}}",
+		msgScheduledToken:  "Звіт за розкладом не надіслано: будь ласка, вкажіть токен.",
+		msgScheduledAcc:    "Звіт за розкладом не надіслано: будь ласка, вкажіть рахунок.",
+		msgScheduledReport: "Звіт за розкладом, {{.Period}} ({{.ID}})",
+		msgChartCaption:    "Витрати {{.From}} - {{.To}}",
+		msgChartExpenses:   "Витрати",
+		msgChartNoExpenses: "За період немає витрат.",
+		msgChartOther:      "Інше",
+		msgSummary: "Підсумок {{.From}} - {{.To}}\n" +
+			"Доходи: {{.Income}}\nВитрати: {{.Expenses}}\nРазом: {{.Net}}\n",
+		msgSummaryTop:      "\nНайбільші категорії:\n",
+		msgSummaryCategory: "{{.N}}. {{.Category}} - {{.Amount}} ({{.Share}}%)\n",
+		msgSummaryBiggest:  "\nНайбільші покупки:\n",
+		msgSummaryPurchase: "{{.Time}} {{.Description}} ({{.Category}}) - {{.Amount}}\n",
+		msgSummaryNoPrev: "\nПорівняння з попереднім періодом недоступне: " +
+			"MonoBank дозволяє один запит виписки на хвилину, оберіть коротший період.",
+		msgSummaryPrev: "\nПорівняно з {{.From}} - {{.To}}:\n" +
+			"Доходи: {{.IncomeChange}} ({{.Income}})\nВитрати: {{.ExpensesChange}} ({{.Expenses}})\n" +
+			"Разом: {{.NetChange}} ({{.Net}})",
+		msgSearchUsage: "Використання: /search <текст> [період] [мін..макс] [--csv], " +
+			"наприклад /search vet 01.01.2020-31.01.2020 100..500",
+		msgSearchNothing: "Нічого не знайдено за {{printf \"%q\" .Text}}, {{.From}} - {{.To}}.",
+		msgSearchFound: "Знайдено {{.Count}} {{plural .Count \"транзакцію\" \"транзакції\" \"транзакцій\"}} " +
+			"за {{printf \"%q\" .Text}}, {{.From}} - {{.To}}:\n",
+		msgSearchMore:    "...і ще {{.Count}}, додайте {{.Flag}}, щоб отримати всі.\n",
+		msgInlineSetup:   "Налаштуйте бота, щоб бачити свій рахунок",
+		msgInlineBusy:    "MonoBank зайнятий, спробуйте за хвилину",
+		msgInlineFailed:  "Щось пішло не так, спробуйте пізніше",
+		msgInlineBalance: "{{.Card}}: {{.Balance}} {{.Currency}}",
+		msgInlineNothing: "Нічого не знайдено",
+		msgInlineFound:   "{{.Count}} {{plural .Count \"транзакція\" \"транзакції\" \"транзакцій\"}}, {{.Total}}",
+		msgInlineSummary: "{{.From}} - {{.To}}{{if .Text}}, {{printf \"%q\" .Text}}{{end}}: " +
+			"{{.Count}} {{plural .Count \"транзакція\" \"транзакції\" \"транзакцій\"}}, {{.Total}}\n",
+		msgInlineMore:      "...і ще {{.Count}}.\n",
+		msgUnknownCommand:  "Вибачте, я не знаю цієї команди, дивіться /help.",
+		msgTooManyRequests: "Забагато повідомлень, зачекайте хвилину.",
+		msgNoUser:          "Чат ще не прив'язано до користувача, дивіться /start.",
+		msgNoToken:         "Токен MonoBank ще не встановлено, дивіться /start.",
+		msgHelp:            "Команди та приклади використання, /help <команда> показує одну команду:\n",
+		msgHelpUnknown:     "Немає команди {{printf \"%q\" .Command}}, дивіться /help.",
+		msgStart: "Привіт! Я складаю звіти з виписки MonoBank. " +
+			"Кроки налаштування:\n\n",
+		msgStartReady:  "Усе налаштовано, дивіться /help, щоб дізнатися, що я вмію.",
+		msgStepUser:    "чат прив'язано до користувача",
+		msgStepUserHow: "Наступний крок: прив'яжіть чат до користувача, наприклад /user {{.UserID}}",
+		msgStepToken:   "токен MonoBank встановлено",
+		msgStepTokenHow: "Наступний крок: отримайте персональний токен на https://api.monobank.ua і надішліть його: " +
+			"/token <токен>",
+		msgStepAccount:    "рахунок обрано",
+		msgStepAccountHow: "Наступний крок: перегляньте рахунки командою /info і оберіть один: /account <id рахунку>",
+		msgStepMapping:    "категорії зіставлено (необов'язково)",
+		msgStepMappingHow: "Необов'язковий крок: надішліть mapping.csv, щоб зіставити категорії MonoBank зі своїми, " +
+			"звіти вже працюють, дивіться /help.",
+		msgCmdStart:        "Кроки налаштування і що робити далі",
+		msgCmdHelp:         "Команди та приклади використання",
+		msgCmdGet:          "CSV-звіт за період",
+		msgCmdToday:        "CSV-звіт за сьогодні",
+		msgCmdMonth:        "CSV-звіт за поточний місяць",
+		msgCmdSummary:      "Витрати за категоріями",
+		msgCmdChart:        "Діаграма витрат",
+		msgCmdSearch:       "Пошук транзакцій",
+		msgCmdBudget:       "Місячні бюджети за категоріями",
+		msgCmdSubscription: "Регулярні платежі",
+		msgCmdSchedule:     "Звіти за розкладом",
+		msgCmdInfo:         "Рахунки MonoBank",
+		msgCmdAccount:      "Обрати рахунок",
+		msgCmdToken:        "Встановити або перевірити токен MonoBank",
+		msgCmdUser:         "Прив'язати чат до користувача",
+		msgCmdAPIKey:       "Ключі REST API",
+		msgCmdTimezone:     "Часовий пояс",
+		msgCmdLocale:       "Формат дат і чисел",
+		msgCmdLang:         "Мова бота",
+		msgCmdExport:       "Експорт звіту крок за кроком",
+		msgCmdMapping:      "Змінити категорію мапінгу",
+		msgCmdCancel:       "Скасувати поточний діалог",
+		msgBudgetUsage: "Використання:\n" +
+			"/budget <категорія> <місячний ліміт>, наприклад /budget Продукти 8000\n" +
+			"/budget status\n" +
+			"/budget delete <категорія>\n" +
+			"Категорії - це категорії застосунку з mapping.csv.",
+		msgBudgetUnknown:  "Немає бюджету {{printf \"%q\" .Category}}.",
+		msgBudgetDeleted:  "Бюджет успішно видалено.",
+		msgBudgetSet:      "Місячний бюджет {{printf \"%q\" .Category}}: {{.Limit}}.",
+		msgBudgetNone:     "Немає бюджетів.\n\n{{.Usage}}",
+		msgBudgets:        "Бюджети на {{month .Month}} {{.Year}}:\n\n{{.Statuses}}",
+		msgBudgetStatus:   "{{.Category}}\n{{.Bar}} {{.Percent}}% {{.Spent}} з {{.Limit}}",
+		msgBudgetExceeded: "Бюджет {{printf \"%q\" .Category}} перевищено: витрачено {{.Spent}} з {{.Limit}} ({{.Percent}}%).",
+		msgBudgetAlert:    "Витрачено {{.Percent}}% бюджету {{printf \"%q\" .Category}}: {{.Spent}} з {{.Limit}}.",
+		msgSubsOff:        "Попередження про підписки вимкнено.",
+		msgSubsNone:       "Підписок не знайдено.\n",
+		msgSubs:           "Підписки:\n",
+		msgSub: "{{.Description}} - {{.Amount}} {{.Period}}, {{.MonthlyCost}} на місяць, " +
+			"наступне списання {{.Next}}{{if .Notes}} ({{.Notes}}){{end}}\n",
+		msgSubsTotal:   "Разом: {{.Total}} на місяць.\n",
+		msgSubsPartial: "\nІсторію зібрано з {{.From}}, пізніше можуть знайтися інші підписки.\n",
+		msgSubsFooter: "\nВи отримаєте попередження про зміну ціни та пропущені списання, " +
+			"/subscriptions off вимикає попередження.",
+		msgSubPriceNote:  "ціна змінилася з {{.Previous}}",
+		msgSubMissedNote: "очікуване списання не відбулося",
+		msgSubMissed:     "Очікуване списання {{.Description}} ({{.Amount}}) {{.Date}} не відбулося.",
+		msgSubPrice:      "Ціна {{.Description}} змінилася з {{.Previous}} на {{.Amount}} ({{.Change}}).",
+		msgTimezone: "Ваш часовий пояс: {{.Timezone}}.\n\n" +
+			"Використання: /timezone <назва IANA>, наприклад /timezone Europe/Warsaw",
+		msgTimezoneSet: "Часовий пояс {{.Timezone}} встановлено, там зараз {{.Time}}.",
+		msgLocale:      "Ваша локаль: {{.Locale}}.\n\nВикористання: /locale <тег>, доступні локалі:\n{{.Locales}}",
+		msgLocaleSet:   "Локаль {{.Tag}} ({{.Name}}) встановлено.",
+		msgLanguage: "Повідомлення українською.\n\n" +
+			"Використання: /lang <мова>, доступні мови: uk - Українська, en - English.",
+		msgLanguageSet:   "Тепер повідомлення українською.",
+		msgCancelled:     "Скасовано.",
+		msgCancelNothing: "Немає чого скасовувати.",
+		msgDialogExpired: "Це питання застаріло, почніть спочатку.",
+		msgExportAccount: "Оберіть рахунок, /cancel щоб зупинитися.",
+		msgExportPeriod: "Оберіть швидкий період, або перший і останній день у календарі, " +
+			"або введіть період, наприклад \"останні 7 днів\".",
+		msgExportFormat:    "Оберіть формат.",
+		msgExportToday:     "Сьогодні",
+		msgExportWeek:      "Цей тиждень",
+		msgExportMonth:     "Цей місяць",
+		msgExportLastMonth: "Минулий місяць",
+		msgExportYear:      "Цей рік",
+		msgExportSummary:   "Підсумок",
+		msgExportChart:     "Графік",
+		msgTokenAsk: "Надішліть особистий токен з https://api.monobank.ua, повідомлення буде одразу видалено. " +
+			"/cancel щоб зупинитися.",
+		msgMappingNone:    "Мапінг категорій не встановлено, надішліть файл mapping.csv.",
+		msgMappingAsk:     "Надішліть номер категорії, яку потрібно змінити, /cancel щоб зупинитися:\n",
+		msgMappingAskApp:  "Надішліть нову категорію застосунку для {{.Category}}.",
+		msgMappingChanged: "Категорію змінено на {{.App}}.",
+		msgCalendarMonth:  "{{month .Month}} {{.Year}}",
+		msgCalendarDays:   "Пн Вт Ср Чт Пт Сб Нд",
+		msgCalendarTo:     "Перший день {{.From}}, оберіть останній.",
+	},
+}
+
+// monthsUK - the Ukrainian month names in the nominative case.
+var monthsUK = [12]string{ //nolint:gochecknoglobals
+	"січень", "лютий", "березень", "квітень", "травень", "червень",
+	"липень", "серпень", "вересень", "жовтень", "листопад", "грудень",
+}
+
+// weekdaysUK - the Ukrainian weekday names in the accusative case, as in "в понеділок", Sunday first.
+var weekdaysUK = [7]string{ //nolint:gochecknoglobals
+	"неділю", "понеділок", "вівторок", "середу", "четвер", "п'ятницю", "суботу",
+}
+
+// validationCatalog - the translations of the validation error formats of the use-cases and adapters,
+// the English ones are used as they are. Every format must be translated, see TestValidationCatalog.
+var validationCatalog = map[string]map[string]string{ //nolint:gochecknoglobals
+	model.LanguageUK: {
+		"token can't be empty":                           "токен не може бути порожнім",
+		"search text is empty":                           "текст пошуку порожній",
+		"min amount must not be greater than max amount": "мінімальна сума не може перевищувати максимальну",
+		"amount range must look like 100..250.50: %s":    "діапазон сум має виглядати як 100..250.50: %s",
+		"min amount must not be greater than max amount: %s": "мінімальна сума не може перевищувати " +
+			"максимальну: %s",
+		"invalid amount: %s": "некоректна сума: %s",
+		"the period must not end before it starts: %s": "період не може закінчуватися раніше, " +
+			"ніж починається: %s",
+		"the number of days must be positive: %s": "кількість днів має бути додатною: %s",
+		"can't recognize the period: %s":          "не вдалося розпізнати період: %s",
+		"can't parse the date: %s":                "не вдалося розібрати дату: %s",
+		"time zone must be an IANA name, e.g. Europe/Warsaw": "часовий пояс має бути назвою IANA, " +
+			"наприклад Europe/Warsaw",
+		"unknown time zone %q, it must be an IANA name, e.g. Europe/Warsaw": "невідомий часовий пояс %q, " +
+			"це має бути назва IANA, наприклад Europe/Warsaw",
+		"unknown locale %q, the locales are: %s":         "невідома локаль %q, доступні локалі: %s",
+		"unknown language %q, the languages are: %s, %s": "невідома мова %q, доступні мови: %s, %s",
+		"unknown API key scope: %s":                      "невідомий дозвіл API ключа: %s",
+		"can't read file: err=%s":                        "не вдалося прочитати файл: err=%s",
+		"mapping should have 3 column":                   "мапінг має містити 3 колонки",
+		"period is missing":                              "не вказано період",
+		"weekday is missing":                             "не вказано день тижня",
+		"day of month is missing":                        "не вказано день місяця",
+		"day of month must be from 1 to 31: %s":          "день місяця має бути від 1 до 31: %s",
+		"unknown period: %s":                             "невідомий період: %s",
+		"time is missing":                                "не вказано час",
+		"time must be in the format HH:MM: %s":           "час має бути у форматі ГГ:ХХ: %s",
+		"unknown report format: %s":                      "невідомий формат звіту: %s",
+		"unexpected arguments: %s":                       "зайві аргументи: %s",
+		"you can't have more than %d schedules":          "можна мати не більше %d розкладів",
+		"unknown weekday: %s":                            "невідомий день тижня: %s",
+		"limit must be a positive number: %s":            "ліміт має бути додатним числом: %s",
+		"you can't have more than %d budgets":            "можна мати не більше %d бюджетів",
+		"unknown category %q, the categories are: %s":    "невідома категорія %q, доступні категорії: %s",
+		"category mapping isn't set, send the mapping.csv file": "мапінг категорій не встановлено, " +
+			"надішліть файл mapping.csv",
+		"MonoBank rejected the request: %s":      "MonoBank відхилив запит: %s",
+		"dialog must have the flow and the step": "діалог має містити сценарій і крок",
+		"choose the account with the buttons":    "оберіть рахунок кнопками",
+		"choose the format with the buttons":     "оберіть формат кнопками",
+		"the number must be from 1 to %d":        "номер має бути від 1 до %d",
+		"the category must not be empty":         "категорія не може бути порожньою",
+		"line %d: the statement must have at least %d columns, got %d": "рядок %d: виписка має містити " +
+			"щонайменше %d колонок, отримано %d",
+		"line %d: can't parse the date %q":                "рядок %d: не вдалося розібрати дату %q",
+		"line 1: the %s column isn't found in the header": "рядок 1: у заголовку не знайдено колонку %s",
+		`chat can only be processed using the files "csv", "xls" and "xlsx"`: `обробляються лише файли "csv", ` +
+			`"xls" і "xlsx"`,
+	},
+}
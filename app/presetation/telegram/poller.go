@@ -0,0 +1,68 @@
+package telegram
+
+import (
+	"context"
+	"time"
+
+	tg "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+const pollRetryDelay = 3 * time.Second
+
+// NewPoller - builds long polling receiver of updates starting from "offset".
+func NewPoller(bot *tg.BotAPI, log Logger, offset, timeout int) *Poller {
+	config := tg.NewUpdate(offset)
+	config.Timeout = timeout
+
+	return &Poller{
+		bot:     bot,
+		log:     log,
+		config:  config,
+		updates: make(chan tg.Update),
+	}
+}
+
+// Poller - receives updates with long polling. Unlike "BotAPI.GetUpdatesChan" it doesn't buffer updates,
+// so updates Telegram considers received are the ones read from the channel and the ones of the last request.
+type Poller struct {
+	bot     *tg.BotAPI
+	log     Logger
+	config  tg.UpdateConfig
+	updates chan tg.Update
+}
+
+// Updates returns the channel of received updates.
+func (p *Poller) Updates() tg.UpdatesChannel {
+	return p.updates
+}
+
+// Run - receives updates until ctx is canceled, the updates of the request in progress are left to Telegram
+// and will be received again after the restart.
+func (p *Poller) Run(ctx context.Context) error {
+	for {
+		updates, err := p.bot.GetUpdates(p.config)
+		if err != nil {
+			p.log.Errorf("can't get updates, retrying in %s: err=%s", pollRetryDelay, err)
+
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(pollRetryDelay):
+				continue
+			}
+		}
+
+		for _, u := range updates {
+			select {
+			case <-ctx.Done():
+				return nil
+			case p.updates <- u:
+				p.config.Offset = u.UpdateID + 1
+			}
+		}
+
+		if ctx.Err() != nil {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package telegram
+
+import (
+	"runtime/debug"
+	"sync"
+
+	tg "github.com/go-telegram-bot-api/telegram-bot-api"
+)
+
+// newWorkerPool - builds worker pool, "workers" goroutines process updates, each of them has a queue
+// limited by "queueSize" updates.
+func newWorkerPool(workers, queueSize int, handle func(u tg.Update), log Logger) *workerPool {
+	queues := make([]chan tg.Update, workers)
+	for i := range queues {
+		queues[i] = make(chan tg.Update, queueSize)
+	}
+
+	return &workerPool{
+		queues:   queues,
+		handle:   handle,
+		inFlight: make(map[int]struct{}),
+		log:      log,
+	}
+}
+
+// workerPool - processes updates concurrently, updates of the same chat always go to the same worker,
+// so they are processed one by one in the order they came.
+type workerPool struct {
+	queues []chan tg.Update
+	handle func(u tg.Update)
+	log    Logger
+	wg     sync.WaitGroup
+
+	mu       sync.Mutex
+	inFlight map[int]struct{} // IDs of queued and processing updates
+	next     int              // ID following the last dispatched update
+}
+
+// start - runs the workers.
+func (p *workerPool) start() {
+	for _, queue := range p.queues {
+		p.wg.Add(1)
+
+		go func(queue chan tg.Update) {
+			defer p.wg.Done()
+
+			for u := range queue {
+				p.process(u)
+				p.done(u.UpdateID)
+			}
+		}(queue)
+	}
+}
+
+// dispatch - puts the update into the queue of the chat worker, returns false if the queue is full.
+func (p *workerPool) dispatch(u tg.Update) bool {
+	p.begin(u.UpdateID)
+
+	select {
+	case p.queues[p.index(updateChatID(u))] <- u:
+		return true
+	default:
+		p.done(u.UpdateID)
+
+		return false
+	}
+}
+
+// skip - marks the update as processed without handling it.
+func (p *workerPool) skip(u tg.Update) {
+	p.begin(u.UpdateID)
+	p.done(u.UpdateID)
+}
+
+// offset - returns the ID of the first update that isn't processed yet, all the updates before it are processed.
+func (p *workerPool) offset() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	offset := p.next
+	for id := range p.inFlight {
+		if id < offset {
+			offset = id
+		}
+	}
+
+	return offset
+}
+
+func (p *workerPool) begin(updateID int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.inFlight[updateID] = struct{}{}
+	if updateID >= p.next {
+		p.next = updateID + 1
+	}
+}
+
+func (p *workerPool) done(updateID int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.inFlight, updateID)
+}
+
+// stop - closes the queues and waits until the workers process the queued updates.
+func (p *workerPool) stop() {
+	for _, queue := range p.queues {
+		close(queue)
+	}
+
+	p.wg.Wait()
+}
+
+func (p *workerPool) index(chatID int64) int {
+	return int(uint64(chatID) % uint64(len(p.queues)))
+}
+
+// process - handles the update, a panic outside the handlers is logged, so the worker keeps running.
+// The panics of the handlers are recovered and reported to the chat by the router.
+func (p *workerPool) process(u tg.Update) {
+	defer func() {
+		if r := recover(); r != nil {
+			p.log.Errorf("update panic: update=%d err=%v\n%s", u.UpdateID, r, debug.Stack())
+		}
+	}()
+
+	p.handle(u)
+}
@@ -0,0 +1,112 @@
+package telegram
+
+import (
+	"sync"
+	"testing"
+
+	tg "github.com/go-telegram-bot-api/telegram-bot-api"
+	. "github.com/onsi/gomega"
+)
+
+const errNotEqual = "not equal"
+
+// panicLog - counts the logged errors.
+type panicLog struct {
+	mu     sync.Mutex
+	errors int
+}
+
+func (l *panicLog) Infof(string, ...interface{}) {}
+
+func (l *panicLog) Error(...interface{}) {}
+
+func (l *panicLog) Errorf(string, ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.errors++
+}
+
+func update(chatID int64, updateID int) tg.Update {
+	return tg.Update{UpdateID: updateID, Message: &tg.Message{Chat: &tg.Chat{ID: chatID}}}
+}
+
+func TestWorkerPool_Ordering(t *testing.T) {
+	RegisterTestingT(t)
+
+	mu := sync.Mutex{}
+	got := make(map[int64][]int)
+	handle := func(u tg.Update) {
+		mu.Lock()
+		defer mu.Unlock()
+		got[u.Message.Chat.ID] = append(got[u.Message.Chat.ID], u.UpdateID)
+	}
+
+	p := newWorkerPool(3, 100, handle, nil)
+	p.start()
+
+	want := make(map[int64][]int)
+	for i := 0; i < 50; i++ {
+		chatID := int64(i%5) - 2 // group chats have negative IDs
+		Ω(p.dispatch(update(chatID, i))).To(BeTrue(), errNotEqual)
+		want[chatID] = append(want[chatID], i)
+	}
+	p.stop()
+
+	Ω(got).To(Equal(want), errNotEqual)
+}
+
+func TestWorkerPool_QueueIsFull(t *testing.T) {
+	RegisterTestingT(t)
+
+	p := newWorkerPool(1, 1, func(tg.Update) {}, nil)
+
+	Ω(p.dispatch(update(1, 1))).To(BeTrue(), errNotEqual)
+	Ω(p.dispatch(update(1, 2))).To(BeFalse(), errNotEqual)
+}
+
+func TestWorkerPool_Offset(t *testing.T) {
+	RegisterTestingT(t)
+
+	release := make(chan struct{})
+	handle := func(u tg.Update) {
+		if u.Message.Chat.ID == 1 {
+			<-release
+		}
+	}
+
+	p := newWorkerPool(2, 10, handle, nil)
+	p.start()
+
+	Ω(p.dispatch(update(1, 100))).To(BeTrue(), errNotEqual) // blocked until release
+	Ω(p.dispatch(update(2, 101))).To(BeTrue(), errNotEqual)
+	p.skip(update(2, 102))
+	Eventually(p.offset).Should(Equal(100), errNotEqual)
+
+	close(release)
+	p.stop()
+	Ω(p.offset()).To(Equal(103), errNotEqual)
+}
+
+func TestWorkerPool_Panic(t *testing.T) {
+	RegisterTestingT(t)
+
+	var got []int
+	handle := func(u tg.Update) {
+		if u.UpdateID == 1 {
+			panic("route")
+		}
+		got = append(got, u.UpdateID)
+	}
+
+	log := &panicLog{}
+	p := newWorkerPool(1, 10, handle, log)
+	p.start()
+
+	Ω(p.dispatch(update(1, 1))).To(BeTrue(), errNotEqual)
+	Ω(p.dispatch(update(1, 2))).To(BeTrue(), errNotEqual)
+	p.stop()
+
+	Ω(got).To(Equal([]int{2}), errNotEqual)
+	Ω(log.errors).To(Equal(1), errNotEqual)
+	Ω(p.offset()).To(Equal(3), errNotEqual)
+}
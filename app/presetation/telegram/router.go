@@ -0,0 +1,319 @@
+package telegram
+
+import (
+	"runtime/debug"
+	"sync"
+	"time"
+
+	tg "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+const (
+	// rateLimitUpdates - the number of the messages of a chat processed within rateLimitWindow,
+	// the others are rejected.
+	rateLimitUpdates = 30
+	rateLimitWindow  = time.Minute
+)
+
+// Requirement - what the handler needs before it is called, every requirement implies RequireUser.
+type Requirement int
+
+// Handler requirements, the router loads them into the request or replies how to set them up.
+const (
+	// RequireUser - the chat must be bound to a user, see Request.UserID.
+	RequireUser Requirement = 1 << iota
+	// RequireToken - the MonoBank token must be set, see Request.Token.
+	RequireToken
+	// RequireAccount - the account must be chosen, see Request.Account.
+	RequireAccount
+)
+
+// Request - represents the update processed by the router with the data loaded by the middleware.
+type Request struct {
+	tg.Update
+	Key      HandlerKey
+	Requires Requirement
+	ChatID   int64
+	Lang     string
+	UserID   uuid.UUID // uuid.Nil if the chat isn't bound to a user
+	Token    string
+	Account  string
+	Dialog   *model.Dialog // the dialog answered by the update, nil if it isn't an answer
+}
+
+// HandlerFunc - processes the request, the last function of the middleware chain calls the handler.
+type HandlerFunc func(r *Request)
+
+// Middleware - wraps the processing of the request, it calls "next" to continue or replies and returns.
+type Middleware func(next HandlerFunc) HandlerFunc
+
+// NewRouter - builds the router of the internal handlers, the middleware recovers the panics,
+// answers the callback queries, logs the processing time, limits the messages of a chat, routes the answers
+// to the dialogs and loads the requirements of the handler.
+func NewRouter(handlers map[HandlerKey]Handler, chatUserUC ChatUserUC, tokenUC TokenUC, accountUC AccountUC,
+	dialogUC DialogUC, botWrapper *BotWrapper) *Router {
+	r := &Router{
+		handlers:   handlers,
+		chatUserUC: chatUserUC,
+		tokenUC:    tokenUC,
+		accountUC:  accountUC,
+		dialogUC:   dialogUC,
+		limiter:    newRateLimiter(rateLimitWindow),
+		BotWrapper: botWrapper,
+	}
+	r.Use(r.recovery, r.answerCallback, r.logging, r.rateLimit, r.resolveUser, r.resumeDialog, r.authorize,
+		r.loadToken, r.loadAccount)
+
+	return r
+}
+
+// Router - routes the updates to the internal handlers through the middleware chain.
+type Router struct {
+	handlers   map[HandlerKey]Handler
+	middleware []Middleware
+	chatUserUC ChatUserUC
+	tokenUC    TokenUC
+	accountUC  AccountUC
+	dialogUC   DialogUC
+	limiter    *rateLimiter
+	*BotWrapper
+}
+
+// Use - appends the middleware to the chain, the first one is the outermost.
+func (r *Router) Use(middleware ...Middleware) {
+	r.middleware = append(r.middleware, middleware...)
+}
+
+// serve - processes the update by the handler of the key, the updates without a handler are ignored.
+// The middleware can route the request to another handler changing the key of it.
+func (r *Router) serve(key HandlerKey, u tg.Update) {
+	h, ok := r.handlers[key]
+	if !ok {
+		return
+	}
+
+	next := HandlerFunc(r.handle)
+	for i := len(r.middleware) - 1; i >= 0; i-- {
+		next = r.middleware[i](next)
+	}
+
+	next(&Request{Update: u, Key: key, Requires: h.Requires(), ChatID: updateChatID(u), Lang: r.language(u)})
+}
+
+func (r *Router) handle(req *Request) {
+	r.handlers[req.Key].Handle(req)
+}
+
+// recovery - logs the panic of the handler and reports it to the chat.
+func (r *Router) recovery(next HandlerFunc) HandlerFunc {
+	return func(req *Request) {
+		defer func() {
+			if p := recover(); p != nil {
+				r.log.Errorf("handler panic: handler=%d chat=%d update=%d err=%v\n%s",
+					req.Key, req.ChatID, req.UpdateID, p, debug.Stack())
+				r.reply(req, msgDefaultErr, nil)
+			}
+		}()
+
+		next(req)
+	}
+}
+
+// answerCallback - answers the callback query after it is processed, so the button stops loading.
+func (r *Router) answerCallback(next HandlerFunc) HandlerFunc {
+	return func(req *Request) {
+		next(req)
+
+		if req.CallbackQuery == nil {
+			return
+		}
+
+		if _, err := r.bot.AnswerCallbackQuery(tg.NewCallback(req.CallbackQuery.ID, "")); err != nil {
+			r.log.Errorf("can't answer callback query: err=%+v", errors.WithStack(err))
+		}
+	}
+}
+
+// logging - logs the processed update and the processing time.
+func (r *Router) logging(next HandlerFunc) HandlerFunc {
+	return func(req *Request) {
+		start := time.Now()
+		next(req)
+		r.log.Infof("update processed: handler=%d chat=%d update=%d duration=%s",
+			req.Key, req.ChatID, req.UpdateID, time.Since(start))
+	}
+}
+
+// rateLimit - rejects the messages and the pressed buttons of the chat over rateLimitUpdates
+// within rateLimitWindow, the first rejected one is answered. The inline queries are cached by Telegram
+// and aren't limited.
+func (r *Router) rateLimit(next HandlerFunc) HandlerFunc {
+	return func(req *Request) {
+		if req.InlineQuery != nil {
+			next(req)
+
+			return
+		}
+
+		n := r.limiter.hit(req.ChatID, time.Now())
+		if n > rateLimitUpdates {
+			if n == rateLimitUpdates+1 {
+				r.reply(req, msgTooManyRequests, nil)
+			}
+
+			return
+		}
+
+		next(req)
+	}
+}
+
+// resolveUser - loads the user bound to the chat, the request of an unbound chat has uuid.Nil.
+func (r *Router) resolveUser(next HandlerFunc) HandlerFunc {
+	return func(req *Request) {
+		userID, err := r.chatUserUC.GetChatUserID(req.ChatID)
+		if err != nil && err != model.ErrNil {
+			r.fail(req, err)
+
+			return
+		}
+
+		req.UserID = userID
+		next(req)
+	}
+}
+
+// resumeDialog - routes the answer to the dialog of the chat to the handler of the flow,
+// the answers without a dialog stay with the handler they were routed to.
+func (r *Router) resumeDialog(next HandlerFunc) HandlerFunc {
+	return func(req *Request) {
+		if !isDialogAnswer(req.Update) {
+			next(req)
+
+			return
+		}
+
+		dialog, err := r.dialogUC.Get(req.ChatID)
+		if err != nil && err != model.ErrNil {
+			r.fail(req, err)
+
+			return
+		}
+
+		h, ok := r.handlers[dialogFlows[dialog.Flow]]
+		if err == nil && ok {
+			req.Key, req.Requires, req.Dialog = dialogFlows[dialog.Flow], h.Requires(), &dialog
+		}
+
+		next(req)
+	}
+}
+
+// authorize - rejects the request of the handler with requirements if the chat isn't bound to a user.
+func (r *Router) authorize(next HandlerFunc) HandlerFunc {
+	return func(req *Request) {
+		if req.Requires != 0 && req.UserID == uuid.Nil {
+			r.reply(req, msgNoUser, nil)
+
+			return
+		}
+
+		next(req)
+	}
+}
+
+// loadToken - loads the MonoBank token of the user if the handler requires it.
+func (r *Router) loadToken(next HandlerFunc) HandlerFunc {
+	return func(req *Request) {
+		if req.Requires&RequireToken == 0 {
+			next(req)
+
+			return
+		}
+
+		token, err := r.tokenUC.Get(req.UserID)
+		switch {
+		case err == model.ErrNil:
+			r.reply(req, msgNoToken, nil)
+		case err != nil:
+			r.fail(req, err)
+		default:
+			req.Token = token
+			next(req)
+		}
+	}
+}
+
+// loadAccount - loads the account of the user if the handler requires it.
+func (r *Router) loadAccount(next HandlerFunc) HandlerFunc {
+	return func(req *Request) {
+		if req.Requires&RequireAccount == 0 {
+			next(req)
+
+			return
+		}
+
+		account, err := r.accountUC.Get(req.UserID)
+		switch {
+		case err == model.ErrNil:
+			r.reply(req, msgSetAccount, nil)
+		case err != nil:
+			r.fail(req, err)
+		default:
+			req.Account = account
+			next(req)
+		}
+	}
+}
+
+// reply - sends the catalog message to the chat, the inline queries are answered by their handler only.
+func (r *Router) reply(req *Request, key msgKey, a msgArgs) {
+	if req.InlineQuery == nil {
+		r.sendText(req.ChatID, req.Lang, key, a)
+	}
+}
+
+// fail - logs the error and reports it to the chat.
+func (r *Router) fail(req *Request, err error) {
+	if req.InlineQuery != nil {
+		r.log.Error(ErrStack(err))
+
+		return
+	}
+
+	r.sendDefaultErr(req.ChatID, req.Lang, err)
+}
+
+// newRateLimiter - builds the fixed window counter of the chat messages.
+func newRateLimiter(window time.Duration) *rateLimiter {
+	return &rateLimiter{window: window, counts: make(map[int64]int)}
+}
+
+// rateLimiter - counts the messages of every chat within the window, all the counters are reset
+// when the window is over, so the idle chats don't stay in memory.
+type rateLimiter struct {
+	window time.Duration
+
+	mu      sync.Mutex
+	started time.Time
+	counts  map[int64]int
+}
+
+// hit - counts the message of the chat, returns the number of the messages of the chat within the window.
+func (l *rateLimiter) hit(chatID int64, now time.Time) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now.Sub(l.started) >= l.window {
+		l.started = now
+		l.counts = make(map[int64]int)
+	}
+
+	l.counts[chatID]++
+
+	return l.counts[chatID]
+}
@@ -0,0 +1,132 @@
+package telegram
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	. "github.com/onsi/gomega"
+	"go.uber.org/zap"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+type chatUsers map[int64]uuid.UUID
+
+func (c chatUsers) SetChatUserID(chatID int64, userID uuid.UUID) error {
+	c[chatID] = userID
+
+	return nil
+}
+
+func (c chatUsers) GetChatUserID(chatID int64) (uuid.UUID, error) {
+	if userID, ok := c[chatID]; ok {
+		return userID, nil
+	}
+
+	return uuid.Nil, model.ErrNil
+}
+
+type userValues map[uuid.UUID]string
+
+func (v userValues) Get(userID uuid.UUID) (string, error) {
+	if value, ok := v[userID]; ok {
+		return value, nil
+	}
+
+	return "", model.ErrNil
+}
+
+func (v userValues) Set(userID uuid.UUID, value string) error {
+	v[userID] = value
+
+	return nil
+}
+
+type tokens struct{ userValues }
+
+func (t tokens) Set(uuid.UUID, string) (model.ClientInfo, error) { return model.ClientInfo{}, nil }
+
+func (t tokens) Status(uuid.UUID) (model.TokenStatus, error) { return model.TokenStatus{}, nil }
+
+type dialogs map[int64]model.Dialog
+
+func (d dialogs) Get(chatID int64) (model.Dialog, error) {
+	if dialog, ok := d[chatID]; ok {
+		return dialog, nil
+	}
+
+	return model.Dialog{}, model.ErrNil
+}
+
+func (d dialogs) Set(chatID int64, dialog model.Dialog) error {
+	d[chatID] = dialog
+
+	return nil
+}
+
+func (d dialogs) Delete(chatID int64) error {
+	delete(d, chatID)
+
+	return nil
+}
+
+type requestRecorder struct {
+	requires Requirement
+	requests []*Request
+}
+
+func (h *requestRecorder) Requires() Requirement { return h.requires }
+
+func (h *requestRecorder) Handle(r *Request) { h.requests = append(h.requests, r) }
+
+func TestRouter_Requirements(t *testing.T) {
+	RegisterTestingT(t)
+	userID := uuid.New()
+	users := chatUsers{1: userID}
+	report := &requestRecorder{requires: RequireToken | RequireAccount}
+	help := &requestRecorder{}
+	export := &requestRecorder{requires: RequireToken}
+	cancel := &requestRecorder{}
+
+	r := NewRouter(map[HandlerKey]Handler{TransactionsHandler: report, HelpHandler: help, ExportHandler: export,
+		DialogHandler: cancel}, users, tokens{userValues{userID: "token"}}, userValues{userID: "account"},
+		dialogs{1: {Flow: flowExport, Step: exportPeriodStep}}, NewBotWrapper(nil, zap.NewNop().Sugar(), nil, nil))
+
+	r.serve(TransactionsHandler, update(1, 1))
+	Ω(report.requests).To(HaveLen(1), errNotEqual)
+	Ω(report.requests[0].UserID).To(Equal(userID), errNotEqual)
+	Ω(report.requests[0].Token).To(Equal("token"), errNotEqual)
+	Ω(report.requests[0].Account).To(Equal("account"), errNotEqual)
+
+	r.serve(HelpHandler, update(2, 2))
+	Ω(help.requests).To(HaveLen(1), errNotEqual)
+	Ω(help.requests[0].UserID).To(Equal(uuid.Nil), errNotEqual)
+	Ω(help.requests[0].Token).To(BeEmpty(), errNotEqual)
+
+	r.serve(StartHandler, update(1, 3)) // no handler
+
+	answer := update(1, 4)
+	answer.Message.Text = "last month"
+	r.serve(DialogHandler, answer)
+	Ω(export.requests).To(HaveLen(1), errNotEqual)
+	Ω(export.requests[0].Dialog.Step).To(Equal(exportPeriodStep), errNotEqual)
+	Ω(export.requests[0].Token).To(Equal("token"), errNotEqual)
+
+	answer = update(2, 5) // no dialog
+	answer.Message.Text = "last month"
+	r.serve(DialogHandler, answer)
+	Ω(cancel.requests).To(HaveLen(1), errNotEqual)
+	Ω(cancel.requests[0].Dialog).To(BeNil(), errNotEqual)
+}
+
+func TestRateLimiter(t *testing.T) {
+	RegisterTestingT(t)
+	l := newRateLimiter(time.Minute)
+	now := time.Now()
+
+	Ω(l.hit(1, now)).To(Equal(1), errNotEqual)
+	Ω(l.hit(1, now.Add(time.Second))).To(Equal(2), errNotEqual)
+	Ω(l.hit(2, now.Add(time.Second))).To(Equal(1), errNotEqual)
+	Ω(l.hit(1, now.Add(time.Minute))).To(Equal(1), errNotEqual)
+}
@@ -0,0 +1,138 @@
+package telegram
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tg "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+const (
+	scheduleListArg   = "list"
+	scheduleDeleteArg = "delete"
+)
+
+// ScheduleUC - represents a use-case interface for managing and running report schedules.
+type ScheduleUC interface {
+	Add(userID uuid.UUID, chatID int64, args string) (model.Schedule, error)
+	List(userID uuid.UUID) ([]model.Schedule, error)
+	Delete(userID uuid.UUID, id string) error
+	Due(now time.Time) ([]model.ScheduledRun, error)
+	Done(run model.ScheduledRun) error
+	Location(userID uuid.UUID) *time.Location
+}
+
+// NewSchedule - builds "Schedule" internal handler.
+func NewSchedule(scheduleUC ScheduleUC, botWrapper *BotWrapper) *Schedule {
+	return &Schedule{
+		scheduleUC: scheduleUC,
+		BotWrapper: botWrapper,
+	}
+}
+
+// Schedule - represents an internal handler for managing report schedules.
+type Schedule struct {
+	scheduleUC ScheduleUC
+	*BotWrapper
+}
+
+// Requires - the schedules are saved for the user of the chat.
+func (s *Schedule) Requires() Requirement {
+	return RequireUser
+}
+
+// Handle - process the "schedule" command, send the result to the user.
+func (s *Schedule) Handle(r *Request) {
+	chatID, lang, userID := r.ChatID, r.Lang, r.UserID
+	args := strings.TrimSpace(r.Message.CommandArguments())
+	fields := strings.Fields(args)
+
+	switch {
+	case len(fields) == 0 || fields[0] == scheduleListArg:
+		s.list(chatID, lang, userID)
+	case fields[0] == scheduleDeleteArg && len(fields) == 2:
+		err := s.scheduleUC.Delete(userID, fields[1])
+		if err == model.ErrNil {
+			s.sendText(chatID, lang, msgScheduleUnknown, msgArgs{"ID": fields[1]})
+
+			return
+		}
+
+		if err != nil {
+			s.sendDefaultErr(chatID, lang, err)
+
+			return
+		}
+
+		s.sendText(chatID, lang, msgScheduleDeleted, nil)
+	default:
+		schedule, err := s.scheduleUC.Add(userID, chatID, args)
+		if validationErr, ok := errors.Cause(err).(model.ValidationError); ok {
+			s.sendText(chatID, lang, msgInvalidUsage, msgArgs{
+				"Error": validationText(lang, validationErr),
+				"Usage": translate(lang, msgScheduleUsage, nil),
+			})
+
+			return
+		}
+
+		if err != nil {
+			s.sendDefaultErr(chatID, lang, err)
+
+			return
+		}
+
+		description := describeSchedule(schedule, s.scheduleUC.Location(userID), lang)
+		s.sendText(chatID, lang, msgScheduleAdded, msgArgs{"Schedule": description})
+	}
+}
+
+func (s *Schedule) list(chatID int64, lang string, userID uuid.UUID) {
+	schedules, err := s.scheduleUC.List(userID)
+	if err != nil {
+		s.sendDefaultErr(chatID, lang, err)
+
+		return
+	}
+
+	if len(schedules) == 0 {
+		s.sendText(chatID, lang, msgScheduleNone, msgArgs{"Usage": translate(lang, msgScheduleUsage, nil)})
+
+		return
+	}
+
+	loc := s.scheduleUC.Location(userID)
+	lines := make([]string, 0, len(schedules))
+	for _, schedule := range schedules {
+		lines = append(lines, describeSchedule(schedule, loc, lang))
+	}
+
+	s.sendMSG(tg.NewMessage(chatID, strings.Join(lines, "\n")))
+}
+
+// describeSchedule - returns the schedule description in the language and the user's time zone,
+// e.g. "1a2b3c4d: monthly on day 1 at 09:00 (Europe/Kiev), csv".
+func describeSchedule(s model.Schedule, loc *time.Location, lang string) string {
+	var when string
+	switch s.Period {
+	case model.PeriodWeekly:
+		when = translate(lang, msgScheduleWeekly, msgArgs{"Weekday": time.Weekday(s.Day)})
+	case model.PeriodMonthly:
+		when = translate(lang, msgScheduleMonthly, msgArgs{"Day": s.Day})
+	default:
+		when = translate(lang, msgScheduleDaily, nil)
+	}
+
+	return translate(lang, msgSchedule, msgArgs{
+		"ID":       s.ID,
+		"When":     when,
+		"Time":     fmt.Sprintf("%02d:%02d", s.Hour, s.Minute),
+		"Location": loc,
+		"Format":   s.Format,
+	})
+}
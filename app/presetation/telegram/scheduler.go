@@ -0,0 +1,146 @@
+package telegram
+
+import (
+	"context"
+	"io"
+	"time"
+
+	tg "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/pkg/errors"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+const schedulerInterval = time.Minute
+
+// NewScheduler - builds "Scheduler" delivering scheduled reports.
+func NewScheduler(scheduleUC ScheduleUC, tokenUC TokenUC, accountUC AccountUC, transactionUC TransactionUC,
+	botWrapper *BotWrapper) *Scheduler {
+	return &Scheduler{
+		scheduleUC:    scheduleUC,
+		tokenUC:       tokenUC,
+		accountUC:     accountUC,
+		transactionUC: transactionUC,
+		BotWrapper:    botWrapper,
+	}
+}
+
+// Scheduler - checks the schedules every minute and sends the due reports to the chats.
+type Scheduler struct {
+	scheduleUC    ScheduleUC
+	tokenUC       TokenUC
+	accountUC     AccountUC
+	transactionUC TransactionUC
+	*BotWrapper
+}
+
+// Run - sends the due reports until ctx is canceled. The first check is made at once,
+// so the runs missed while the service was stopped are caught up.
+func (s *Scheduler) Run(ctx context.Context) error {
+	ticker := time.NewTicker(schedulerInterval)
+	defer ticker.Stop()
+
+	for {
+		s.runDue(ctx, time.Now())
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Scheduler) runDue(ctx context.Context, now time.Time) {
+	runs, err := s.scheduleUC.Due(now)
+	if err != nil {
+		s.log.Errorf("can't get due schedules: err=%+v", err)
+
+		return
+	}
+
+	for _, run := range runs {
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err := s.deliver(run); err != nil {
+			s.log.Errorf("can't deliver scheduled report: user=%v schedule=%s err=%+v", run.UserID, run.Schedule.ID, err)
+
+			continue
+		}
+
+		if err := s.scheduleUC.Done(run); err != nil {
+			s.log.Errorf("can't save schedule run: user=%v schedule=%s err=%+v", run.UserID, run.Schedule.ID, err)
+		}
+	}
+}
+
+// deliver - sends the report of the run to the chat. The run is retried on the next check if an error
+// is returned, if the token or the account isn't set it's reported to the chat instead of the report.
+func (s *Scheduler) deliver(run model.ScheduledRun) error {
+	chatID := run.Schedule.ChatID
+	lang := s.userLanguage(run.UserID)
+
+	token, err := s.tokenUC.Get(run.UserID)
+	if err == model.ErrNil {
+		s.sendText(chatID, lang, msgScheduledToken, nil)
+
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	account, err := s.accountUC.Get(run.UserID)
+	if err == model.ErrNil {
+		s.sendText(chatID, lang, msgScheduledAcc, nil)
+
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	from, to := run.Schedule.Range(run.At)
+	if run.Schedule.Format == model.ReportFormatSummary {
+		summary, err := s.transactionUC.Summary(token, account, run.UserID, from, to)
+		if err != nil {
+			return err
+		}
+
+		_, err = s.bot.Send(tg.NewMessage(chatID, formatSummary(summary, lang, s.transactionUC.Locale(run.UserID))))
+
+		return errors.WithStack(err)
+	}
+
+	report, name, err := s.report(token, account, run, from, to)
+	if err != nil {
+		return err
+	}
+
+	msg := tg.NewDocumentUpload(chatID, tg.FileReader{Name: name, Reader: report, Size: -1})
+	msg.Caption = translate(lang, msgScheduledReport, msgArgs{
+		"Period": translate(lang, periodMessages[run.Schedule.Period], nil),
+		"ID":     run.Schedule.ID,
+	})
+	_, err = s.bot.Send(msg)
+
+	return errors.WithStack(err)
+}
+
+// report - returns the report document of the run in the format of the schedule and its file name.
+func (s *Scheduler) report(token, account string, run model.ScheduledRun, from, to time.Time) (
+	io.Reader, string, error) {
+	if run.Schedule.Format == model.ReportFormatXLSX {
+		report, err := s.transactionUC.XLSXReport(token, account, run.UserID, from, to)
+
+		return report, reportName(from, to, ".xlsx"), err
+	}
+
+	report, err := s.transactionUC.GetTransactions(token, account, run.UserID, from, to, false)
+
+	return report, reportName(from, to, ".csv"), err
+}
@@ -0,0 +1,189 @@
+package telegram
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tg "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/google/uuid"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+const (
+	// csvFlag - the search argument making the found transactions sent as the CSV report too.
+	csvFlag = "--csv"
+	// searchMaxLines - the number of the found transactions listed in the message.
+	searchMaxLines = 20
+	// maxPeriodWords - the number of the words of the longest period, e.g. "last 7 days".
+	maxPeriodWords = 3
+)
+
+// NewSearch - builds "Search" internal handler.
+func NewSearch(tr TransactionUC, b *BotWrapper) *Search {
+	return &Search{
+		transactionUC: tr,
+		BotWrapper:    b,
+	}
+}
+
+// Search - represents an internal handler searching the transactions.
+type Search struct {
+	transactionUC TransactionUC
+	*BotWrapper
+}
+
+// Requires - the transactions are searched in the statement of the account of the user.
+func (s *Search) Requires() Requirement {
+	return RequireToken | RequireAccount
+}
+
+// Handle - process the "search" command: "/search <text> [period] [min..max] [--csv]",
+// see parseSearch for the arguments.
+func (s *Search) Handle(r *Request) {
+	chatID, lang, userID := r.ChatID, r.Lang, r.UserID
+	args, sendCSV := cutFlag(r.Message.CommandArguments(), csvFlag)
+	query, err := parseSearch(s.transactionUC, userID, args)
+	if err != nil {
+		s.sendInputErr(chatID, lang, err)
+
+		return
+	}
+
+	if query.Text == "" {
+		s.sendText(chatID, lang, msgSearchUsage, nil)
+
+		return
+	}
+
+	result, err := s.transactionUC.Search(r.Token, r.Account, userID, query)
+	if err != nil {
+		s.sendDefaultErr(chatID, lang, err)
+
+		return
+	}
+
+	s.sendMSG(tg.NewMessage(chatID, formatSearch(result, lang, s.transactionUC.Locale(userID))))
+
+	if !sendCSV || len(result.Transactions) == 0 {
+		return
+	}
+
+	report, err := s.transactionUC.SearchReport(userID, result)
+	if err != nil {
+		s.sendDefaultErr(chatID, lang, err)
+
+		return
+	}
+
+	s.sendMSG(tg.NewDocumentUpload(chatID, tg.FileReader{
+		Name:   "search-" + reportName(result.From, result.To, ".csv"),
+		Reader: report,
+		Size:   -1,
+	}))
+}
+
+// parseSearch - parses the search arguments "<text> [period] [min..max]": the period is the same as in
+// the "get" command, e.g. "last month", the amount range is in the currency units, e.g. "100..250.50".
+// The last 31 days are searched if there is no period, the period is in the user's time zone.
+func parseSearch(transactionUC TransactionUC, userID uuid.UUID, args string) (model.SearchQuery, error) {
+	var (
+		query               model.SearchQuery
+		hasRange, hasPeriod bool
+		fields              = strings.Fields(args)
+	)
+
+loop:
+	for len(fields) > 1 {
+		if !hasPeriod {
+			if n, from, to, ok := trailingPeriod(transactionUC, userID, fields); ok {
+				query.From, query.To, hasPeriod = from, to, true
+				fields = fields[:len(fields)-n]
+
+				continue
+			}
+		}
+
+		last := fields[len(fields)-1]
+		if hasRange || !strings.Contains(last, "..") {
+			break loop
+		}
+
+		min, max, err := transactionUC.ParseAmountRange(last)
+		if err != nil {
+			return model.SearchQuery{}, err
+		}
+		query.Min, query.Max, hasRange = min, max, true
+		fields = fields[:len(fields)-1]
+	}
+	query.Text = strings.Join(fields, " ")
+
+	return query, nil
+}
+
+// trailingPeriod - returns the number of the last fields making the period, the longest period first,
+// at least one field is left for the text. A word like "7-eleven" is a part of the text.
+func trailingPeriod(transactionUC TransactionUC, userID uuid.UUID, fields []string) (
+	n int, from, to time.Time, ok bool) {
+	for n = maxPeriodWords; n > 0; n-- {
+		if n >= len(fields) {
+			continue
+		}
+
+		var err error
+		if from, to, err = transactionUC.ParseDate(userID, strings.Join(fields[len(fields)-n:], " ")); err == nil {
+			return n, from, to, true
+		}
+	}
+
+	return 0, from, to, false
+}
+
+// formatSearch - renders the found transactions as a chat message in the language, the latest first,
+// the dates follow the locale.
+func formatSearch(r model.SearchResult, lang string, locale model.Locale) string {
+	b := &strings.Builder{}
+	a := msgArgs{
+		"Count": len(r.Transactions),
+		"Text":  r.Text,
+		"From":  r.From.Format(locale.DateTime),
+		"To":    r.To.Format(locale.DateTime),
+	}
+	if len(r.Transactions) == 0 {
+		return translate(lang, msgSearchNothing, a)
+	}
+
+	b.WriteString(translate(lang, msgSearchFound, a))
+	for i, tr := range r.Transactions {
+		if i == searchMaxLines {
+			b.WriteString(translate(lang, msgSearchMore, msgArgs{
+				"Count": len(r.Transactions) - searchMaxLines,
+				"Flag":  csvFlag,
+			}))
+
+			break
+		}
+
+		b.WriteString(formatFound(tr, locale))
+		b.WriteString("\n")
+	}
+
+	return b.String()
+}
+
+// formatFound - renders the found transaction as a line, e.g. "01.03.2020 12:00 Vet Clinic (Rex) [Pets] -450.00".
+func formatFound(tr model.FoundTransaction, locale model.Locale) string {
+	return fmt.Sprintf("%s %s [%s] %s", tr.Time.Format(locale.DateTime), foundDescription(tr), tr.Category,
+		formatAmount(tr.Amount))
+}
+
+// foundDescription - returns the description of the found transaction with the comment in one line.
+func foundDescription(tr model.FoundTransaction) string {
+	description := strings.ReplaceAll(tr.Description, "\n", " ")
+	if tr.Comment != "" {
+		description = fmt.Sprintf("%s (%s)", description, strings.ReplaceAll(tr.Comment, "\n", " "))
+	}
+
+	return description
+}
@@ -0,0 +1,29 @@
+package telegram
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+func TestFormatSearch(t *testing.T) {
+	RegisterTestingT(t)
+	from := time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2020, 3, 31, 23, 59, 0, 0, time.UTC)
+	result := model.SearchResult{SearchQuery: model.SearchQuery{Text: "vet", From: from, To: to}}
+	uk, _ := model.FindLocale("uk")
+	us, _ := model.FindLocale("en-US")
+
+	Ω(formatSearch(result, "en", uk)).To(Equal(`Nothing found for "vet", 01.03.2020 00:00 - 31.03.2020 23:59.`), errNotEqual)
+
+	result.Transactions = []model.FoundTransaction{
+		{Time: from.Add(12 * time.Hour), Description: "Vet Clinic", Comment: "Rex", Category: "Pets", Amount: -45000},
+	}
+	Ω(formatSearch(result, "en", uk)).To(Equal("Found 1 transaction for \"vet\", 01.03.2020 00:00 - 31.03.2020 23:59:\n"+
+		"01.03.2020 12:00 Vet Clinic (Rex) [Pets] -450.00\n"), errNotEqual)
+	Ω(formatSearch(result, "en", us)).To(Equal("Found 1 transaction for \"vet\", 03/01/2020 12:00 AM - 03/31/2020 11:59 PM:\n"+
+		"03/01/2020 12:00 PM Vet Clinic (Rex) [Pets] -450.00\n"), errNotEqual)
+}
@@ -0,0 +1,139 @@
+package telegram
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+// SettingsUC - represents a use-case interface for managing the user's time zone, locale and language.
+type SettingsUC interface {
+	Get(userID uuid.UUID) model.Settings
+	SetTimezone(userID uuid.UUID, name string) (*time.Location, error)
+	SetLocale(userID uuid.UUID, tag string) (model.Locale, error)
+	SetLanguage(userID uuid.UUID, tag string) (string, error)
+	Location(userID uuid.UUID) *time.Location
+	Locale(userID uuid.UUID) model.Locale
+}
+
+// NewSettings - builds "Settings" internal handler.
+func NewSettings(settingsUC SettingsUC, botWrapper *BotWrapper) *Settings {
+	return &Settings{
+		settingsUC: settingsUC,
+		BotWrapper: botWrapper,
+	}
+}
+
+// Settings - represents an internal handler for the user's time zone, locale and language.
+type Settings struct {
+	settingsUC SettingsUC
+	*BotWrapper
+}
+
+// Requires - the settings are saved for the user of the chat.
+func (s *Settings) Requires() Requirement {
+	return RequireUser
+}
+
+// Handle - process the "timezone", "locale" and "lang" commands, without arguments the current value is sent.
+func (s *Settings) Handle(r *Request) {
+	chatID, lang, userID := r.ChatID, r.Lang, r.UserID
+	arg := strings.TrimSpace(r.Message.CommandArguments())
+
+	switch r.Message.Command() {
+	case timezoneCommand:
+		s.timezone(chatID, lang, userID, arg)
+	case localeCommand:
+		s.locale(chatID, lang, userID, arg)
+	case langCommand:
+		s.lang(chatID, lang, userID, arg)
+	default:
+		s.sendDefaultErr(chatID, lang, errors.New("can't detect command"))
+	}
+}
+
+func (s *Settings) timezone(chatID int64, lang string, userID uuid.UUID, name string) {
+	if name == "" {
+		s.sendText(chatID, lang, msgTimezone, msgArgs{"Timezone": s.settingsUC.Get(userID).Timezone})
+
+		return
+	}
+
+	loc, err := s.settingsUC.SetTimezone(userID, name)
+	if validationErr, ok := errors.Cause(err).(model.ValidationError); ok {
+		s.sendText(chatID, lang, msgInvalidInput, msgArgs{"Error": validationText(lang, validationErr)})
+
+		return
+	}
+
+	if err != nil {
+		s.sendDefaultErr(chatID, lang, err)
+
+		return
+	}
+
+	s.sendText(chatID, lang, msgTimezoneSet, msgArgs{
+		"Timezone": loc.String(),
+		"Time":     time.Now().In(loc).Format(s.settingsUC.Locale(userID).DateTime),
+	})
+}
+
+func (s *Settings) locale(chatID int64, lang string, userID uuid.UUID, tag string) {
+	if tag == "" {
+		lines := make([]string, 0)
+		for _, l := range model.Locales() {
+			lines = append(lines, fmt.Sprintf("%s - %s, %s", l.Tag, l.Name, time.Now().Format(l.DateTime)))
+		}
+
+		s.sendText(chatID, lang, msgLocale, msgArgs{
+			"Locale":  s.settingsUC.Get(userID).Locale,
+			"Locales": strings.Join(lines, "\n"),
+		})
+
+		return
+	}
+
+	locale, err := s.settingsUC.SetLocale(userID, tag)
+	if validationErr, ok := errors.Cause(err).(model.ValidationError); ok {
+		s.sendText(chatID, lang, msgInvalidInput, msgArgs{"Error": validationText(lang, validationErr)})
+
+		return
+	}
+
+	if err != nil {
+		s.sendDefaultErr(chatID, lang, err)
+
+		return
+	}
+
+	s.sendText(chatID, lang, msgLocaleSet, msgArgs{"Tag": locale.Tag, "Name": locale.Name})
+}
+
+// lang - sets the language of the messages, the reply is in the new language.
+func (s *Settings) lang(chatID int64, lang string, userID uuid.UUID, tag string) {
+	if tag == "" {
+		s.sendText(chatID, lang, msgLanguage, nil)
+
+		return
+	}
+
+	newLang, err := s.settingsUC.SetLanguage(userID, tag)
+	if validationErr, ok := errors.Cause(err).(model.ValidationError); ok {
+		s.sendText(chatID, lang, msgInvalidInput, msgArgs{"Error": validationText(lang, validationErr)})
+
+		return
+	}
+
+	if err != nil {
+		s.sendDefaultErr(chatID, lang, err)
+
+		return
+	}
+
+	s.sendText(chatID, newLang, msgLanguageSet, nil)
+}
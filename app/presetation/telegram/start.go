@@ -0,0 +1,114 @@
+package telegram
+
+import (
+	"strings"
+
+	tg "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/google/uuid"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+// setupStep - represents a step of the setup: the name of it, whether it is done and how to do it.
+type setupStep struct {
+	name msgKey
+	done bool
+	how  msgKey
+}
+
+// NewStart - builds "Start" internal handler.
+func NewStart(tokenUC TokenUC, accountUC AccountUC, mappingUC MappingUC, botWrapper *BotWrapper) *Start {
+	return &Start{
+		tokenUC:    tokenUC,
+		accountUC:  accountUC,
+		mappingUC:  mappingUC,
+		BotWrapper: botWrapper,
+	}
+}
+
+// Start - represents an internal handler walking the user through the setup.
+type Start struct {
+	tokenUC   TokenUC
+	accountUC AccountUC
+	mappingUC MappingUC
+	*BotWrapper
+}
+
+// Requires - nothing, the handler checks the requirements itself to show the missing ones.
+func (s *Start) Requires() Requirement {
+	return 0
+}
+
+// Handle - process the "start" command: shows which setup steps are done and how to do the next one.
+func (s *Start) Handle(r *Request) {
+	steps, err := s.steps(r.UserID)
+	if err != nil {
+		s.sendDefaultErr(r.ChatID, r.Lang, err)
+
+		return
+	}
+
+	s.sendMSG(tg.NewMessage(r.ChatID, formatSetup(steps, r.Lang)))
+}
+
+// steps - checks the setup steps of the user: the user binding, the token, the account and the mapping,
+// the steps after the user binding aren't done until the chat is bound.
+func (s *Start) steps(userID uuid.UUID) ([]setupStep, error) {
+	steps := []setupStep{
+		{name: msgStepUser, how: msgStepUserHow},
+		{name: msgStepToken, how: msgStepTokenHow},
+		{name: msgStepAccount, how: msgStepAccountHow},
+		{name: msgStepMapping, how: msgStepMappingHow},
+	}
+
+	if userID == uuid.Nil {
+		return steps, nil
+	}
+
+	checks := []func() error{
+		func() error { return nil },
+		func() error { _, err := s.tokenUC.Get(userID); return err },
+		func() error { _, err := s.accountUC.Get(userID); return err },
+		func() error { _, err := s.mappingUC.Get(userID); return err },
+	}
+	for i, check := range checks {
+		err := check()
+		if err != nil && err != model.ErrNil {
+			return nil, err
+		}
+
+		steps[i].done = err == nil
+	}
+
+	return steps, nil
+}
+
+// formatSetup - renders the setup steps and the instructions of the first step to do.
+func formatSetup(steps []setupStep, lang string) string {
+	b := &strings.Builder{}
+	b.WriteString(translate(lang, msgStart, nil))
+
+	var next *setupStep
+	for i, step := range steps {
+		mark := "✅"
+		if !step.done {
+			mark = "⬜"
+			if next == nil {
+				next = &steps[i]
+			}
+		}
+
+		b.WriteString(mark + " " + translate(lang, step.name, nil) + "\n")
+	}
+
+	b.WriteString("\n")
+	if next == nil {
+		b.WriteString(translate(lang, msgStartReady, nil))
+
+		return b.String()
+	}
+
+	b.WriteString(translate(lang, next.how, msgArgs{"UserID": uuid.New().String()}))
+
+	return b.String()
+}
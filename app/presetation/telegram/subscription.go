@@ -0,0 +1,165 @@
+package telegram
+
+import (
+	"strings"
+	"time"
+
+	tg "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+const subscriptionOffArg = "off"
+
+// SubscriptionUC - represents a use-case interface for detecting recurring charges.
+type SubscriptionUC interface {
+	Detect(transactions []model.Transaction, now time.Time) []model.Subscription
+	Watch(userID uuid.UUID, chatID int64) error
+	Unwatch(userID uuid.UUID) error
+	Watched() (map[uuid.UUID]int64, error)
+	Warnings(userID uuid.UUID, subscriptions []model.Subscription) ([]model.SubscriptionWarning, error)
+	WarningSent(userID uuid.UUID, w model.SubscriptionWarning) error
+}
+
+// NewSubscription - builds "Subscription" internal handler.
+func NewSubscription(subscriptionUC SubscriptionUC, historyUC HistoryUC, settingsUC SettingsUC,
+	botWrapper *BotWrapper) *Subscription {
+	return &Subscription{
+		subscriptionUC: subscriptionUC,
+		historyUC:      historyUC,
+		settingsUC:     settingsUC,
+		BotWrapper:     botWrapper,
+	}
+}
+
+// Subscription - represents an internal handler listing recurring charges.
+type Subscription struct {
+	subscriptionUC SubscriptionUC
+	historyUC      HistoryUC
+	settingsUC     SettingsUC
+	*BotWrapper
+}
+
+// Requires - the subscriptions are detected in the history of the account of the user.
+func (s *Subscription) Requires() Requirement {
+	return RequireToken | RequireAccount
+}
+
+// Handle - process the "subscriptions" command: lists the recurring charges and enables the warnings
+// about their changes, "off" disables the warnings.
+func (s *Subscription) Handle(r *Request) {
+	chatID, lang, userID := r.ChatID, r.Lang, r.UserID
+	if strings.TrimSpace(r.Message.CommandArguments()) == subscriptionOffArg {
+		if err := s.subscriptionUC.Unwatch(userID); err != nil {
+			s.sendDefaultErr(chatID, lang, err)
+
+			return
+		}
+
+		s.sendText(chatID, lang, msgSubsOff, nil)
+
+		return
+	}
+
+	now := time.Now().In(s.settingsUC.Location(userID))
+	state, err := s.historyUC.Sync(userID, r.Token, r.Account, now)
+	if errors.Cause(err) == model.ErrRateLimited {
+		state, err = s.historyUC.State(r.Account, now)
+	}
+
+	if err != nil {
+		s.sendDefaultErr(chatID, lang, err)
+
+		return
+	}
+
+	if state.To.IsZero() {
+		s.sendText(chatID, lang, msgRateLimited, nil)
+
+		return
+	}
+
+	transactions, err := s.historyUC.Get(r.Account, state.From, now)
+	if err != nil {
+		s.sendDefaultErr(chatID, lang, err)
+
+		return
+	}
+
+	if err := s.subscriptionUC.Watch(userID, chatID); err != nil {
+		s.sendDefaultErr(chatID, lang, err)
+
+		return
+	}
+
+	s.sendMSG(tg.NewMessage(chatID, formatSubscriptions(s.subscriptionUC.Detect(transactions, now), state,
+		lang, s.settingsUC.Locale(userID))))
+}
+
+// formatSubscriptions - renders the subscriptions list as a chat message in the language,
+// the dates follow the locale.
+func formatSubscriptions(subscriptions []model.Subscription, state model.HistoryState, lang string,
+	locale model.Locale) string {
+	b := &strings.Builder{}
+	if len(subscriptions) == 0 {
+		b.WriteString(translate(lang, msgSubsNone, nil))
+	} else {
+		var total int64
+		b.WriteString(translate(lang, msgSubs, nil))
+		for _, sub := range subscriptions {
+			total += sub.MonthlyCost
+			b.WriteString(translate(lang, msgSub, msgArgs{
+				"Description": sub.Description,
+				"Amount":      formatAmount(sub.Amount),
+				"Period":      translate(lang, periodMessages[sub.Period], nil),
+				"MonthlyCost": formatAmount(sub.MonthlyCost),
+				"Next":        sub.NextCharge.Format(locale.Date),
+				"Notes":       subscriptionNotes(sub, lang),
+			}))
+		}
+		b.WriteString(translate(lang, msgSubsTotal, msgArgs{"Total": formatAmount(total)}))
+	}
+
+	if !state.Complete {
+		b.WriteString(translate(lang, msgSubsPartial, msgArgs{"From": state.From.Format(locale.Date)}))
+	}
+	b.WriteString(translate(lang, msgSubsFooter, nil))
+
+	return b.String()
+}
+
+// subscriptionNotes - returns the notes about the subscription changes, empty if there are none.
+func subscriptionNotes(sub model.Subscription, lang string) string {
+	var notes []string
+	if sub.PriceChanged {
+		notes = append(notes, translate(lang, msgSubPriceNote, msgArgs{"Previous": formatAmount(sub.PreviousAmount)}))
+	}
+
+	if sub.Missed {
+		notes = append(notes, translate(lang, msgSubMissedNote, nil))
+	}
+
+	return strings.Join(notes, ", ")
+}
+
+// formatSubscriptionWarning - renders the warning as a chat message in the language, the dates follow the locale.
+func formatSubscriptionWarning(w model.SubscriptionWarning, lang string, locale model.Locale) string {
+	sub := w.Subscription
+	if w.Kind == model.WarningMissed {
+		return translate(lang, msgSubMissed, msgArgs{
+			"Period":      translate(lang, periodMessages[sub.Period], nil),
+			"Description": sub.Description,
+			"Amount":      formatAmount(sub.Amount),
+			"Date":        sub.NextCharge.Format(locale.Date),
+		})
+	}
+
+	return translate(lang, msgSubPrice, msgArgs{
+		"Description": sub.Description,
+		"Previous":    formatAmount(sub.PreviousAmount),
+		"Amount":      formatAmount(sub.Amount),
+		"Change":      formatChange(lang, sub.Amount, sub.PreviousAmount),
+	})
+}
@@ -0,0 +1,30 @@
+package telegram
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+func TestFormatSubscriptionWarning(t *testing.T) {
+	RegisterTestingT(t)
+	sub := model.Subscription{
+		Description:    "Netflix",
+		Period:         model.SubscriptionMonthly,
+		Amount:         22900,
+		PreviousAmount: 19900,
+		NextCharge:     time.Date(2020, 9, 3, 10, 0, 0, 0, time.UTC),
+	}
+	uk, _ := model.FindLocale("uk")
+	us, _ := model.FindLocale("en-US")
+
+	Ω(formatSubscriptionWarning(model.SubscriptionWarning{Kind: model.WarningPriceChanged, Subscription: sub}, "en", uk)).
+		To(Equal("The price of Netflix is changed from 199.00 to 229.00 (+15%)."), errNotEqual)
+	Ω(formatSubscriptionWarning(model.SubscriptionWarning{Kind: model.WarningMissed, Subscription: sub}, "en", uk)).
+		To(Equal("The monthly charge of Netflix (229.00) expected on 03.09.2020 didn't happen."), errNotEqual)
+	Ω(formatSubscriptionWarning(model.SubscriptionWarning{Kind: model.WarningMissed, Subscription: sub}, "en", us)).
+		To(Equal("The monthly charge of Netflix (229.00) expected on 09/03/2020 didn't happen."), errNotEqual)
+}
@@ -0,0 +1,108 @@
+package telegram
+
+import (
+	"context"
+	"time"
+
+	tg "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/google/uuid"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+// subscriptionCheckInterval - the subscriptions are detected in the history synced by "HistorySync".
+const subscriptionCheckInterval = 30 * time.Minute
+
+// NewSubscriptionWatcher - builds "SubscriptionWatcher" sending subscription warnings.
+func NewSubscriptionWatcher(subscriptionUC SubscriptionUC, historyUC HistoryUC, accountUC AccountUC,
+	settingsUC SettingsUC, botWrapper *BotWrapper) *SubscriptionWatcher {
+	return &SubscriptionWatcher{
+		subscriptionUC: subscriptionUC,
+		historyUC:      historyUC,
+		accountUC:      accountUC,
+		settingsUC:     settingsUC,
+		BotWrapper:     botWrapper,
+	}
+}
+
+// SubscriptionWatcher - warns the users with enabled subscription warnings when a subscription's price
+// changes or an expected charge didn't happen.
+type SubscriptionWatcher struct {
+	subscriptionUC SubscriptionUC
+	historyUC      HistoryUC
+	accountUC      AccountUC
+	settingsUC     SettingsUC
+	*BotWrapper
+}
+
+// Run - checks the subscriptions until ctx is canceled, the first check is made at once.
+func (w *SubscriptionWatcher) Run(ctx context.Context) error {
+	ticker := time.NewTicker(subscriptionCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		w.check(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (w *SubscriptionWatcher) check(ctx context.Context) {
+	chats, err := w.subscriptionUC.Watched()
+	if err != nil {
+		w.log.Errorf("can't get subscription chats: err=%+v", err)
+
+		return
+	}
+
+	for userID, chatID := range chats {
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err := w.checkUser(userID, chatID, time.Now().In(w.settingsUC.Location(userID))); err != nil {
+			w.log.Errorf("can't check subscriptions: user=%v err=%+v", userID, err)
+		}
+	}
+}
+
+// checkUser - sends the warnings once the user's history is complete, the users without account are skipped.
+func (w *SubscriptionWatcher) checkUser(userID uuid.UUID, chatID int64, now time.Time) error {
+	account, err := w.accountUC.Get(userID)
+	if err == model.ErrNil {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	state, err := w.historyUC.State(account, now)
+	if err != nil || !state.Complete {
+		return err
+	}
+
+	transactions, err := w.historyUC.Get(account, state.From, now)
+	if err != nil {
+		return err
+	}
+
+	warnings, err := w.subscriptionUC.Warnings(userID, w.subscriptionUC.Detect(transactions, now))
+	if err != nil {
+		return err
+	}
+
+	for _, warning := range warnings {
+		w.sendMSG(tg.NewMessage(chatID, formatSubscriptionWarning(warning, w.userLanguage(userID), w.settingsUC.Locale(userID))))
+
+		if err := w.subscriptionUC.WarningSent(userID, warning); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,152 @@
+package telegram
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tg "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/google/uuid"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+// NewSummary - builds "Summary" internal handler.
+func NewSummary(tr TransactionUC, b *BotWrapper) *Summary {
+	return &Summary{
+		transactionUC: tr,
+		BotWrapper:    b,
+	}
+}
+
+// Summary - represents an internal handler sending the spending summary as a message.
+type Summary struct {
+	transactionUC TransactionUC
+	*BotWrapper
+}
+
+// Requires - the summary is built from the statement of the account of the user.
+func (s *Summary) Requires() Requirement {
+	return RequireToken | RequireAccount
+}
+
+// Handle - process the "summary" command, see parsePeriod for the period format.
+func (s *Summary) Handle(r *Request) {
+	chatID, lang, userID := r.ChatID, r.Lang, r.UserID
+	from, to, err := parsePeriod(s.transactionUC, userID, r.Message.CommandArguments())
+	if err != nil {
+		s.sendInputErr(chatID, lang, err)
+
+		return
+	}
+
+	summary, err := s.transactionUC.Summary(r.Token, r.Account, userID, from, to)
+	if err != nil {
+		s.sendDefaultErr(chatID, lang, err)
+
+		return
+	}
+
+	s.sendMSG(tg.NewMessage(chatID, formatSummary(summary, lang, s.transactionUC.Locale(userID))))
+}
+
+// parsePeriod - parses the period of the "summary" and "chart" commands, the same as in the "get" command,
+// the current month if it is empty, in the user's time zone.
+func parsePeriod(transactionUC TransactionUC, userID uuid.UUID, args string) (from, to time.Time, err error) {
+	period := strings.TrimSpace(args)
+	if period == "" {
+		period = currentMonthCommand
+	}
+
+	return transactionUC.ParseDate(userID, period)
+}
+
+// formatSummary - renders the summary as a chat message in the language, the dates follow the locale.
+func formatSummary(s model.Summary, lang string, locale model.Locale) string {
+	b := &strings.Builder{}
+	b.WriteString(translate(lang, msgSummary, msgArgs{
+		"From":     s.From.Format(locale.DateTime),
+		"To":       s.To.Format(locale.DateTime),
+		"Income":   formatAmount(s.Income),
+		"Expenses": formatAmount(s.Expenses),
+		"Net":      formatAmount(s.Net()),
+	}))
+
+	if len(s.TopCategories) > 0 {
+		b.WriteString(translate(lang, msgSummaryTop, nil))
+		for i, c := range s.TopCategories {
+			b.WriteString(translate(lang, msgSummaryCategory, msgArgs{
+				"N":        i + 1,
+				"Category": c.Category,
+				"Amount":   formatAmount(c.Amount),
+				"Share":    fmt.Sprintf("%.0f", c.Share*100),
+			}))
+		}
+	}
+
+	if len(s.BiggestPurchases) > 0 {
+		b.WriteString(translate(lang, msgSummaryBiggest, nil))
+		for _, p := range s.BiggestPurchases {
+			b.WriteString(translate(lang, msgSummaryPurchase, msgArgs{
+				"Time":        p.Time.Format(locale.DayTime),
+				"Description": p.Description,
+				"Category":    p.Category,
+				"Amount":      formatAmount(p.Amount),
+			}))
+		}
+	}
+
+	if s.Previous == nil {
+		b.WriteString(translate(lang, msgSummaryNoPrev, nil))
+
+		return b.String()
+	}
+
+	p := s.Previous
+	b.WriteString(translate(lang, msgSummaryPrev, msgArgs{
+		"From":           p.From.Format(locale.DateTime),
+		"To":             p.To.Format(locale.DateTime),
+		"IncomeChange":   formatChange(lang, s.Income, p.Income),
+		"Income":         formatAmount(p.Income),
+		"ExpensesChange": formatChange(lang, s.Expenses, p.Expenses),
+		"Expenses":       formatAmount(p.Expenses),
+		"NetChange":      formatChange(lang, s.Net(), p.Net()),
+		"Net":            formatAmount(p.Net()),
+	}))
+
+	return b.String()
+}
+
+// formatAmount - formats the amount in minor units, e.g. -123456 as "-1 234.56".
+func formatAmount(amount int64) string {
+	sign := ""
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+
+	units := fmt.Sprintf("%d", amount/accuracy)
+	for i := len(units) - 3; i > 0; i -= 3 {
+		units = units[:i] + " " + units[i:]
+	}
+
+	return fmt.Sprintf("%s%s.%02d", sign, units, amount%accuracy)
+}
+
+// formatChange - formats the change of the current value relative to the previous one, e.g. "+12%".
+func formatChange(lang string, current, previous int64) string {
+	if previous == 0 {
+		return translate(lang, msgNotAvailable, nil)
+	}
+
+	change := float64(current-previous) / float64(abs(previous)) * 100
+
+	return fmt.Sprintf("%+.0f%%", change)
+}
+
+func abs(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+
+	return v
+}
@@ -0,0 +1,25 @@
+package telegram
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestFormatAmount(t *testing.T) {
+	RegisterTestingT(t)
+
+	Ω(formatAmount(0)).To(Equal("0.00"), errNotEqual)
+	Ω(formatAmount(5)).To(Equal("0.05"), errNotEqual)
+	Ω(formatAmount(123456)).To(Equal("1 234.56"), errNotEqual)
+	Ω(formatAmount(-123456789)).To(Equal("-1 234 567.89"), errNotEqual)
+}
+
+func TestFormatChange(t *testing.T) {
+	RegisterTestingT(t)
+
+	Ω(formatChange("en", 110, 100)).To(Equal("+10%"), errNotEqual)
+	Ω(formatChange("en", 50, 100)).To(Equal("-50%"), errNotEqual)
+	Ω(formatChange("en", 50, -100)).To(Equal("+150%"), errNotEqual)
+	Ω(formatChange("en", 50, 0)).To(Equal("n/a"), errNotEqual)
+}
@@ -1,45 +1,142 @@
 package telegram
 
 import (
-	tg "github.com/go-telegram-bot-api/telegram-bot-api"
 	"github.com/google/uuid"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+const (
+	tokenStatusArg         = "status"
+	tokenVerifiedAtPattern = "02.01.2006 15:04 MST"
 )
 
 // TokenUC - represents a usecase interface for processing "Token" business logic.
 type TokenUC interface {
-	Set(userID uuid.UUID, token string) error
+	Set(userID uuid.UUID, token string) (model.ClientInfo, error)
 	Get(userID uuid.UUID) (string, error)
+	Status(userID uuid.UUID) (model.TokenStatus, error)
 }
 
+// tokenStep - the step of the token dialog waiting for the token.
+const tokenStep = "token"
+
 // NewToken - builds "NewToken" internal handler.
-func NewToken(tokenUC TokenUC, chatUserUC ChatUserUC, botWrapper *BotWrapper) *Token {
-	return &Token{
+func NewToken(tokenUC TokenUC, dialogUC DialogUC, botWrapper *BotWrapper) *Token {
+	t := &Token{
 		tokenUC:    tokenUC,
-		chatUserUC: chatUserUC,
 		BotWrapper: botWrapper,
 	}
+	t.flow = newDialogFlow(flowToken, map[string]dialogStep{
+		tokenStep: {ask: t.askToken, answer: t.answerToken},
+	}, dialogUC, botWrapper)
+
+	return t
 }
 
 // Token - represents an internal handler for processing "Token".
 type Token struct {
-	tokenUC    TokenUC
-	chatUserUC ChatUserUC
+	tokenUC TokenUC
+	flow    *dialogFlow
 	*BotWrapper
 }
 
-// Handle - process the "Token", send the result to the user.
-func (t *Token) Handle(u tg.Update) { // nolint:dupl
-	userID, err := t.chatUserUC.GetChatUserID(u.Message.Chat.ID)
+// Requires - nothing, the token is removed from the chat history before the user of the chat is checked.
+func (t *Token) Requires() Requirement {
+	return 0
+}
+
+// Handle - process the "Token", send the result to the user, "/token" without the token asks for it.
+func (t *Token) Handle(r *Request) {
+	chatID, lang := r.ChatID, r.Lang
+	if r.Dialog != nil {
+		if r.Message != nil {
+			// The answer is the token, remove it from the chat history before anything else.
+			t.deleteMSG(chatID, r.Message.MessageID)
+		}
+
+		t.flow.resume(r)
+
+		return
+	}
+
+	args := r.Message.CommandArguments()
+
+	if args != tokenStatusArg {
+		// The token is a secret, remove it from the chat history before anything else.
+		t.deleteMSG(chatID, r.Message.MessageID)
+	}
+
+	if r.UserID == uuid.Nil {
+		t.sendText(chatID, lang, msgNoUser, nil)
+
+		return
+	}
+
+	switch args {
+	case tokenStatusArg:
+		t.handleStatus(chatID, lang, r.UserID)
+	case "":
+		t.flow.start(r, tokenStep)
+	default:
+		if _, err := t.set(r, args); err != nil {
+			t.sendDefaultErr(chatID, lang, err)
+		}
+	}
+}
+
+func (t *Token) askToken(r *Request) error {
+	t.sendText(r.ChatID, r.Lang, msgTokenAsk, nil)
+
+	return nil
+}
+
+// answerToken - sets the token, the step waits for another token if MonoBank rejects it.
+func (t *Token) answerToken(r *Request, token string) (string, error) {
+	accepted, err := t.set(r, token)
+	if err != nil || accepted {
+		return dialogEnd, err
+	}
+
+	return tokenStep, nil
+}
+
+// set - verifies and saves the token, sends the result to the user, returns whether the token is accepted.
+func (t *Token) set(r *Request, token string) (bool, error) {
+	clientInfo, err := t.tokenUC.Set(r.UserID, token)
+	if err == model.ErrInvalidToken {
+		t.sendText(r.ChatID, r.Lang, msgTokenRejected, nil)
+
+		return false, nil
+	}
+
 	if err != nil {
-		t.sendDefaultErr(u.Message.Chat.ID, err)
+		return false, err
+	}
+
+	t.sendText(r.ChatID, r.Lang, msgTokenSet, msgArgs{"Client": clientInfo.Name})
+
+	return true, nil
+}
+
+func (t *Token) handleStatus(chatID int64, lang string, userID uuid.UUID) {
+	status, err := t.tokenUC.Status(userID)
+	if err == model.ErrNil {
+		t.sendText(chatID, lang, msgTokenNotSet, nil)
 
 		return
 	}
 
-	if err := t.tokenUC.Set(userID, u.Message.CommandArguments()); err != nil {
-		t.sendDefaultErr(u.Message.Chat.ID, err)
+	if err != nil {
+		t.sendDefaultErr(chatID, lang, err)
 
 		return
 	}
-	t.sendMSG(tg.NewMessage(u.Message.Chat.ID, "successfully set token"))
+
+	verifiedAt := translate(lang, msgTokenNever, nil)
+	if !status.VerifiedAt.IsZero() {
+		verifiedAt = status.VerifiedAt.Format(tokenVerifiedAtPattern)
+	}
+
+	t.sendText(chatID, lang, msgTokenStatus, msgArgs{"Token": status.Masked, "VerifiedAt": verifiedAt})
 }
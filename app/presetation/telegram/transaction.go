@@ -3,6 +3,7 @@ package telegram
 import (
 	"fmt"
 	"io"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -10,99 +11,78 @@ import (
 	"github.com/Kalachevskyi/mono-chat/app/model"
 
 	tg "github.com/go-telegram-bot-api/telegram-bot-api"
-	"github.com/jinzhu/now"
 	"github.com/pkg/errors"
 )
 
+// refreshFlag - the report argument making the statement fetched from MonoBank instead of the history.
+const refreshFlag = "--refresh"
+
 // TransactionUC - represents a use-case interface for processing business logic of "MonoBank" transactions API.
 type TransactionUC interface {
-	GetTransactions(token, account string, userID uuid.UUID, from time.Time, to time.Time) (io.Reader, error)
-	ParseDate(period string) (from time.Time, to time.Time, err error)
-	Locale() *time.Location
+	GetTransactions(token, account string, userID uuid.UUID, from time.Time, to time.Time, refresh bool) (
+		io.Reader, error)
+	Summary(token, account string, userID uuid.UUID, from, to time.Time) (model.Summary, error)
+	XLSXReport(token, account string, userID uuid.UUID, from, to time.Time) (io.Reader, error)
+	Chart(token, account string, userID uuid.UUID, from, to time.Time, labels model.ChartLabels) (io.Reader, error)
+	Spending(token, account string, userID uuid.UUID, from, to time.Time) (map[string]int64, error)
+	Search(token, account string, userID uuid.UUID, q model.SearchQuery) (model.SearchResult, error)
+	SearchReport(userID uuid.UUID, result model.SearchResult) (io.Reader, error)
+	ParseAmountRange(s string) (min, max int64, err error)
+	ParseDate(userID uuid.UUID, period string) (from time.Time, to time.Time, err error)
+	Location(userID uuid.UUID) *time.Location
+	Locale(userID uuid.UUID) model.Locale
 }
 
 // NewTransaction - builds "NewTransaction" internal handler.
-func NewTransaction(t TokenUC, tr TransactionUC, a AccountUC, cu ChatUserUC, b *BotWrapper) *Transaction {
+func NewTransaction(tr TransactionUC, b *BotWrapper) *Transaction {
 	return &Transaction{
-		tokenUC:       t,
 		transactionUC: tr,
-		accountUC:     a,
-		chatUserUC:    cu,
 		BotWrapper:    b,
 	}
 }
 
 // Transaction - represents an internal handler for processing "MonoBank" transactions API.
 type Transaction struct {
-	tokenUC       TokenUC
 	transactionUC TransactionUC
-	accountUC     AccountUC
-	chatUserUC    ChatUserUC
 	*BotWrapper
 }
 
-// Handle - process the "MonoBank" transactions API, send the result to the user.
-func (t *Transaction) Handle(u tg.Update) {
-	var (
-		from, to time.Time
-		timeNow  = now.New(time.Now().In(t.transactionUC.Locale()))
-	)
+// Requires - the statement is requested by the token and the account of the user.
+func (t *Transaction) Requires() Requirement {
+	return RequireToken | RequireAccount
+}
 
-	switch u.Message.Command() {
+// Handle - process the "MonoBank" transactions API, send the result to the user.
+// The "--refresh" argument makes the statement fetched from MonoBank.
+func (t *Transaction) Handle(r *Request) {
+	chatID, lang := r.ChatID, r.Lang
+	period, refresh := cutFlag(r.Message.CommandArguments(), refreshFlag)
+	switch r.Message.Command() {
 	case getCommand:
-		var err error
-		from, to, err = t.transactionUC.ParseDate(u.Message.CommandArguments())
-		if err != nil {
-			t.sendDefaultErr(u.Message.Chat.ID, err)
-
-			return
-		}
-	case todayCommand:
-		from, to = timeNow.BeginningOfDay(), timeNow.EndOfDay()
-	case currentMonthCommand:
-		from, to = timeNow.BeginningOfMonth(), timeNow.EndOfMonth()
+	case todayCommand, currentMonthCommand:
+		// "today" and "month" are the periods of the date range grammar too.
+		period = r.Message.Command()
 	default:
-		t.sendDefaultErr(u.Message.Chat.ID, errors.New("can't detect command"))
-
-		return
-	}
-
-	chatID := u.Message.Chat.ID
-	userID, err := t.chatUserUC.GetChatUserID(chatID)
-	if err != nil {
-		t.sendDefaultErr(chatID, err)
-
-		return
-	}
-
-	token, err := t.tokenUC.Get(userID)
-	if err != nil {
-		t.sendDefaultErr(chatID, err)
-
-		return
-	}
-
-	account, err := t.accountUC.Get(userID)
-	if err == model.ErrNil {
-		t.sendMSG(tg.NewMessage(chatID, "Please set account."))
+		t.sendDefaultErr(chatID, lang, errors.New("can't detect command"))
 
 		return
 	}
 
+	from, to, err := t.transactionUC.ParseDate(r.UserID, period)
 	if err != nil {
-		t.sendDefaultErr(chatID, err)
+		t.sendInputErr(chatID, lang, err)
 
 		return
 	}
 
-	fileResp, err := t.transactionUC.GetTransactions(token, account, userID, from, to)
+	fileResp, err := t.transactionUC.GetTransactions(r.Token, r.Account, r.UserID, from, to, refresh)
 	if err != nil {
-		t.sendDefaultErr(chatID, err)
+		t.sendDefaultErr(chatID, lang, err)
 
 		return
 	}
 	reader := tg.FileReader{
-		Name:   fmt.Sprintf("%s-%s%s", from.Format(dateTimePattern), to.Format(dateTimePattern), ".csv"),
+		Name:   reportName(from, to, ".csv"),
 		Reader: fileResp,
 		Size:   -1,
 	}
@@ -110,3 +90,25 @@ func (t *Transaction) Handle(u tg.Update) {
 	msg := tg.NewDocumentUpload(chatID, reader)
 	t.sendMSG(msg)
 }
+
+// reportName - returns the file name of the report of the period, e.g. "01.03.2024T00.00-31.03.2024T23.59.csv".
+func reportName(from, to time.Time, ext string) string {
+	return fmt.Sprintf("%s-%s%s", from.Format(dateTimePattern), to.Format(dateTimePattern), ext)
+}
+
+// cutFlag - returns the arguments without the flag and whether the flag was there.
+func cutFlag(args, flag string) (string, bool) {
+	fields := strings.Fields(args)
+	rest := make([]string, 0, len(fields))
+	found := false
+	for _, f := range fields {
+		if f == flag {
+			found = true
+
+			continue
+		}
+		rest = append(rest, f)
+	}
+
+	return strings.Join(rest, " "), found
+}
@@ -0,0 +1,19 @@
+package telegram
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestCutFlag(t *testing.T) {
+	RegisterTestingT(t)
+
+	args, ok := cutFlag("01.03.2020-31.03.2020 --refresh", refreshFlag)
+	Ω(args).To(Equal("01.03.2020-31.03.2020"), errNotEqual)
+	Ω(ok).To(BeTrue(), errNotEqual)
+
+	args, ok = cutFlag(" 01.03.2020 ", refreshFlag)
+	Ω(args).To(Equal("01.03.2020"), errNotEqual)
+	Ω(ok).To(BeFalse(), errNotEqual)
+}
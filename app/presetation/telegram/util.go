@@ -5,7 +5,10 @@ import (
 	"io"
 
 	tg "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/google/uuid"
 	"github.com/pkg/errors"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
 )
 
 // HandlerKey - type for naming handlers.
@@ -20,6 +23,19 @@ const (
 	ClientInfoHandler
 	AccountHandler
 	ChatUserHandler
+	APIKeyHandler
+	ScheduleHandler
+	SummaryHandler
+	ChartHandler
+	BudgetHandler
+	SubscriptionHandler
+	SearchHandler
+	SettingsHandler
+	InlineQueryHandler
+	HelpHandler
+	StartHandler
+	DialogHandler
+	ExportHandler
 )
 
 // ErrStack - add stack to error, work with "github.com/pkg/errors" package.
@@ -31,26 +47,118 @@ func ErrStack(err error) error {
 	return fmt.Errorf("%+v", err)
 }
 
-func close(closer io.Closer, log Logger) {
+func closeBody(closer io.Closer, log Logger) {
 	if err := closer.Close(); err != nil {
 		log.Errorf("handlers.Chat.close: can't close body: err=%s", ErrStack(err))
 	}
 }
 
-// NewBotWrapper - builds "NewBotWrapper".
-func NewBotWrapper(bot *tg.BotAPI, log Logger) *BotWrapper {
-	return &BotWrapper{bot: bot, log: log}
+// updateChatID - returns the ID of the chat of the message or the pressed button,
+// the private chat of the inline query sender.
+func updateChatID(u tg.Update) int64 {
+	switch {
+	case u.InlineQuery != nil:
+		return int64(u.InlineQuery.From.ID)
+	case u.CallbackQuery != nil && u.CallbackQuery.Message != nil:
+		return u.CallbackQuery.Message.Chat.ID
+	case u.CallbackQuery != nil:
+		return int64(u.CallbackQuery.From.ID)
+	default:
+		return u.Message.Chat.ID
+	}
+}
+
+// updateSender - returns the sender of the message, the inline query or the pressed button, can be nil.
+func updateSender(u tg.Update) *tg.User {
+	switch {
+	case u.InlineQuery != nil:
+		return u.InlineQuery.From
+	case u.CallbackQuery != nil:
+		return u.CallbackQuery.From
+	default:
+		return u.Message.From
+	}
+}
+
+// NewBotWrapper - builds "NewBotWrapper", "languageUC" and "chatUserUC" can be nil,
+// then the messages are in the Telegram language of the sender.
+func NewBotWrapper(bot *tg.BotAPI, log Logger, languageUC LanguageUC, chatUserUC ChatUserUC) *BotWrapper {
+	return &BotWrapper{bot: bot, log: log, languageUC: languageUC, chatUserUC: chatUserUC}
 }
 
 // BotWrapper - represents the Telegram chatbot wrapper, register an error if it occurred.
 type BotWrapper struct {
-	bot *tg.BotAPI
-	log Logger
+	bot        *tg.BotAPI
+	log        Logger
+	languageUC LanguageUC
+	chatUserUC ChatUserUC
+}
+
+// language - returns the language of the messages to the sender of the update: the one chosen by "/lang",
+// the Telegram language of the sender (English if it isn't supported) or the default one.
+func (c *BotWrapper) language(u tg.Update) string {
+	if c.chatUserUC != nil {
+		if userID, err := c.chatUserUC.GetChatUserID(updateChatID(u)); err == nil {
+			if lang, ok := c.chosenLanguage(userID); ok {
+				return lang
+			}
+		}
+	}
+
+	from := updateSender(u)
+	if from == nil || from.LanguageCode == "" {
+		return model.DefaultLanguage
+	}
+
+	if lang, ok := model.FindLanguage(from.LanguageCode); ok {
+		return lang
+	}
+
+	return model.LanguageEN
 }
 
-func (c *BotWrapper) sendDefaultErr(chatID int64, err error) {
+// userLanguage - returns the language chosen by the user or the default one, the messages sent
+// not in reply to the user (alerts, scheduled reports) are in this language.
+func (c *BotWrapper) userLanguage(userID uuid.UUID) string {
+	if lang, ok := c.chosenLanguage(userID); ok {
+		return lang
+	}
+
+	return model.DefaultLanguage
+}
+
+func (c *BotWrapper) chosenLanguage(userID uuid.UUID) (string, bool) {
+	if c.languageUC == nil {
+		return "", false
+	}
+
+	lang, err := c.languageUC.Language(userID)
+	if err != nil && err != model.ErrNil {
+		c.log.Errorf("can't get user language: user=%v err=%+v", userID, err)
+	}
+
+	return lang, err == nil
+}
+
+func (c *BotWrapper) sendDefaultErr(chatID int64, lang string, err error) {
 	c.log.Error(ErrStack(err))
-	c.sendMSG(tg.NewMessage(chatID, defaultErrMSG))
+	c.sendText(chatID, lang, msgDefaultErr, nil)
+}
+
+// sendInputErr - sends the validation error as the invalid input, the other errors as the default error.
+func (c *BotWrapper) sendInputErr(chatID int64, lang string, err error) {
+	if validationErr, ok := errors.Cause(err).(model.ValidationError); ok {
+		c.sendText(chatID, lang, msgInvalidInput, msgArgs{"Error": validationText(lang, validationErr)})
+
+		return
+	}
+
+	c.sendDefaultErr(chatID, lang, err)
+}
+
+// sendText - sends the catalog message in the language to the chat.
+func (c *BotWrapper) sendText(chatID int64, lang string, key msgKey, a msgArgs) {
+	c.sendMSG(tg.NewMessage(chatID, translate(lang, key, a)))
 }
 
 func (c *BotWrapper) sendMSG(msg tg.Chattable) {
@@ -58,3 +166,9 @@ func (c *BotWrapper) sendMSG(msg tg.Chattable) {
 		c.log.Errorf("can't send err message: err=%+v", errors.WithStack(err))
 	}
 }
+
+func (c *BotWrapper) deleteMSG(chatID int64, messageID int) {
+	if _, err := c.bot.DeleteMessage(tg.NewDeleteMessage(chatID, messageID)); err != nil {
+		c.log.Errorf("can't delete message: err=%+v", errors.WithStack(err))
+	}
+}
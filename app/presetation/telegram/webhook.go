@@ -0,0 +1,31 @@
+package telegram
+
+import (
+	"net/url"
+
+	tg "github.com/go-telegram-bot-api/telegram-bot-api"
+	"github.com/pkg/errors"
+)
+
+// SetWebhook - registers the webhook, Telegram sends updates to "link" with "secret"
+// in the "X-Telegram-Bot-Api-Secret-Token" header.
+func SetWebhook(bot *tg.BotAPI, link, secret string) error {
+	params := url.Values{}
+	params.Set("url", link)
+	params.Set("secret_token", secret)
+
+	if _, err := bot.MakeRequest("setWebhook", params); err != nil {
+		return errors.Wrap(err, "can't set webhook")
+	}
+
+	return nil
+}
+
+// RemoveWebhook - removes the webhook, it's required for getting updates with long polling.
+func RemoveWebhook(bot *tg.BotAPI) error {
+	if _, err := bot.RemoveWebhook(); err != nil {
+		return errors.Wrap(err, "can't remove webhook")
+	}
+
+	return nil
+}
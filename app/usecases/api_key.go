@@ -0,0 +1,138 @@
+package usecases
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+const (
+	apiKeyKey       = "apikey"
+	apiKeyPrefix    = "mc_"
+	apiKeyLen       = 32 // number of random bytes in API key
+	signatureMaxAge = 5 * time.Minute
+)
+
+//go:generate mockgen -destination=./api_key_mock_test.go -package=usecases_test -source=./api_key.go
+
+// APIKeyRepo - represents API key repository interface.
+type APIKeyRepo interface {
+	Set(key string, val model.APIKey) error
+	Get(key string) (model.APIKey, error)
+	Delete(key string) error
+}
+
+// NewAPIKey - builds API key use-case.
+func NewAPIKey(repo APIKeyRepo) *APIKey {
+	return &APIKey{repo: repo}
+}
+
+// APIKey - represents API key use-case, issues and checks keys for accessing REST API.
+type APIKey struct {
+	repo APIKeyRepo
+}
+
+// New - issues a new API key with the scope for the user, the previous key is revoked.
+// The key is returned only once, the repository keeps its hash.
+func (a *APIKey) New(userID uuid.UUID, scope string) (string, error) {
+	if scope != model.ScopeReportsRead && scope != model.ScopeAccountManage {
+		return "", model.NewValidationError("unknown API key scope: %s", scope)
+	}
+
+	if err := a.Revoke(userID); err != nil && err != model.ErrNil {
+		return "", err
+	}
+
+	raw := make([]byte, apiKeyLen)
+	if _, err := rand.Read(raw); err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	key := apiKeyPrefix + hex.EncodeToString(raw)
+	apiKey := model.APIKey{
+		UserID:    userID,
+		Hash:      hashAPIKey(key),
+		Scope:     scope,
+		CreatedAt: time.Now(),
+	}
+
+	if err := a.repo.Set(apiKeyHashKey(apiKey.Hash), apiKey); err != nil {
+		return "", err
+	}
+
+	if err := a.repo.Set(apiKeyUserKey(userID), apiKey); err != nil {
+		return "", err
+	}
+
+	return key, nil
+}
+
+// Revoke - revokes the user's API key, returns "model.ErrNil" if the user has no key.
+func (a *APIKey) Revoke(userID uuid.UUID) error {
+	apiKey, err := a.repo.Get(apiKeyUserKey(userID))
+	if err != nil {
+		return err
+	}
+
+	if err := a.repo.Delete(apiKeyHashKey(apiKey.Hash)); err != nil {
+		return err
+	}
+
+	return a.repo.Delete(apiKeyUserKey(userID))
+}
+
+// Authenticate - returns the API key record by the raw key, returns "model.ErrNil" for unknown keys.
+func (a *APIKey) Authenticate(key string) (model.APIKey, error) {
+	return a.repo.Get(apiKeyHashKey(hashAPIKey(key)))
+}
+
+// VerifySignature - checks HMAC-SHA256 signature of the request payload made with the raw API key,
+// "timestamp" is Unix time of signing, it must not differ from the current time more than 5 minutes.
+func (a *APIKey) VerifySignature(key, timestamp, signature string, payload []byte) error {
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return errors.New("can't parse signature timestamp")
+	}
+
+	age := time.Since(time.Unix(unix, 0))
+	if age > signatureMaxAge || age < -signatureMaxAge {
+		return errors.New("signature timestamp is out of range")
+	}
+
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		return errors.New("can't decode signature")
+	}
+
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write(payload) //nolint:errcheck // hash.Hash never returns an error
+
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return errors.New("signature mismatch")
+	}
+
+	return nil
+}
+
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func apiKeyHashKey(hash string) string {
+	return fmt.Sprintf("%s_%s", apiKeyKey, hash)
+}
+
+func apiKeyUserKey(userID uuid.UUID) string {
+	return fmt.Sprintf("%s_%s_%v", apiKeyKey, userKey, userID)
+}
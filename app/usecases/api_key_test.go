@@ -0,0 +1,113 @@
+package usecases_test
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+	uc "github.com/Kalachevskyi/mono-chat/app/usecases"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	. "github.com/onsi/gomega"
+)
+
+func TestAPIKey_New(t *testing.T) {
+	RegisterTestingT(t)
+	mockCtrl := gomock.NewController(t)
+	userID := uuid.New()
+	userKey := fmt.Sprintf("apikey_user_%v", userID)
+
+	var stored model.APIKey
+	repo := NewMockAPIKeyRepo(mockCtrl)
+	repo.EXPECT().Get(userKey).Return(model.APIKey{Hash: "old"}, nil).Times(1)
+	repo.EXPECT().Delete("apikey_old").Return(nil).Times(1)
+	repo.EXPECT().Delete(userKey).Return(nil).Times(1)
+	repo.EXPECT().Set(gomock.Any(), gomock.Any()).DoAndReturn(func(key string, val model.APIKey) error {
+		stored = val
+		return nil
+	}).Times(2)
+
+	key, err := uc.NewAPIKey(repo).New(userID, model.ScopeReportsRead)
+	Ω(err).To(BeNil(), fmt.Sprintf(errDefaultMsg, err))
+	Ω(key).To(HavePrefix("mc_"), fmt.Sprintf(errDefaultMsg, key))
+	Ω(stored.UserID).To(Equal(userID), fmt.Sprintf(errDefaultMsg, stored.UserID))
+	Ω(stored.Scope).To(Equal(model.ScopeReportsRead), fmt.Sprintf(errDefaultMsg, stored.Scope))
+	Ω(stored.Hash).NotTo(ContainSubstring(key), fmt.Sprintf(errDefaultMsg, stored.Hash))
+
+	repo.EXPECT().Get("apikey_"+stored.Hash).Return(stored, nil).Times(1)
+	got, err := uc.NewAPIKey(repo).Authenticate(key)
+	Ω(err).To(BeNil(), fmt.Sprintf(errDefaultMsg, err))
+	Ω(got).To(Equal(stored), fmt.Sprintf(errDefaultMsg, got))
+}
+
+func TestAPIKey_NewUnknownScope(t *testing.T) {
+	RegisterTestingT(t)
+	_, err := uc.NewAPIKey(nil).New(uuid.New(), "admin")
+	Ω(err).NotTo(BeNil(), fmt.Sprintf(errDefaultMsg, err))
+}
+
+func TestAPIKey_Revoke(t *testing.T) {
+	RegisterTestingT(t)
+	mockCtrl := gomock.NewController(t)
+	userID := uuid.New()
+
+	repo := NewMockAPIKeyRepo(mockCtrl)
+	repo.EXPECT().Get(fmt.Sprintf("apikey_user_%v", userID)).Return(model.APIKey{}, model.ErrNil).Times(1)
+
+	err := uc.NewAPIKey(repo).Revoke(userID)
+	Ω(err).To(Equal(model.ErrNil), fmt.Sprintf(errDefaultMsg, err))
+}
+
+func TestAPIKey_VerifySignature(t *testing.T) {
+	RegisterTestingT(t)
+	const key = "mc_secret"
+	payload := []byte("GET\n/transactions/today\n")
+	sign := func(key string, payload []byte) string {
+		mac := hmac.New(sha256.New, []byte(key))
+		mac.Write(payload)
+		return hex.EncodeToString(mac.Sum(nil))
+	}
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	expired := strconv.FormatInt(time.Now().Add(-time.Hour).Unix(), 10)
+
+	tests := []struct {
+		name      string
+		timestamp string
+		signature string
+		wantErr   bool
+	}{
+		{
+			name:      "test-case1: success",
+			timestamp: now,
+			signature: sign(key, payload),
+		},
+		{
+			name:      "test-case2: signed with another key",
+			timestamp: now,
+			signature: sign("mc_other", payload),
+			wantErr:   true,
+		},
+		{
+			name:      "test-case3: expired timestamp",
+			timestamp: expired,
+			signature: sign(key, payload),
+			wantErr:   true,
+		},
+		{
+			name:      "test-case4: malformed signature",
+			timestamp: now,
+			signature: "not hex",
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		err := uc.NewAPIKey(nil).VerifySignature(key, tt.timestamp, tt.signature, payload)
+		Ω(err != nil).To(Equal(tt.wantErr), fmt.Sprintf("%s: %v", tt.name, err))
+	}
+}
@@ -0,0 +1,270 @@
+package usecases
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+const (
+	budgetKey          = "budget"
+	budgetAlertKey     = "budget_alert"
+	budgetUsersKey     = "budget_users"
+	budgetMonthPattern = "2006-01"
+	maxBudgets         = 30
+)
+
+//go:generate mockgen -destination=./budget_mock_test.go -package=usecases_test -source=./budget.go
+
+// BudgetRepo - represents category budgets repository interface.
+type BudgetRepo interface {
+	Set(key string, val model.Budget) error
+	GetAll(key string) ([]model.Budget, error)
+	Delete(key, field string) error
+	SetField(key, field, val string) error
+	GetFields(key string) (map[string]string, error)
+	AddMember(key, member string) error
+	RemoveMember(key, member string) error
+	Members(key string) ([]string, error)
+}
+
+// NewBudget - builds budget use-case.
+func NewBudget(repo BudgetRepo, mappingRepo MappingRepo, log Logger) *Budget {
+	return &Budget{repo: repo, mappingRepo: mappingRepo, log: log}
+}
+
+// Budget - represents monthly category budgets use-case.
+type Budget struct {
+	repo        BudgetRepo
+	mappingRepo MappingRepo
+	log         Logger
+}
+
+// Set - sets the monthly budget of the category, the alerts are sent to the chat. The category must be
+// one of the application's categories of the user's mapping, the limit is in the currency units, e.g. "8000.50".
+func (b *Budget) Set(userID uuid.UUID, chatID int64, category, limit string) (model.Budget, error) {
+	amount, err := strconv.ParseFloat(strings.Replace(limit, ",", ".", 1), 64)
+	if err != nil || amount <= 0 || math.IsInf(amount, 0) {
+		return model.Budget{}, model.NewValidationError("limit must be a positive number: %s", limit)
+	}
+
+	category, err = b.appCategory(userID, category)
+	if err != nil {
+		return model.Budget{}, err
+	}
+
+	budgets, err := b.List(userID)
+	if err != nil {
+		return model.Budget{}, err
+	}
+
+	if len(budgets) >= maxBudgets && findBudget(budgets, category) < 0 {
+		return model.Budget{}, model.NewValidationError("you can't have more than %d budgets", maxBudgets)
+	}
+
+	budget := model.Budget{Category: category, Limit: int64(math.Round(amount * accuracy)), ChatID: chatID}
+	if err := b.repo.Set(budgetUserKey(userID), budget); err != nil {
+		return model.Budget{}, err
+	}
+
+	// the limit is changed, so the thresholds are counted again
+	if err := b.repo.Delete(budgetAlertUserKey(userID), category); err != nil && err != model.ErrNil {
+		return model.Budget{}, err
+	}
+
+	if err := b.repo.AddMember(budgetUsersKey, userID.String()); err != nil {
+		return model.Budget{}, err
+	}
+
+	return budget, nil
+}
+
+// List - returns the user's budgets ordered by category.
+func (b *Budget) List(userID uuid.UUID) ([]model.Budget, error) {
+	budgets, err := b.repo.GetAll(budgetUserKey(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(budgets, func(i, j int) bool {
+		return budgets[i].Category < budgets[j].Category
+	})
+
+	return budgets, nil
+}
+
+// Delete - removes the budget of the category, the category is case insensitive,
+// returns "model.ErrNil" if there is no budget of the category.
+func (b *Budget) Delete(userID uuid.UUID, category string) error {
+	budgets, err := b.List(userID)
+	if err != nil {
+		return err
+	}
+
+	i := findBudget(budgets, category)
+	if i < 0 {
+		return model.ErrNil
+	}
+
+	if err := b.repo.Delete(budgetUserKey(userID), budgets[i].Category); err != nil {
+		return err
+	}
+
+	if err := b.repo.Delete(budgetAlertUserKey(userID), budgets[i].Category); err != nil && err != model.ErrNil {
+		return err
+	}
+
+	if len(budgets) == 1 {
+		return b.repo.RemoveMember(budgetUsersKey, userID.String())
+	}
+
+	return nil
+}
+
+// Status - returns the user's budgets with the spending by category of the current month.
+func (b *Budget) Status(userID uuid.UUID, spending map[string]int64) ([]model.BudgetStatus, error) {
+	budgets, err := b.List(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]model.BudgetStatus, 0, len(budgets))
+	for _, budget := range budgets {
+		statuses = append(statuses, model.BudgetStatus{Budget: budget, Spent: spending[budget.Category]})
+	}
+
+	return statuses, nil
+}
+
+// Users - returns the users having budgets.
+func (b *Budget) Users() ([]uuid.UUID, error) {
+	members, err := b.repo.Members(budgetUsersKey)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]uuid.UUID, 0, len(members))
+	for _, member := range members {
+		userID, err := uuid.Parse(member)
+		if err != nil {
+			b.log.Error(errors.Wrapf(err, "can't parse budget user: %s", member))
+
+			continue
+		}
+		users = append(users, userID)
+	}
+
+	return users, nil
+}
+
+// Alerts - returns the alerts of the thresholds reached in the month of "now" and not sent yet,
+// only the highest threshold of a budget is returned. The month is of the "now" time zone, the user's one.
+func (b *Budget) Alerts(userID uuid.UUID, spending map[string]int64, now time.Time) ([]model.BudgetAlert, error) {
+	statuses, err := b.Status(userID, spending)
+	if err != nil {
+		return nil, err
+	}
+
+	sent, err := b.repo.GetFields(budgetAlertUserKey(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	month := now.Format(budgetMonthPattern)
+	alerts := make([]model.BudgetAlert, 0)
+	for _, status := range statuses {
+		threshold := status.Threshold()
+		if threshold == 0 || threshold <= sentThreshold(sent[status.Category], month) {
+			continue
+		}
+
+		alerts = append(alerts, model.BudgetAlert{UserID: userID, Month: month, Threshold: threshold, Status: status})
+	}
+
+	return alerts, nil
+}
+
+// AlertSent - saves the alert as sent, the lower thresholds of the month aren't alerted anymore.
+func (b *Budget) AlertSent(alert model.BudgetAlert) error {
+	val := fmt.Sprintf("%s %d", alert.Month, alert.Threshold)
+
+	return b.repo.SetField(budgetAlertUserKey(alert.UserID), alert.Status.Category, val)
+}
+
+// appCategory - returns the application's category of the user's mapping equal to the category ignoring case.
+func (b *Budget) appCategory(userID uuid.UUID, category string) (string, error) {
+	mapping, err := b.mappingRepo.Get(fmt.Sprintf("%s_%s", mappingKey, userID))
+	if err == model.ErrNil || (err == nil && len(mapping) == 0) {
+		return "", model.NewValidationError("category mapping isn't set, send the mapping.csv file")
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	categories := make([]string, 0, len(mapping))
+	for _, m := range mapping {
+		if strings.EqualFold(m.App, category) {
+			return m.App, nil
+		}
+		categories = append(categories, m.App)
+	}
+
+	return "", model.NewValidationError("unknown category %q, the categories are: %s",
+		category, strings.Join(uniqueSorted(categories), ", "))
+}
+
+// sentThreshold - returns the threshold of the sent alert value "<month> <threshold>", 0 if it is of another month.
+func sentThreshold(val, month string) int {
+	fields := strings.Fields(val)
+	if len(fields) != 2 || fields[0] != month {
+		return 0
+	}
+
+	threshold, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return 0
+	}
+
+	return threshold
+}
+
+func findBudget(budgets []model.Budget, category string) int {
+	for i, budget := range budgets {
+		if strings.EqualFold(budget.Category, category) {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func uniqueSorted(vals []string) []string {
+	set := make(map[string]bool, len(vals))
+	unique := make([]string, 0, len(vals))
+	for _, val := range vals {
+		if !set[val] {
+			set[val] = true
+			unique = append(unique, val)
+		}
+	}
+	sort.Strings(unique)
+
+	return unique
+}
+
+func budgetUserKey(userID uuid.UUID) string {
+	return fmt.Sprintf("%s_%v", budgetKey, userID)
+}
+
+func budgetAlertUserKey(userID uuid.UUID) string {
+	return fmt.Sprintf("%s_%v", budgetAlertKey, userID)
+}
@@ -0,0 +1,84 @@
+package usecases_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+	uc "github.com/Kalachevskyi/mono-chat/app/usecases"
+)
+
+func TestBudget_Set(t *testing.T) {
+	RegisterTestingT(t)
+	mockCtrl := gomock.NewController(t)
+	userID := uuid.New()
+
+	mappingRepo := NewMockMappingRepo(mockCtrl)
+	mappingRepo.EXPECT().Get(fmt.Sprintf("mapping_%s", userID)).Return(map[string]model.CategoryMapping{
+		"5411": {Mono: "5411", App: "Groceries"},
+		"4121": {Mono: "4121", App: "Transport"},
+	}, nil).Times(3)
+
+	budgetKey := fmt.Sprintf("budget_%s", userID)
+	want := model.Budget{Category: "Groceries", Limit: 800050, ChatID: 1}
+	repo := NewMockBudgetRepo(mockCtrl)
+	repo.EXPECT().GetAll(budgetKey).Return(nil, nil).Times(1)
+	repo.EXPECT().Set(budgetKey, want).Return(nil).Times(1)
+	repo.EXPECT().Delete(fmt.Sprintf("budget_alert_%s", userID), "Groceries").Return(model.ErrNil).Times(1)
+	repo.EXPECT().AddMember("budget_users", userID.String()).Return(nil).Times(1)
+
+	b := uc.NewBudget(repo, mappingRepo, nil)
+	got, err := b.Set(userID, 1, "groceries", "8000,50")
+	Ω(err).To(BeNil(), fmt.Sprintf(errDefaultMsg, err))
+	Ω(got).To(Equal(want), errNotEqual)
+
+	_, err = b.Set(userID, 1, "Cafe", "100")
+	_, ok := errors.Cause(err).(model.ValidationError)
+	Ω(ok).To(BeTrue(), errNotEqual)
+	Ω(err.Error()).To(ContainSubstring("Groceries, Transport"), errNotEqual)
+
+	_, err = b.Set(userID, 1, "Groceries", "-1")
+	_, ok = errors.Cause(err).(model.ValidationError)
+	Ω(ok).To(BeTrue(), errNotEqual)
+}
+
+func TestBudget_Alerts(t *testing.T) {
+	RegisterTestingT(t)
+	mockCtrl := gomock.NewController(t)
+	userID := uuid.New()
+	now := time.Date(2020, 3, 15, 12, 0, 0, 0, time.UTC)
+
+	repo := NewMockBudgetRepo(mockCtrl)
+	repo.EXPECT().GetAll(fmt.Sprintf("budget_%s", userID)).Return([]model.Budget{
+		{Category: "Groceries", Limit: 10000},
+		{Category: "Transport", Limit: 10000},
+		{Category: "Cafe", Limit: 10000},
+		{Category: "Fun", Limit: 10000},
+	}, nil).Times(1)
+	repo.EXPECT().GetFields(fmt.Sprintf("budget_alert_%s", userID)).Return(map[string]string{
+		"Groceries": "2020-03 80",  // 100% isn't sent yet
+		"Transport": "2020-03 80",  // already sent
+		"Cafe":      "2020-02 100", // previous month
+	}, nil).Times(1)
+
+	b := uc.NewBudget(repo, nil, nil)
+	got, err := b.Alerts(userID, map[string]int64{
+		"Groceries": 12000,
+		"Transport": 9000,
+		"Cafe":      8000,
+		"Fun":       7999,
+	}, now)
+	Ω(err).To(BeNil(), fmt.Sprintf(errDefaultMsg, err))
+	Ω(got).To(HaveLen(2), errNotEqual)
+	Ω(got[0].Status.Category).To(Equal("Cafe"), errNotEqual)
+	Ω(got[0].Threshold).To(Equal(model.BudgetWarningThreshold), errNotEqual)
+	Ω(got[1].Status.Category).To(Equal("Groceries"), errNotEqual)
+	Ω(got[1].Threshold).To(Equal(model.BudgetExceededThreshold), errNotEqual)
+	Ω(got[1].Month).To(Equal("2020-03"), errNotEqual)
+}
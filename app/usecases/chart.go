@@ -0,0 +1,289 @@
+package usecases
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jinzhu/now"
+	"github.com/pkg/errors"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+// Chart layout, in pixels.
+const (
+	chartWidth      = 1000
+	chartHeight     = 900
+	chartMargin     = 30
+	chartPieX       = 230
+	chartPieY       = 290
+	chartPieRadius  = 170
+	chartLegendX    = 450
+	chartLegendY    = 140
+	chartLegendRow  = 36
+	chartSwatch     = 20
+	chartBarsTop    = 560
+	chartBarsBottom = 830
+	chartBarsLeft   = 110
+	chartBarsGap    = 2
+	chartMaxLabels  = 16
+	// chartMaxSlices - the number of pie slices, the rest categories are joined into "Other".
+	chartMaxSlices     = 8
+	chartMaxLabelRunes = 28
+	chartTitleSize     = 24
+	chartTextSize      = 16
+	chartDayPattern    = "2006-01-02"
+)
+
+//nolint:gochecknoglobals
+var (
+	chartBackground = color.RGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+	chartText       = color.RGBA{R: 0x33, G: 0x33, B: 0x33, A: 0xff}
+	chartAxis       = color.RGBA{R: 0xbb, G: 0xbb, B: 0xbb, A: 0xff}
+	chartBar        = color.RGBA{R: 0x42, G: 0x85, B: 0xf4, A: 0xff}
+	chartPalette    = []color.RGBA{
+		{R: 0x42, G: 0x85, B: 0xf4, A: 0xff},
+		{R: 0xea, G: 0x43, B: 0x35, A: 0xff},
+		{R: 0xfb, G: 0xbc, B: 0x05, A: 0xff},
+		{R: 0x34, G: 0xa8, B: 0x53, A: 0xff},
+		{R: 0xff, G: 0x6d, B: 0x01, A: 0xff},
+		{R: 0x46, G: 0xbd, B: 0xc6, A: 0xff},
+		{R: 0xab, G: 0x47, B: 0xbc, A: 0xff},
+		{R: 0x8d, G: 0x6e, B: 0x63, A: 0xff},
+		{R: 0x9e, G: 0x9e, B: 0x9e, A: 0xff}, // "Other", the palette has chartMaxSlices+1 colors
+	}
+	// chartFont - the font of the chart, it's parsed once by parseChartFont.
+	chartFont     *opentype.Font
+	chartFontErr  error
+	chartFontOnce sync.Once
+)
+
+// Chart - returns PNG chart of the period expenses: a pie chart by mapped category
+// and a bar chart by day, the transactions are the same as in the CSV report. The text of the chart
+// is in the user's language, see model.ChartLabels.
+func (a *Transaction) Chart(token, account string, userID uuid.UUID, from, to time.Time,
+	labels model.ChartLabels) (io.Reader, error) {
+	transactions, err := a.statementRepo.Statement(userID, token, account, from, to, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return renderChart(a.chart(userID, transactions, from, to), a.Locale(userID), labels)
+}
+
+func (a *Transaction) chart(userID uuid.UUID, transactions []model.Transaction, from, to time.Time) model.Chart {
+	catMap := a.getCategoryMapping(userID)
+	loc := a.Location(userID)
+	chart := model.Chart{Totals: totals(transactions, from, to)}
+
+	dayIndex := make(map[string]int)
+	for day := now.New(from.In(loc)).BeginningOfDay(); !day.After(to); day = day.AddDate(0, 0, 1) {
+		dayIndex[day.Format(chartDayPattern)] = len(chart.Days)
+		chart.Days = append(chart.Days, model.DayTotal{Day: day})
+	}
+
+	byCategory := make(map[string]int64)
+	for _, tr := range transactions {
+		if tr.Amount >= 0 {
+			continue
+		}
+
+		amount := -int64(tr.Amount)
+		byCategory[a.expenseCategory(catMap, tr)] += amount
+
+		day := time.Unix(int64(tr.Time), 0).In(loc).Format(chartDayPattern)
+		if i, ok := dayIndex[day]; ok {
+			chart.Days[i].Amount += amount
+		}
+	}
+	chart.Categories = categoryTotals(byCategory, chart.Expenses)
+
+	return chart
+}
+
+// chartCanvas - represents the image the chart is drawn on.
+type chartCanvas struct {
+	img   *image.RGBA
+	title font.Face
+	text  font.Face
+	other string
+}
+
+// parseChartFont - returns the font of the chart, the font is parsed on the first call.
+func parseChartFont() (*opentype.Font, error) {
+	chartFontOnce.Do(func() {
+		if chartFont, chartFontErr = opentype.Parse(goregular.TTF); chartFontErr != nil {
+			chartFontErr = errors.Errorf("can't parse font: err=%v", chartFontErr)
+		}
+	})
+
+	return chartFont, chartFontErr
+}
+
+// renderChart - draws the chart with the labels, the dates of the title follow the locale.
+func renderChart(c model.Chart, locale model.Locale, labels model.ChartLabels) (io.Reader, error) {
+	f, err := parseChartFont()
+	if err != nil {
+		return nil, err
+	}
+
+	title, err := opentype.NewFace(f, &opentype.FaceOptions{Size: chartTitleSize, DPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		return nil, errors.Errorf("can't create font face: err=%v", err)
+	}
+
+	text, err := opentype.NewFace(f, &opentype.FaceOptions{Size: chartTextSize, DPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		return nil, errors.Errorf("can't create font face: err=%v", err)
+	}
+
+	cv := &chartCanvas{
+		img:   image.NewRGBA(image.Rect(0, 0, chartWidth, chartHeight)),
+		title: title,
+		text:  text,
+		other: labels.Other,
+	}
+	draw.Draw(cv.img, cv.img.Bounds(), image.NewUniform(chartBackground), image.Point{}, draw.Src)
+
+	cv.drawText(cv.title, chartMargin, chartMargin+chartTitleSize, fmt.Sprintf("%s %s - %s: %s",
+		labels.Expenses, c.From.Format(locale.Date), c.To.Format(locale.Date), chartAmount(c.Expenses)))
+
+	if c.Expenses == 0 {
+		cv.drawText(cv.text, chartMargin, chartPieY, labels.NoExpenses)
+	} else {
+		cv.drawPie(c.Categories)
+		cv.drawBars(c.Days)
+	}
+
+	buf := &bytes.Buffer{}
+	if err := png.Encode(buf, cv.img); err != nil {
+		return nil, errors.Errorf("can't encode chart: err=%v", err)
+	}
+
+	return buf, nil
+}
+
+// drawPie - draws the pie chart clockwise from 12 o'clock and its legend.
+func (cv *chartCanvas) drawPie(categories []model.CategoryTotal) {
+	slices := categories
+	if len(slices) > chartMaxSlices {
+		other := model.CategoryTotal{Category: cv.other}
+		for _, c := range slices[chartMaxSlices:] {
+			other.Amount += c.Amount
+			other.Share += c.Share
+		}
+		slices = append(slices[:chartMaxSlices:chartMaxSlices], other)
+	}
+
+	bounds := make([]float64, len(slices))
+	var sum float64
+	for i, s := range slices {
+		sum += s.Share
+		bounds[i] = sum
+	}
+
+	for y := -chartPieRadius; y <= chartPieRadius; y++ {
+		for x := -chartPieRadius; x <= chartPieRadius; x++ {
+			if x*x+y*y > chartPieRadius*chartPieRadius {
+				continue
+			}
+
+			angle := math.Atan2(float64(x), float64(-y))
+			if angle < 0 {
+				angle += 2 * math.Pi
+			}
+
+			fraction := angle / (2 * math.Pi) * sum
+			i := 0
+			for i < len(bounds)-1 && fraction >= bounds[i] {
+				i++
+			}
+			cv.img.SetRGBA(chartPieX+x, chartPieY+y, chartPalette[i])
+		}
+	}
+
+	for i, s := range slices {
+		y := chartLegendY + i*chartLegendRow
+		swatch := image.Rect(chartLegendX, y-chartSwatch, chartLegendX+chartSwatch, y)
+		draw.Draw(cv.img, swatch, image.NewUniform(chartPalette[i]), image.Point{}, draw.Src)
+		cv.drawText(cv.text, chartLegendX+chartSwatch+10, y-3,
+			fmt.Sprintf("%s - %s (%.0f%%)", truncate(s.Category), chartAmount(s.Amount), s.Share*100))
+	}
+}
+
+// drawBars - draws the bar chart of expenses by day, every day of the period has a bar.
+func (cv *chartCanvas) drawBars(days []model.DayTotal) {
+	if len(days) == 0 {
+		return
+	}
+
+	var max int64
+	for _, d := range days {
+		if d.Amount > max {
+			max = d.Amount
+		}
+	}
+
+	axis := image.Rect(chartBarsLeft, chartBarsBottom, chartWidth-chartMargin, chartBarsBottom+1)
+	draw.Draw(cv.img, axis, image.NewUniform(chartAxis), image.Point{}, draw.Src)
+
+	maxLabel := chartAmount(max)
+	cv.drawText(cv.text, chartBarsLeft-font.MeasureString(cv.text, maxLabel).Ceil()-8, chartBarsTop+chartTextSize/2,
+		maxLabel)
+	top := image.Rect(chartBarsLeft, chartBarsTop, chartWidth-chartMargin, chartBarsTop+1)
+	draw.Draw(cv.img, top, image.NewUniform(chartAxis), image.Point{}, draw.Src)
+
+	width := (chartWidth - chartMargin - chartBarsLeft) / len(days)
+	labelEvery := (len(days) + chartMaxLabels - 1) / chartMaxLabels
+	for i, d := range days {
+		x := chartBarsLeft + i*width
+		if d.Amount > 0 {
+			height := int(float64(d.Amount) / float64(max) * (chartBarsBottom - chartBarsTop))
+			bar := image.Rect(x+chartBarsGap, chartBarsBottom-height, x+width-chartBarsGap, chartBarsBottom)
+			draw.Draw(cv.img, bar, image.NewUniform(chartBar), image.Point{}, draw.Src)
+		}
+
+		if i%labelEvery == 0 {
+			label := d.Day.Format("02")
+			labelX := x + (width-font.MeasureString(cv.text, label).Ceil())/2
+			cv.drawText(cv.text, labelX, chartBarsBottom+chartTextSize+8, label)
+		}
+	}
+}
+
+func (cv *chartCanvas) drawText(face font.Face, x, y int, s string) {
+	d := font.Drawer{
+		Dst:  cv.img,
+		Src:  image.NewUniform(chartText),
+		Face: face,
+		Dot:  fixed.P(x, y),
+	}
+	d.DrawString(s)
+}
+
+func truncate(s string) string {
+	r := []rune(s)
+	if len(r) <= chartMaxLabelRunes {
+		return s
+	}
+
+	return string(r[:chartMaxLabelRunes-1]) + "…"
+}
+
+// chartAmount - formats the amount in minor units, e.g. 123456 as "1234.56".
+func chartAmount(amount int64) string {
+	return fmt.Sprintf("%.2f", float64(amount)/accuracy)
+}
@@ -0,0 +1,53 @@
+package usecases_test
+
+import (
+	"fmt"
+	"image/color"
+	"image/png"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	. "github.com/onsi/gomega"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+	uc "github.com/Kalachevskyi/mono-chat/app/usecases"
+)
+
+func TestTransaction_Chart(t *testing.T) {
+	RegisterTestingT(t)
+	mockCtrl := gomock.NewController(t)
+	loc, _ := time.LoadLocation("Europe/Kiev")
+	date := uc.NewDate(loc, nil)
+
+	userID := uuid.New()
+	from := time.Date(2020, 3, 1, 0, 0, 0, 0, loc)
+	to := time.Date(2020, 3, 31, 23, 59, 59, 0, loc)
+	day := func(d int) int { return int(time.Date(2020, 3, d, 12, 0, 0, 0, loc).Unix()) }
+
+	statementRepo := NewMockStatementRepo(mockCtrl)
+	statementRepo.EXPECT().Statement(userID, "token", "account", from, to, false).Return([]model.Transaction{
+		{Time: day(2), Mcc: 5411, Amount: -20000, Description: "Silpo"},
+		{Time: day(3), Mcc: 4121, Amount: -5000, Description: "Uklon"},
+		{Time: day(6), Mcc: 4829, Amount: 100000, Description: "Salary"},
+	}, nil).Times(1)
+
+	mappingRepo := NewMockMappingRepo(mockCtrl)
+	mappingRepo.EXPECT().Get(fmt.Sprintf("mapping_%s", userID)).Return(map[string]model.CategoryMapping{
+		"5411": {Mono: "5411", App: "Продукти"},
+	}, nil).Times(1)
+
+	tr := uc.NewTransaction(statementRepo, mappingRepo, nil, date)
+	r, err := tr.Chart("token", "account", userID, from, to, model.ChartLabels{Expenses: "Витрати", Other: "Інше"})
+	Ω(err).To(BeNil(), fmt.Sprintf(errDefaultMsg, err))
+
+	img, err := png.Decode(r)
+	Ω(err).To(BeNil(), fmt.Sprintf(errDefaultMsg, err))
+	Ω(img.Bounds().Dx()).To(Equal(1000), errNotEqual)
+	Ω(img.Bounds().Dy()).To(Equal(900), errNotEqual)
+
+	// the biggest category starts at 12 o'clock of the pie
+	Ω(color.RGBAModel.Convert(img.At(235, 150))).To(Equal(color.RGBA{R: 0x42, G: 0x85, B: 0xf4, A: 0xff}),
+		errNotEqual)
+}
@@ -2,6 +2,8 @@ package usecases
 
 import "github.com/Kalachevskyi/mono-chat/app/model"
 
+//go:generate mockgen -destination=./client_info_mock_test.go -package=usecases_test -source=./client_info.go
+
 // ClientInfoRepo - represents ClientInfo repository.
 type ClientInfoRepo interface {
 	GetClientInfo(token string) (c model.ClientInfo, err error)
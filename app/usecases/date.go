@@ -6,6 +6,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jinzhu/now"
 	"github.com/pkg/errors"
 )
@@ -54,17 +55,24 @@ func NewDateOld(loc *time.Location) (*Date, error) {
 }
 
 // NewDate - Date type constructor
-// "loc" - can be empty, default parameter "Europe/Kiev".
-func NewDate(loc *time.Location) *Date {
-	return &Date{loc: loc}
+// "loc" - the time zone of the users without settings, "settings" - the users' time zones, can be nil.
+func NewDate(loc *time.Location, settings UserSettings) *Date {
+	return &Date{loc: loc, settings: settings}
 }
 
 // Date - precompiled regex for dates, time location.
 type Date struct {
-	loc *time.Location
+	loc      *time.Location
+	settings UserSettings
+}
+
+// forUser - returns Date parsing in the user's time zone.
+func (d Date) forUser(userID uuid.UUID) Date {
+	return Date{loc: userLocation(d.settings, d.loc, userID), settings: d.settings}
 }
 
-func (d Date) getFilter(name string) (*filter, error) {
+// getFilter - parses the file name patterns, the days without the month "1-15" are of the month of "t".
+func (d Date) getFilter(name string, t time.Time) (*filter, error) {
 	name = strings.TrimSuffix(name, csvSuffix)
 	datesSetLen := 2
 
@@ -74,7 +82,7 @@ func (d Date) getFilter(name string) (*filter, error) {
 	}
 
 	if ddRegexp.MatchString(name) {
-		return d.parseDate(d.prepareDays(dates[0], dates[1]))
+		return d.parseDate(d.prepareDays(dates[0], dates[1], t))
 	}
 
 	if ddmmyyyyRegexp.MatchString(name) {
@@ -88,9 +96,9 @@ func (d Date) getFilter(name string) (*filter, error) {
 	return nil, errors.New("can't find date pattern")
 }
 
-// prepareDays - prepare "from, to" dates adding current month/year.
-func (d Date) prepareDays(fromStr, toStr string) (from, to string) {
-	yearMonth := time.Now().Format(yearMonthPattern)
+// prepareDays - prepare "from, to" dates adding the month/year of "t".
+func (d Date) prepareDays(fromStr, toStr string, t time.Time) (from, to string) {
+	yearMonth := t.In(d.loc).Format(yearMonthPattern)
 	prefix := "0"
 	minLen := 1
 
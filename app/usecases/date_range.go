@@ -0,0 +1,240 @@
+package usecases
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/now"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+// Date patterns of the date range grammar.
+const (
+	isoDatePattern  = "2006-01-02"
+	dayMonthPattern = "02.01"
+	rangeSeparator  = ".."
+)
+
+// periodRange - returns the range of the period relative to the current time.
+type periodRange func(n *now.Now) (from, to time.Time)
+
+//nolint:gochecknoglobals
+var (
+	lastDaysRegexp = regexp.MustCompile(`^(?:last|останні|останніх) (\d+) (?:days?|день|дні|днів)$`)
+	sinceRegexp    = regexp.MustCompile(`^(?:since|з|із|від) (\S+)$`)
+	quarterRegexp  = regexp.MustCompile(`^(?:q|кв|к)([1-4])(?: (\d{4}))?$`)
+	monthRegexp    = regexp.MustCompile(`^(\pL+)(?: (\d{4}))?$`)
+	yearRegexp     = regexp.MustCompile(`^\d{4}$`)
+)
+
+// periodKeywords - the periods named by English and Ukrainian words, the weeks start on Monday.
+var periodKeywords = map[string]periodRange{ //nolint:gochecknoglobals
+	"today":           today,
+	"сьогодні":        today,
+	"yesterday":       yesterday,
+	"вчора":           yesterday,
+	"учора":           yesterday,
+	"week":            thisWeek,
+	"this week":       thisWeek,
+	"тиждень":         thisWeek,
+	"цей тиждень":     thisWeek,
+	"last week":       lastWeek,
+	"минулий тиждень": lastWeek,
+	"минулого тижня":  lastWeek,
+	"month":           thisMonth,
+	"this month":      thisMonth,
+	"місяць":          thisMonth,
+	"цей місяць":      thisMonth,
+	"last month":      lastMonth,
+	"минулий місяць":  lastMonth,
+	"минулого місяця": lastMonth,
+	"year":            thisYear,
+	"this year":       thisYear,
+	"рік":             thisYear,
+	"цей рік":         thisYear,
+	"last year":       lastYear,
+	"минулий рік":     lastYear,
+	"минулого року":   lastYear,
+}
+
+// monthNames - the English and Ukrainian month names, the Ukrainian ones in the nominative and genitive cases.
+var monthNames = map[string]time.Month{ //nolint:gochecknoglobals
+	"january": time.January, "jan": time.January, "січень": time.January, "січня": time.January,
+	"february": time.February, "feb": time.February, "лютий": time.February, "лютого": time.February,
+	"march": time.March, "mar": time.March, "березень": time.March, "березня": time.March,
+	"april": time.April, "apr": time.April, "квітень": time.April, "квітня": time.April,
+	"may": time.May, "травень": time.May, "травня": time.May,
+	"june": time.June, "jun": time.June, "червень": time.June, "червня": time.June,
+	"july": time.July, "jul": time.July, "липень": time.July, "липня": time.July,
+	"august": time.August, "aug": time.August, "серпень": time.August, "серпня": time.August,
+	"september": time.September, "sep": time.September, "вересень": time.September, "вересня": time.September,
+	"october": time.October, "oct": time.October, "жовтень": time.October, "жовтня": time.October,
+	"november": time.November, "nov": time.November, "листопад": time.November, "листопада": time.November,
+	"december": time.December, "dec": time.December, "грудень": time.December, "грудня": time.December,
+}
+
+// parseRange - parses the period in the time zone of the Date relative to "t":
+//   - the file name patterns of getFilter: "1-15", "01.08.2019-31.08.2019", "01.08.2019T10.00-31.08.2019T18.00";
+//   - a day or a range of days: "2024-03-01", "01.03.2024", "2024-03-01..2024-03-15", "01.03..15.03";
+//   - "today", "yesterday", "[this|last] week", "[this|last] month", "[this|last] year";
+//   - a quarter "q1 2024", a month "march", "march 2024", a year "2024";
+//   - "last 7 days", "since 01.03".
+//
+// The words can be Ukrainian too, e.g. "вчора", "минулого місяця", "березень", "кв1 2024", "останні 7 днів", "з 01.03".
+// A month, a quarter or a day without the year is the latest one, not after "t".
+func (d Date) parseRange(period string, t time.Time) (*filter, error) {
+	period = strings.Join(strings.Fields(period), " ")
+	if filter, err := d.getFilter(period, t); err == nil {
+		return filter, nil
+	}
+	period = strings.ToLower(period)
+
+	n := now.New(t.In(d.loc))
+	if keyword, ok := periodKeywords[period]; ok {
+		return dayFilter(keyword(n)), nil
+	}
+
+	if parts := strings.Split(period, rangeSeparator); len(parts) == 2 {
+		from, err := d.parseDay(parts[0], t)
+		if err != nil {
+			return nil, err
+		}
+
+		to, err := d.parseDay(parts[1], t)
+		if err != nil {
+			return nil, err
+		}
+
+		if from.After(to) {
+			return nil, model.NewValidationError("the period must not end before it starts: %s", period)
+		}
+
+		return dayFilter(from, now.New(to).EndOfDay()), nil
+	}
+
+	if m := lastDaysRegexp.FindStringSubmatch(period); m != nil {
+		days, err := strconv.Atoi(m[1])
+		if err != nil || days < 1 {
+			return nil, model.NewValidationError("the number of days must be positive: %s", period)
+		}
+
+		return dayFilter(now.New(t.In(d.loc).AddDate(0, 0, 1-days)).BeginningOfDay(), n.EndOfDay()), nil
+	}
+
+	if m := sinceRegexp.FindStringSubmatch(period); m != nil {
+		from, err := d.parseDay(m[1], t)
+		if err != nil {
+			return nil, err
+		}
+
+		return dayFilter(from, n.EndOfDay()), nil
+	}
+
+	if m := quarterRegexp.FindStringSubmatch(period); m != nil {
+		quarter, _ := strconv.Atoi(m[1])
+		from := d.monthStart(m[2], time.Month(quarter*3-2), t)
+
+		return dayFilter(from, now.New(from).EndOfQuarter()), nil
+	}
+
+	if m := monthRegexp.FindStringSubmatch(period); m != nil {
+		if month, ok := monthNames[m[1]]; ok {
+			from := d.monthStart(m[2], month, t)
+
+			return dayFilter(from, now.New(from).EndOfMonth()), nil
+		}
+	}
+
+	if yearRegexp.MatchString(period) {
+		year, _ := strconv.Atoi(period)
+		from := time.Date(year, time.January, 1, 0, 0, 0, 0, d.loc)
+
+		return dayFilter(from, now.New(from).EndOfYear()), nil
+	}
+
+	if from, err := d.parseDay(period, t); err == nil {
+		return dayFilter(from, now.New(from).EndOfDay()), nil
+	}
+
+	return nil, model.NewValidationError("can't recognize the period: %s", period)
+}
+
+// parseDay - parses the beginning of the day "2024-03-01", "01.03.2024" or "01.03", the latest one not after "t".
+func (d Date) parseDay(s string, t time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range []string{isoDatePattern, datePattern} {
+		if day, err := time.ParseInLocation(layout, s, d.loc); err == nil {
+			return day, nil
+		}
+	}
+
+	day, err := time.ParseInLocation(dayMonthPattern, s, d.loc)
+	if err != nil {
+		return time.Time{}, model.NewValidationError("can't parse the date: %s", s)
+	}
+
+	day = day.AddDate(t.In(d.loc).Year()-day.Year(), 0, 0)
+	if day.After(t) {
+		day = day.AddDate(-1, 0, 0)
+	}
+
+	return day, nil
+}
+
+// monthStart - returns the beginning of the month of the year, the latest one not after "t" if the year is empty.
+func (d Date) monthStart(year string, month time.Month, t time.Time) time.Time {
+	if year != "" {
+		y, _ := strconv.Atoi(year)
+
+		return time.Date(y, month, 1, 0, 0, 0, 0, d.loc)
+	}
+
+	start := time.Date(t.In(d.loc).Year(), month, 1, 0, 0, 0, 0, d.loc)
+	if start.After(t) {
+		start = start.AddDate(-1, 0, 0)
+	}
+
+	return start
+}
+
+// dayFilter - returns the filter of the range truncated to days.
+func dayFilter(from, to time.Time) *filter {
+	return &filter{from: from, to: to, truncate: timeDurationDay}
+}
+
+func today(n *now.Now) (from, to time.Time) {
+	return n.BeginningOfDay(), n.EndOfDay()
+}
+
+func yesterday(n *now.Now) (from, to time.Time) {
+	return today(now.New(n.AddDate(0, 0, -1)))
+}
+
+func thisWeek(n *now.Now) (from, to time.Time) {
+	monday := n.Monday()
+
+	return monday, monday.AddDate(0, 0, 7).Add(-time.Nanosecond)
+}
+
+func lastWeek(n *now.Now) (from, to time.Time) {
+	return thisWeek(now.New(n.AddDate(0, 0, -7)))
+}
+
+func thisMonth(n *now.Now) (from, to time.Time) {
+	return n.BeginningOfMonth(), n.EndOfMonth()
+}
+
+func lastMonth(n *now.Now) (from, to time.Time) {
+	return thisMonth(now.New(n.BeginningOfMonth().AddDate(0, -1, 0)))
+}
+
+func thisYear(n *now.Now) (from, to time.Time) {
+	return n.BeginningOfYear(), n.EndOfYear()
+}
+
+func lastYear(n *now.Now) (from, to time.Time) {
+	return thisYear(now.New(n.BeginningOfYear().AddDate(-1, 0, 0)))
+}
@@ -0,0 +1,66 @@
+package usecases
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestDate_parseRange(t *testing.T) {
+	RegisterTestingT(t)
+	loc, _ := time.LoadLocation("Europe/Kiev")
+	d := Date{loc: loc}
+	// Wednesday.
+	current := time.Date(2024, 5, 15, 10, 30, 0, 0, loc)
+	day := func(year int, month time.Month, day int) time.Time {
+		return time.Date(year, month, day, 0, 0, 0, 0, loc)
+	}
+	end := func(t time.Time) time.Time { return t.Add(-time.Nanosecond) }
+
+	tests := []struct {
+		period   string
+		from, to time.Time
+	}{
+		{period: "01.03.2024-15.03.2024", from: day(2024, 3, 1), to: end(day(2024, 3, 16))},
+		{period: "1-15", from: day(2024, 5, 1), to: end(day(2024, 5, 16))},
+		{period: "today", from: day(2024, 5, 15), to: end(day(2024, 5, 16))},
+		{period: "Сьогодні", from: day(2024, 5, 15), to: end(day(2024, 5, 16))},
+		{period: "yesterday", from: day(2024, 5, 14), to: end(day(2024, 5, 15))},
+		{period: "вчора", from: day(2024, 5, 14), to: end(day(2024, 5, 15))},
+		{period: "week", from: day(2024, 5, 13), to: end(day(2024, 5, 20))},
+		{period: "last  week", from: day(2024, 5, 6), to: end(day(2024, 5, 13))},
+		{period: "минулого тижня", from: day(2024, 5, 6), to: end(day(2024, 5, 13))},
+		{period: "month", from: day(2024, 5, 1), to: end(day(2024, 6, 1))},
+		{period: "last month", from: day(2024, 4, 1), to: end(day(2024, 5, 1))},
+		{period: "минулий місяць", from: day(2024, 4, 1), to: end(day(2024, 5, 1))},
+		{period: "last year", from: day(2023, 1, 1), to: end(day(2024, 1, 1))},
+		{period: "q1 2024", from: day(2024, 1, 1), to: end(day(2024, 4, 1))},
+		{period: "Q3", from: day(2023, 7, 1), to: end(day(2023, 10, 1))},
+		{period: "кв2 2023", from: day(2023, 4, 1), to: end(day(2023, 7, 1))},
+		{period: "march", from: day(2024, 3, 1), to: end(day(2024, 4, 1))},
+		{period: "june", from: day(2023, 6, 1), to: end(day(2023, 7, 1))},
+		{period: "березня 2023", from: day(2023, 3, 1), to: end(day(2023, 4, 1))},
+		{period: "2023", from: day(2023, 1, 1), to: end(day(2024, 1, 1))},
+		{period: "last 7 days", from: day(2024, 5, 9), to: end(day(2024, 5, 16))},
+		{period: "останні 30 днів", from: day(2024, 4, 16), to: end(day(2024, 5, 16))},
+		{period: "since 01.03", from: day(2024, 3, 1), to: end(day(2024, 5, 16))},
+		{period: "з 01.12", from: day(2023, 12, 1), to: end(day(2024, 5, 16))},
+		{period: "2024-03-01..2024-03-15", from: day(2024, 3, 1), to: end(day(2024, 3, 16))},
+		{period: "01.03..15.03", from: day(2024, 3, 1), to: end(day(2024, 3, 16))},
+		{period: "2024-03-01", from: day(2024, 3, 1), to: end(day(2024, 3, 2))},
+		{period: "01.03.2024", from: day(2024, 3, 1), to: end(day(2024, 3, 2))},
+	}
+	for _, tt := range tests {
+		got, err := d.parseRange(tt.period, current)
+		Ω(err).To(BeNil(), tt.period)
+
+		Ω(got.from).To(BeTemporally("==", tt.from), tt.period)
+		Ω(got.to).To(BeTemporally("==", tt.to), tt.period)
+	}
+
+	for _, period := range []string{"", "vet", "last 0 days", "2024-03-15..2024-03-01", "q5 2024", "since tomorrow"} {
+		_, err := d.parseRange(period, current)
+		Ω(err).ToNot(BeNil(), period)
+	}
+}
@@ -0,0 +1,56 @@
+package usecases
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+const (
+	dialogKey = "dialog"
+	// dialogTTL - how long the dialog waits for the answer, every answer prolongs it.
+	dialogTTL = 15 * time.Minute
+)
+
+//go:generate mockgen -destination=./dialog_mock_test.go -package=usecases_test -source=./dialog.go
+
+// DialogRepo - represents the repository interface of the dialog states.
+type DialogRepo interface {
+	Set(key string, val model.Dialog, ttl time.Duration) error
+	Get(key string) (model.Dialog, error)
+	Delete(key string) error
+}
+
+// NewDialog - builds the dialog use-case.
+func NewDialog(repo DialogRepo) *Dialog {
+	return &Dialog{repo: repo}
+}
+
+// Dialog - represents the use-case keeping the state of the multi-step dialogs, one dialog per chat.
+type Dialog struct {
+	repo DialogRepo
+}
+
+// Get - returns the dialog of the chat, "model.ErrNil" if there is no dialog or it expired.
+func (d *Dialog) Get(chatID int64) (model.Dialog, error) {
+	return d.repo.Get(dialogChatKey(chatID))
+}
+
+// Set - saves the dialog of the chat replacing the previous one, it expires in dialogTTL.
+func (d *Dialog) Set(chatID int64, dialog model.Dialog) error {
+	if dialog.Flow == "" || dialog.Step == "" {
+		return model.NewValidationError("dialog must have the flow and the step")
+	}
+
+	return d.repo.Set(dialogChatKey(chatID), dialog, dialogTTL)
+}
+
+// Delete - ends the dialog of the chat, returns "model.ErrNil" if there is no dialog.
+func (d *Dialog) Delete(chatID int64) error {
+	return d.repo.Delete(dialogChatKey(chatID))
+}
+
+func dialogChatKey(chatID int64) string {
+	return fmt.Sprintf("%s_%d", dialogKey, chatID)
+}
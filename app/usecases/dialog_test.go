@@ -0,0 +1,38 @@
+package usecases_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+	uc "github.com/Kalachevskyi/mono-chat/app/usecases"
+)
+
+func TestDialog_Set(t *testing.T) {
+	RegisterTestingT(t)
+	mockCtrl := gomock.NewController(t)
+
+	dialog := model.Dialog{Flow: "export", Step: "period", Data: map[string]string{"account": "a1"}}
+	repo := NewMockDialogRepo(mockCtrl)
+	repo.EXPECT().Set("dialog_42", dialog, 15*time.Minute).Return(nil).Times(1)
+	repo.EXPECT().Get("dialog_42").Return(dialog, nil).Times(1)
+	repo.EXPECT().Delete("dialog_42").Return(nil).Times(1)
+
+	d := uc.NewDialog(repo)
+	err := d.Set(42, dialog)
+	Ω(err).To(BeNil(), fmt.Sprintf(errDefaultMsg, err))
+
+	got, err := d.Get(42)
+	Ω(err).To(BeNil(), fmt.Sprintf(errDefaultMsg, err))
+	Ω(got).To(Equal(dialog), errNotEqual)
+
+	err = d.Delete(42)
+	Ω(err).To(BeNil(), fmt.Sprintf(errDefaultMsg, err))
+
+	err = d.Set(42, model.Dialog{Flow: "export"})
+	Ω(err).To(BeAssignableToTypeOf(model.ValidationError{}), errNotEqual)
+}
@@ -6,19 +6,28 @@ import (
 	"fmt"
 	"io"
 	"net/url"
+	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/pkg/errors"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
 )
 
 const (
 	csvSuffix    = ".csv"
 	errWriteLine = "can't write line: line=%v err=%v"
-	reportLines  = 10
 )
 
+// statementReaders - the readers of the statements by the file extension.
+var statementReaders = map[string]func(r io.Reader) ([]statementRow, error){ //nolint:gochecknoglobals
+	csvSuffix: readCSVStatement,
+	".xls":    readXLSStatement,
+	".xlsx":   readXLSXStatement,
+}
+
 // TelegramRepo - represents Telegram repository interface.
 type TelegramRepo interface {
 	GetFile(u *url.URL) (io.ReadCloser, error)
@@ -48,20 +57,27 @@ type FileReport struct {
 	TelegramRepo
 }
 
-// Validate - validate file name by suffix ".csv".
+// Validate - validate file name by suffix: ".csv", ".xls" or ".xlsx".
 func (c *FileReport) Validate(name string) error {
-	if !strings.HasSuffix(name, csvSuffix) {
-		return errors.New(`chat can only be processed using the file "csv"`)
+	if _, ok := statementReaders[strings.ToLower(filepath.Ext(name))]; !ok {
+		return model.NewValidationError(`chat can only be processed using the files "csv", "xls" and "xlsx"`)
 	}
 
 	return nil
 }
 
-// Parse - parse MonoBank "csv" report, convert it to application format.
+// Parse - parse MonoBank "csv", "xls" or "xlsx" statement, convert it to application "csv" format,
+// see readCSVStatement for the supported encodings, delimiters and layouts.
 func (c *FileReport) Parse(userID uuid.UUID, fileName string, r io.Reader) (io.Reader, error) {
-	lines, err := csv.NewReader(r).ReadAll()
+	ext := filepath.Ext(fileName)
+	read, ok := statementReaders[strings.ToLower(ext)]
+	if !ok {
+		return nil, errors.Errorf("unknown statement format: %s", fileName)
+	}
+
+	rows, err := read(r)
 	if err != nil {
-		return nil, errors.Errorf("can't read file: err=%s", err)
+		return nil, err
 	}
 
 	// Set header to file
@@ -86,26 +102,15 @@ func (c *FileReport) Parse(userID uuid.UUID, fileName string, r io.Reader) (io.R
 		c.log.Error(err)
 	}
 
-	filter, err := c.date.getFilter(fileName)
+	filter, err := c.date.forUser(userID).getFilter(strings.TrimSuffix(fileName, ext), time.Now())
 	if err != nil {
 		return nil, err
 	}
 
-	for _, line := range lines {
-		if len(line) != reportLines {
-			return nil, errors.New("report template does not match, should be 10")
-		}
-
-		date, category, bankCategory, description, amount := line[0], line[2], line[2], line[1], line[3]
-		description = strings.ReplaceAll(description, "\n", " ")
-
+	for _, row := range rows {
+		category, bankCategory, description := row.mcc, row.mcc, row.description
 		if filter != nil {
-			dateTime, err := time.Parse(dateTimeReportPattern, date)
-			if err != nil {
-				return nil, errors.WithStack(err)
-			}
-			dateTime = dateTime.Truncate(filter.truncate)
-			if ok := c.applyFilter(dateTime, *filter); !ok {
+			if ok := c.applyFilter(row.date.Truncate(filter.truncate), *filter); !ok {
 				continue
 			}
 		}
@@ -118,7 +123,7 @@ func (c *FileReport) Parse(userID uuid.UUID, fileName string, r io.Reader) (io.R
 			}
 		}
 
-		record := []string{date, description, category, bankCategory, amount}
+		record := []string{row.date.Format(dateTimeReportPattern), description, category, bankCategory, row.amount}
 		if err := wr.Write(record); err != nil {
 			return nil, errors.Errorf(errWriteLine, record, err)
 		}
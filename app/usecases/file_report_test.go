@@ -0,0 +1,167 @@
+package usecases_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	. "github.com/onsi/gomega"
+	"golang.org/x/text/encoding/charmap"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+	uc "github.com/Kalachevskyi/mono-chat/app/usecases"
+)
+
+func TestFileReport_Parse(t *testing.T) {
+	RegisterTestingT(t)
+	mockCtrl := gomock.NewController(t)
+	loc, _ := time.LoadLocation("Europe/Kiev")
+	userID := uuid.New()
+
+	cp1251, err := charmap.Windows1251.NewEncoder().String("Дата i час операції;Деталі операції;MCC;" +
+		"Сума в валюті картки (UAH);Сума в валюті операції;Валюта;Курс;Сума комісій (UAH);Сума кешбеку (UAH);" +
+		"Залишок після операції;Категорія\n" +
+		"03.03.2020 12:00:00;Сільпо;5411;-200.00;-200.00;UAH;—;0.00;0.00;800.00;Продукти\n")
+	Ω(err).To(BeNil(), fmt.Sprintf(errDefaultMsg, err))
+
+	tests := []struct {
+		name    string
+		report  string
+		want    string
+		wantErr string
+	}{
+		{
+			name: "10 columns without header",
+			report: `"02.03.2020 10:00:00","Uklon","4121","-50.00","-50.00","UAH","—","0.00","0.00","950.00"` + "\n" +
+				`"03.03.2020 12:00:00","Silpo","5411","-200.00","-200.00","UAH","—","0.00","0.00","750.00"` + "\n",
+			want: "Date,Description,Category,Bank category,Amount\n" +
+				"02.03.2020 10:00:00,Uklon,4121,4121,-50.00\n03.03.2020 12:00:00,Silpo,Продукти,5411,-200.00\n",
+		},
+		{
+			name:   "Windows-1251 with semicolons and 11-column header",
+			report: cp1251,
+			want: "Date,Description,Category,Bank category,Amount\n" +
+				"03.03.2020 12:00:00,Сільпо,Продукти,5411,-200.00\n",
+		},
+		{
+			name: "UTF-8 BOM with English header",
+			report: "\ufeffDate and time,Description,MCC,\"Card currency amount, (UAH)\",Operation amount\n" +
+				"2020-03-03 12:00:00,Silpo,5411,-200.00,-200.00\n",
+			want: "Date,Description,Category,Bank category,Amount\n" +
+				"03.03.2020 12:00:00,Silpo,Продукти,5411,-200.00\n",
+		},
+		{
+			name:    "invalid date",
+			report:  "02.03.2020 10:00:00,Uklon,4121,-50.00\n2020/03/03,Silpo,5411,-200.00\n",
+			wantErr: `line 2: can't parse the date "2020/03/03"`,
+		},
+		{
+			name:    "missing columns",
+			report:  "02.03.2020 10:00:00,Uklon,4121,-50.00\n03.03.2020 12:00:00,Silpo\n",
+			wantErr: "line 2: the statement must have at least 4 columns, got 2",
+		},
+		{
+			name:    "header without amount",
+			report:  "Дата i час операції;Деталі операції;MCC\n03.03.2020 12:00:00;Сільпо;5411\n",
+			wantErr: "line 1: the card currency amount column isn't found in the header",
+		},
+	}
+
+	for _, tt := range tests {
+		mappingRepo := NewMockMappingRepo(mockCtrl)
+		mappingRepo.EXPECT().Get(fmt.Sprintf("mapping_%s", userID)).Return(map[string]model.CategoryMapping{
+			"5411": {Mono: "5411", App: "Продукти"},
+		}, nil).AnyTimes()
+
+		report := uc.NewFileReport(uc.NewDate(loc, nil), mappingRepo, nil, nil)
+		r, err := report.Parse(userID, "01.03.2020-31.03.2020.csv", strings.NewReader(tt.report))
+		if tt.wantErr != "" {
+			Ω(err).To(MatchError(tt.wantErr), tt.name)
+
+			continue
+		}
+
+		Ω(err).To(BeNil(), fmt.Sprintf(errDefaultMsg, err))
+		got, _ := ioutil.ReadAll(r)
+		Ω(string(got)).To(Equal(tt.want), tt.name)
+	}
+}
+
+func TestFileReport_ParseXLSX(t *testing.T) {
+	RegisterTestingT(t)
+	mockCtrl := gomock.NewController(t)
+	loc, _ := time.LoadLocation("Europe/Kiev")
+	userID := uuid.New()
+
+	buf := &bytes.Buffer{}
+	archive := zip.NewWriter(buf)
+	for name, content := range map[string]string{
+		"xl/workbook.xml": `<workbook xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets><sheet name="Statement" sheetId="1" r:id="rId1"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships><Relationship Id="rId1" ` +
+			`Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" ` +
+			`Target="worksheets/sheet1.xml"/></Relationships>`,
+		"xl/sharedStrings.xml": `<sst><si><t>Дата i час операції</t></si><si><t>Деталі операції</t></si>` +
+			`<si><t>MCC</t></si><si><t>Сума в валюті картки (UAH)</t></si><si><r><t>Сіль</t></r><r><t>по</t></r></si></sst>`,
+		"xl/worksheets/sheet1.xml": `<worksheet><sheetData>` +
+			`<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c><c r="C1" t="s"><v>2</v></c>` +
+			`<c r="D1" t="s"><v>3</v></c></row>` +
+			`<row r="2"><c r="A2"><v>43893.5</v></c><c r="B2" t="s"><v>4</v></c><c r="C2"><v>5411</v></c>` +
+			`<c r="D2"><v>-200</v></c></row>` +
+			`<row r="3"><c r="A3" t="inlineStr"><is><t>04.04.2020 10:00:00</t></is></c>` +
+			`<c r="B3" t="inlineStr"><is><t>Uklon</t></is></c><c r="C3"><v>4121</v></c><c r="D3"><v>-50</v></c></row>` +
+			`</sheetData></worksheet>`,
+	} {
+		w, err := archive.Create(name)
+		Ω(err).To(BeNil(), fmt.Sprintf(errDefaultMsg, err))
+		_, err = w.Write([]byte(content))
+		Ω(err).To(BeNil(), fmt.Sprintf(errDefaultMsg, err))
+	}
+	Ω(archive.Close()).To(BeNil(), errNotEqual)
+
+	mappingRepo := NewMockMappingRepo(mockCtrl)
+	mappingRepo.EXPECT().Get(fmt.Sprintf("mapping_%s", userID)).Return(map[string]model.CategoryMapping{
+		"5411": {Mono: "5411", App: "Продукти"},
+	}, nil).Times(1)
+
+	report := uc.NewFileReport(uc.NewDate(loc, nil), mappingRepo, nil, nil)
+	Ω(report.Validate("01.03.2020-31.03.2020.XLSX")).To(BeNil(), errNotEqual)
+	Ω(report.Validate("statement.pdf")).NotTo(BeNil(), errNotEqual)
+
+	r, err := report.Parse(userID, "01.03.2020-31.03.2020.xlsx", buf)
+	Ω(err).To(BeNil(), fmt.Sprintf(errDefaultMsg, err))
+	got, _ := ioutil.ReadAll(r)
+	Ω(string(got)).To(Equal("Date,Description,Category,Bank category,Amount\n"+
+		"03.03.2020 12:00:00,Сільпо,Продукти,5411,-200\n"), errNotEqual)
+}
+
+func TestFileReport_ParseXLSXInvalidColumn(t *testing.T) {
+	RegisterTestingT(t)
+	buf := &bytes.Buffer{}
+	archive := zip.NewWriter(buf)
+	for name, content := range map[string]string{
+		"xl/workbook.xml": `<workbook xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets><sheet name="Statement" sheetId="1" r:id="rId1"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships><Relationship Id="rId1" ` +
+			`Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" ` +
+			`Target="worksheets/sheet1.xml"/></Relationships>`,
+		"xl/worksheets/sheet1.xml": `<worksheet><sheetData><row r="1"><c r="ZZZZZZZZZZZZZZZ1"><v>1</v></c></row>` +
+			`</sheetData></worksheet>`,
+	} {
+		w, err := archive.Create(name)
+		Ω(err).To(BeNil(), fmt.Sprintf(errDefaultMsg, err))
+		_, err = w.Write([]byte(content))
+		Ω(err).To(BeNil(), fmt.Sprintf(errDefaultMsg, err))
+	}
+	Ω(archive.Close()).To(BeNil(), errNotEqual)
+
+	report := uc.NewFileReport(uc.NewDate(time.UTC, nil), nil, nil, nil)
+	_, err := report.Parse(uuid.New(), "01.03.2020-31.03.2020.xlsx", buf)
+	Ω(err).To(MatchError("can't read file: err=invalid cell reference: ZZZZZZZZZZZZZZZ1"), errNotEqual)
+}
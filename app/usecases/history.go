@@ -0,0 +1,238 @@
+package usecases
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+const (
+	historyKey      = "history"
+	historySyncKey  = "history_sync"
+	historyUsersKey = "history_users"
+	historyFrom     = "from"
+	historyTo       = "to"
+	// historyDepth - how long ago the history starts.
+	historyDepth = 186 * timeDurationDay
+	// historyStep - the longest period fetched at once, MonoBank returns up to 31 days per request.
+	historyStep = 31 * timeDurationDay
+	// historyOverlap - the recent transactions are fetched again, so the holds are updated.
+	historyOverlap = timeDurationDay
+	// historyRefresh - the recent transactions are fetched by Sync if the history is older,
+	// otherwise the history is extended to the past.
+	historyRefresh = 30 * time.Minute
+	// historyFresh - the history isn't updated for a statement if it is younger,
+	// MonoBank allows one statement request per minute.
+	historyFresh = time.Minute
+	// monoStatementLimit - the max number of the transactions MonoBank returns per request,
+	// the newest ones are returned if the period has more.
+	monoStatementLimit = 500
+)
+
+//go:generate mockgen -destination=./history_mock_test.go -package=usecases_test -source=./history.go
+
+// TransactionRepo - represents the repository keeping the account's transactions by ID.
+type TransactionRepo interface {
+	Replace(key string, from, to time.Time, transactions []model.Transaction) error
+	Range(key string, from, to time.Time) ([]model.Transaction, error)
+	SetTime(key, field string, t time.Time) error
+	GetTimes(key string) (map[string]time.Time, error)
+	AddMember(key, member string) error
+	Members(key string) ([]string, error)
+}
+
+// NewHistory - builds transaction history use-case.
+func NewHistory(repo TransactionRepo, monoRepo MonoRepo, log Logger) *History {
+	return &History{repo: repo, monoRepo: monoRepo, log: log}
+}
+
+// History - represents the use-case keeping the history of the account's transactions, so the reports
+// don't download the whole statement every time and aren't limited by the bank's retention window.
+type History struct {
+	repo     TransactionRepo
+	monoRepo MonoRepo
+	log      Logger
+}
+
+// Statement - returns the account's transactions of the period. If the period is in the history,
+// only the transactions since the last sync are fetched from MonoBank, otherwise, if "refresh" is set
+// or the last sync is too old to be fetched in one request, the whole period is fetched.
+// The user's history is synced by Sync since then.
+func (h *History) Statement(userID uuid.UUID, token, account string, from, to time.Time, refresh bool) (
+	[]model.Transaction, error) {
+	if err := h.repo.AddMember(historyUsersKey, userID.String()); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	state, err := h.State(account, now)
+	if err != nil {
+		return nil, err
+	}
+
+	since := state.To.Add(-historyOverlap)
+	if !refresh && !state.To.IsZero() && !from.Before(state.From) && now.Sub(since) <= maxStatementRange {
+		if end := minTime(to, now); end.Sub(state.To) > historyFresh {
+			state.To = now
+			if err := h.fetch(token, account, state, since, now); err != nil {
+				return nil, err
+			}
+		}
+
+		return h.Get(account, from, to)
+	}
+
+	transactions, err := h.monoRepo.GetTransactions(token, account, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := h.save(account, from, to, transactions); err != nil {
+		return nil, err
+	}
+
+	// the fetched period continues the history
+	if !state.To.IsZero() && !from.After(state.To) && !to.Before(now) {
+		if err := h.repo.SetTime(historySyncAccountKey(account), historyTo, now); err != nil {
+			return nil, err
+		}
+	}
+
+	return transactions, nil
+}
+
+// Sync - makes at most one statement request: fetches the transactions since the last sync
+// if the history is older than 30 minutes, otherwise extends the history 31 days to the past
+// until it covers ~6 months. MonoBank allows one statement request per minute.
+func (h *History) Sync(userID uuid.UUID, token, account string, now time.Time) (model.HistoryState, error) {
+	if err := h.repo.AddMember(historyUsersKey, userID.String()); err != nil {
+		return model.HistoryState{}, err
+	}
+
+	state, err := h.State(account, now)
+	if err != nil {
+		return model.HistoryState{}, err
+	}
+
+	var from, to time.Time
+	switch {
+	case state.To.IsZero():
+		from, to = now.Add(-historyStep), now
+		state.From, state.To = from, to
+	case now.Sub(state.To) >= historyRefresh:
+		from, to = state.To.Add(-historyOverlap), now
+		if to.Sub(from) > historyStep {
+			to = from.Add(historyStep)
+		}
+		state.To = to
+	case !state.Complete:
+		from, to = state.From.Add(-historyStep), state.From
+		if start := now.Add(-historyDepth); from.Before(start) {
+			from = start
+		}
+		state.From = from
+	default:
+		return state, nil
+	}
+
+	if err := h.fetch(token, account, state, from, to); err != nil {
+		return model.HistoryState{}, err
+	}
+	state.Complete = !state.From.After(now.Add(-historyDepth))
+
+	return state, nil
+}
+
+// State - returns the period of the account's history.
+func (h *History) State(account string, now time.Time) (model.HistoryState, error) {
+	times, err := h.repo.GetTimes(historySyncAccountKey(account))
+	if err != nil {
+		return model.HistoryState{}, err
+	}
+
+	state := model.HistoryState{From: times[historyFrom], To: times[historyTo]}
+	state.Complete = !state.To.IsZero() && !state.From.After(now.Add(-historyDepth))
+
+	return state, nil
+}
+
+// Get - returns the account's transactions of the period kept in the history, ordered by time.
+func (h *History) Get(account string, from, to time.Time) ([]model.Transaction, error) {
+	return h.repo.Range(historyAccountKey(account), from, to)
+}
+
+// Users - returns the users whose history is synced.
+func (h *History) Users() ([]uuid.UUID, error) {
+	members, err := h.repo.Members(historyUsersKey)
+	if err != nil {
+		return nil, err
+	}
+
+	users := make([]uuid.UUID, 0, len(members))
+	for _, member := range members {
+		userID, err := uuid.Parse(member)
+		if err != nil {
+			h.log.Error(errors.Wrapf(err, "can't parse history user: %s", member))
+
+			continue
+		}
+		users = append(users, userID)
+	}
+
+	return users, nil
+}
+
+// fetch - saves the transactions of the period in the history, then saves the new history state.
+func (h *History) fetch(token, account string, state model.HistoryState, from, to time.Time) error {
+	transactions, err := h.monoRepo.GetTransactions(token, account, from, to)
+	if err != nil {
+		return err
+	}
+
+	if err := h.save(account, from, to, transactions); err != nil {
+		return err
+	}
+
+	key := historySyncAccountKey(account)
+	if err := h.repo.SetTime(key, historyFrom, state.From); err != nil {
+		return err
+	}
+
+	return h.repo.SetTime(key, historyTo, state.To)
+}
+
+// save - replaces the history of the period with the fetched transactions, so the transactions
+// which the bank doesn't return anymore, e.g. the canceled holds, are deleted. If the statement is cut
+// by monoStatementLimit, only the period since the oldest fetched transaction is replaced.
+func (h *History) save(account string, from, to time.Time, transactions []model.Transaction) error {
+	if len(transactions) >= monoStatementLimit {
+		from = to
+		for _, tr := range transactions {
+			if t := time.Unix(int64(tr.Time), 0).In(to.Location()); t.Before(from) {
+				from = t
+			}
+		}
+	}
+
+	return h.repo.Replace(historyAccountKey(account), from, to, transactions)
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+
+	return b
+}
+
+func historyAccountKey(account string) string {
+	return fmt.Sprintf("%s_%s", historyKey, account)
+}
+
+func historySyncAccountKey(account string) string {
+	return fmt.Sprintf("%s_%s", historySyncKey, account)
+}
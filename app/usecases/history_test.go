@@ -0,0 +1,222 @@
+package usecases_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	. "github.com/onsi/gomega"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+	uc "github.com/Kalachevskyi/mono-chat/app/usecases"
+)
+
+func TestHistory_Sync(t *testing.T) {
+	RegisterTestingT(t)
+	day := 24 * time.Hour
+	now := time.Date(2020, 9, 1, 12, 0, 0, 0, time.UTC)
+	userID := uuid.New()
+	transactions := []model.Transaction{{ID: "1"}}
+
+	tests := []struct {
+		name         string
+		state        map[string]time.Time
+		from, to     time.Time
+		want         model.HistoryState
+		noStatements bool
+	}{
+		{
+			name: "first sync",
+			from: now.Add(-31 * day), to: now,
+			want: model.HistoryState{From: now.Add(-31 * day), To: now},
+		},
+		{
+			name:  "recent transactions",
+			state: map[string]time.Time{"from": now.Add(-40 * day), "to": now.Add(-time.Hour)},
+			from:  now.Add(-time.Hour - day),
+			to:    now,
+			want:  model.HistoryState{From: now.Add(-40 * day), To: now},
+		},
+		{
+			name:  "past transactions",
+			state: map[string]time.Time{"from": now.Add(-170 * day), "to": now.Add(-time.Minute)},
+			from:  now.Add(-186 * day),
+			to:    now.Add(-170 * day),
+			want:  model.HistoryState{From: now.Add(-186 * day), To: now.Add(-time.Minute), Complete: true},
+		},
+		{
+			name:         "complete",
+			state:        map[string]time.Time{"from": now.Add(-186 * day), "to": now.Add(-time.Minute)},
+			want:         model.HistoryState{From: now.Add(-186 * day), To: now.Add(-time.Minute), Complete: true},
+			noStatements: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			repo := NewMockTransactionRepo(mockCtrl)
+			repo.EXPECT().AddMember("history_users", userID.String()).Return(nil).Times(1)
+			repo.EXPECT().GetTimes("history_sync_account").Return(tt.state, nil).Times(1)
+
+			monoRepo := NewMockMonoRepo(mockCtrl)
+			if !tt.noStatements {
+				monoRepo.EXPECT().GetTransactions("token", "account", tt.from, tt.to).Return(transactions, nil).Times(1)
+				repo.EXPECT().Replace("history_account", tt.from, tt.to, transactions).Return(nil).Times(1)
+				repo.EXPECT().SetTime("history_sync_account", "from", tt.want.From).Return(nil).Times(1)
+				repo.EXPECT().SetTime("history_sync_account", "to", tt.want.To).Return(nil).Times(1)
+			}
+
+			got, err := uc.NewHistory(repo, monoRepo, nil).Sync(userID, "token", "account", now)
+			Ω(err).To(BeNil(), fmt.Sprintf(errDefaultMsg, err))
+			Ω(got).To(Equal(tt.want), errNotEqual)
+		})
+	}
+}
+
+func TestHistory_Statement(t *testing.T) {
+	RegisterTestingT(t)
+	day := 24 * time.Hour
+	now := time.Now()
+	userID := uuid.New()
+	stored := []model.Transaction{{ID: "1"}}
+	fetched := []model.Transaction{{ID: "2"}}
+
+	tests := []struct {
+		name    string
+		state   map[string]time.Time
+		from    time.Time
+		to      time.Time
+		refresh bool
+		expect  func(repo *MockTransactionRepo, monoRepo *MockMonoRepo, to time.Time)
+		want    []model.Transaction
+	}{
+		{
+			name:  "fresh history",
+			state: map[string]time.Time{"from": now.Add(-40 * day), "to": now.Add(-10 * time.Second)},
+			from:  now.Add(-10 * day),
+			to:    now.Add(time.Hour),
+			expect: func(repo *MockTransactionRepo, monoRepo *MockMonoRepo, to time.Time) {
+				repo.EXPECT().Range("history_account", now.Add(-10*day), to).Return(stored, nil).Times(1)
+			},
+			want: stored,
+		},
+		{
+			name:  "transactions since the last sync",
+			state: map[string]time.Time{"from": now.Add(-40 * day), "to": now.Add(-time.Hour)},
+			from:  now.Add(-10 * day),
+			to:    now.Add(time.Hour),
+			expect: func(repo *MockTransactionRepo, monoRepo *MockMonoRepo, to time.Time) {
+				monoRepo.EXPECT().GetTransactions("token", "account", now.Add(-time.Hour-day), gomock.Any()).
+					Return(fetched, nil).Times(1)
+				repo.EXPECT().Replace("history_account", now.Add(-time.Hour-day), gomock.Any(), fetched).Return(nil).
+					Times(1)
+				repo.EXPECT().SetTime("history_sync_account", "from", now.Add(-40*day)).Return(nil).Times(1)
+				repo.EXPECT().SetTime("history_sync_account", "to", gomock.Any()).Return(nil).Times(1)
+				repo.EXPECT().Range("history_account", now.Add(-10*day), to).Return(stored, nil).Times(1)
+			},
+			want: stored,
+		},
+		{
+			name:  "30 days since the last sync",
+			state: map[string]time.Time{"from": now.Add(-40 * day), "to": now.Add(-30 * day)},
+			from:  now.Add(-10 * day),
+			to:    now.Add(time.Hour),
+			expect: func(repo *MockTransactionRepo, monoRepo *MockMonoRepo, to time.Time) {
+				monoRepo.EXPECT().GetTransactions("token", "account", now.Add(-31*day), gomock.Any()).
+					Return(fetched, nil).Times(1)
+				repo.EXPECT().Replace("history_account", now.Add(-31*day), gomock.Any(), fetched).Return(nil).Times(1)
+				repo.EXPECT().SetTime("history_sync_account", "from", now.Add(-40*day)).Return(nil).Times(1)
+				repo.EXPECT().SetTime("history_sync_account", "to", gomock.Any()).Return(nil).Times(1)
+				repo.EXPECT().Range("history_account", now.Add(-10*day), to).Return(stored, nil).Times(1)
+			},
+			want: stored,
+		},
+		{
+			name:  "history too old for one request",
+			state: map[string]time.Time{"from": now.Add(-40 * day), "to": now.Add(-30*day - 12*time.Hour)},
+			from:  now.Add(-10 * day),
+			to:    now.Add(time.Hour),
+			expect: func(repo *MockTransactionRepo, monoRepo *MockMonoRepo, to time.Time) {
+				monoRepo.EXPECT().GetTransactions("token", "account", now.Add(-10*day), to).Return(fetched, nil).Times(1)
+				repo.EXPECT().Replace("history_account", now.Add(-10*day), to, fetched).Return(nil).Times(1)
+			},
+			want: fetched,
+		},
+		{
+			name:    "refresh",
+			state:   map[string]time.Time{"from": now.Add(-40 * day), "to": now.Add(-10 * time.Second)},
+			from:    now.Add(-10 * day),
+			to:      now.Add(time.Hour),
+			refresh: true,
+			expect: func(repo *MockTransactionRepo, monoRepo *MockMonoRepo, to time.Time) {
+				monoRepo.EXPECT().GetTransactions("token", "account", now.Add(-10*day), to).Return(fetched, nil).Times(1)
+				repo.EXPECT().Replace("history_account", now.Add(-10*day), to, fetched).Return(nil).Times(1)
+				repo.EXPECT().SetTime("history_sync_account", "to", gomock.Any()).Return(nil).Times(1)
+			},
+			want: fetched,
+		},
+		{
+			name:  "out of history",
+			state: map[string]time.Time{"from": now.Add(-40 * day), "to": now.Add(-10 * time.Second)},
+			from:  now.Add(-50 * day),
+			to:    now.Add(-45 * day),
+			expect: func(repo *MockTransactionRepo, monoRepo *MockMonoRepo, to time.Time) {
+				monoRepo.EXPECT().GetTransactions("token", "account", now.Add(-50*day), to).Return(fetched, nil).Times(1)
+				repo.EXPECT().Replace("history_account", now.Add(-50*day), to, fetched).Return(nil).Times(1)
+			},
+			want: fetched,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			repo := NewMockTransactionRepo(mockCtrl)
+			repo.EXPECT().AddMember("history_users", userID.String()).Return(nil).Times(1)
+			repo.EXPECT().GetTimes("history_sync_account").Return(tt.state, nil).Times(1)
+
+			monoRepo := NewMockMonoRepo(mockCtrl)
+			tt.expect(repo, monoRepo, tt.to)
+
+			got, err := uc.NewHistory(repo, monoRepo, nil).Statement(userID, "token", "account", tt.from, tt.to, tt.refresh)
+			Ω(err).To(BeNil(), fmt.Sprintf(errDefaultMsg, err))
+			Ω(got).To(Equal(tt.want), errNotEqual)
+		})
+	}
+}
+
+func TestHistory_SyncCutStatement(t *testing.T) {
+	RegisterTestingT(t)
+	mockCtrl := gomock.NewController(t)
+	day := 24 * time.Hour
+	now := time.Date(2020, 9, 1, 12, 0, 0, 0, time.UTC)
+	userID := uuid.New()
+
+	// MonoBank returns the newest 500 transactions of the period, the older part of the period isn't replaced
+	oldest := now.Add(-10 * day)
+	transactions := make([]model.Transaction, 500)
+	for i := range transactions {
+		transactions[i] = model.Transaction{ID: fmt.Sprint(i), Time: int(now.Add(-time.Duration(i) * time.Minute).Unix())}
+	}
+	transactions[499].Time = int(oldest.Unix())
+
+	repo := NewMockTransactionRepo(mockCtrl)
+	repo.EXPECT().AddMember("history_users", userID.String()).Return(nil).Times(1)
+	repo.EXPECT().GetTimes("history_sync_account").Return(nil, nil).Times(1)
+	repo.EXPECT().Replace("history_account", oldest, now, transactions).Return(nil).Times(1)
+	repo.EXPECT().SetTime("history_sync_account", "from", now.Add(-31*day)).Return(nil).Times(1)
+	repo.EXPECT().SetTime("history_sync_account", "to", now).Return(nil).Times(1)
+
+	monoRepo := NewMockMonoRepo(mockCtrl)
+	monoRepo.EXPECT().GetTransactions("token", "account", now.Add(-31*day), now).Return(transactions, nil).Times(1)
+
+	_, err := uc.NewHistory(repo, monoRepo, nil).Sync(userID, "token", "account", now)
+	Ω(err).To(BeNil(), fmt.Sprintf(errDefaultMsg, err))
+}
@@ -0,0 +1,148 @@
+package usecases
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+// inlineFresh - how long the client info and the recent statement are reused by the inline queries:
+// the queries come while the user types and MonoBank allows one request per minute. The older entries
+// are removed from the cache when a new one is added.
+const inlineFresh = time.Minute
+
+// NewInline - builds the inline query use-case.
+func NewInline(clientInfoRepo ClientInfoRepo, transaction *Transaction) *Inline {
+	return &Inline{
+		clientInfoRepo: clientInfoRepo,
+		transaction:    transaction,
+		clientInfos:    make(map[string]cachedClientInfo),
+		statements:     make(map[statementKey]cachedStatement),
+	}
+}
+
+// Inline - represents the use-case answering the inline queries from the cached MonoBank data.
+type Inline struct {
+	clientInfoRepo ClientInfoRepo
+	transaction    *Transaction
+
+	mu          sync.Mutex
+	clientInfos map[string]cachedClientInfo
+	statements  map[statementKey]cachedStatement
+}
+
+// statementKey - the statement is cached by the token and the account: the account can be
+// requested with the tokens of different users.
+type statementKey struct {
+	token   string
+	account string
+}
+
+type cachedClientInfo struct {
+	info      model.ClientInfo
+	fetchedAt time.Time
+}
+
+type cachedStatement struct {
+	transactions []model.Transaction
+	from         time.Time
+	fetchedAt    time.Time
+}
+
+// ClientInfo - returns the client info, it is requested from MonoBank at most once a minute.
+func (i *Inline) ClientInfo(token string) (model.ClientInfo, error) {
+	now := time.Now()
+
+	i.mu.Lock()
+	cached, ok := i.clientInfos[token]
+	i.mu.Unlock()
+
+	if ok && now.Sub(cached.fetchedAt) < inlineFresh {
+		return cached.info, nil
+	}
+
+	info, err := i.clientInfoRepo.GetClientInfo(token)
+	if err != nil {
+		return model.ClientInfo{}, err
+	}
+
+	i.mu.Lock()
+	i.evictStale(now)
+	i.clientInfos[token] = cachedClientInfo{info: info, fetchedAt: now}
+	i.mu.Unlock()
+
+	return info, nil
+}
+
+// Search - returns the transactions of the period matching the query like Transaction.Search,
+// every transaction of the period if the text is empty. The statement of the last 31 days is requested
+// at most once a minute, the older periods are requested from the statement repository.
+func (i *Inline) Search(token, account string, userID uuid.UUID, q model.SearchQuery) (model.SearchResult, error) {
+	if q.Max > 0 && q.Min > q.Max {
+		return model.SearchResult{}, model.NewValidationError("min amount must not be greater than max amount")
+	}
+
+	now := time.Now().In(i.transaction.Location(userID))
+	if q.From.IsZero() || q.To.IsZero() {
+		q.From, q.To = now.Add(-searchPeriod), now
+	}
+
+	words := strings.Fields(strings.ToLower(q.Text))
+	transactions, err := i.statement(token, account, userID, q.From, q.To, now)
+	if err != nil {
+		return model.SearchResult{}, err
+	}
+
+	return i.transaction.searchResult(userID, transactions, q, words), nil
+}
+
+// statement - returns the transactions of the period, the cached statement of the last 31 days
+// if the period is in it.
+func (i *Inline) statement(token, account string, userID uuid.UUID, from, to, now time.Time) (
+	[]model.Transaction, error) {
+	if from.Before(now.Add(-searchPeriod)) {
+		return i.transaction.statementRepo.Statement(userID, token, account, from, to, false)
+	}
+
+	key := statementKey{token: token, account: account}
+	i.mu.Lock()
+	cached, ok := i.statements[key]
+	i.mu.Unlock()
+
+	if ok && now.Sub(cached.fetchedAt) < inlineFresh && !from.Before(cached.from) {
+		return cached.transactions, nil
+	}
+
+	recentFrom := now.Add(-searchPeriod)
+	transactions, err := i.transaction.statementRepo.Statement(userID, token, account, recentFrom, now, false)
+	if err != nil {
+		return nil, err
+	}
+
+	i.mu.Lock()
+	i.evictStale(now)
+	i.statements[key] = cachedStatement{transactions: transactions, from: recentFrom, fetchedAt: now}
+	i.mu.Unlock()
+
+	return transactions, nil
+}
+
+// evictStale - removes the client infos and the statements which aren't fresh at "now", so the cache
+// has only the users of the last minute. The caller must hold the lock.
+func (i *Inline) evictStale(now time.Time) {
+	for token, cached := range i.clientInfos {
+		if now.Sub(cached.fetchedAt) >= inlineFresh {
+			delete(i.clientInfos, token)
+		}
+	}
+
+	for key, cached := range i.statements {
+		if now.Sub(cached.fetchedAt) >= inlineFresh {
+			delete(i.statements, key)
+		}
+	}
+}
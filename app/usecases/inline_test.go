@@ -0,0 +1,75 @@
+package usecases_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	. "github.com/onsi/gomega"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+	uc "github.com/Kalachevskyi/mono-chat/app/usecases"
+)
+
+func TestInline_ClientInfo(t *testing.T) {
+	RegisterTestingT(t)
+	mockCtrl := gomock.NewController(t)
+
+	clientInfoRepo := NewMockClientInfoRepo(mockCtrl)
+	clientInfoRepo.EXPECT().GetClientInfo("token").Return(model.ClientInfo{Name: "Client"}, nil).Times(1)
+	clientInfoRepo.EXPECT().GetClientInfo("other").Return(model.ClientInfo{}, model.ErrRateLimited).Times(1)
+
+	inline := uc.NewInline(clientInfoRepo, nil)
+	for i := 0; i < 3; i++ {
+		info, err := inline.ClientInfo("token")
+		Ω(err).To(BeNil(), fmt.Sprintf(errDefaultMsg, err))
+		Ω(info.Name).To(Equal("Client"), errNotEqual)
+	}
+
+	_, err := inline.ClientInfo("other")
+	Ω(err).To(Equal(model.ErrRateLimited), errNotEqual)
+}
+
+func TestInline_Search(t *testing.T) {
+	RegisterTestingT(t)
+	mockCtrl := gomock.NewController(t)
+	loc, _ := time.LoadLocation("Europe/Kiev")
+	date := uc.NewDate(loc, nil)
+
+	userID := uuid.New()
+	current := time.Now().In(loc)
+	statementRepo := NewMockStatementRepo(mockCtrl)
+	statementRepo.EXPECT().Statement(userID, "token", "account", gomock.Any(), gomock.Any(), false).
+		Return([]model.Transaction{
+			{Time: int(current.Add(-time.Minute).Unix()), Mcc: 5814, Amount: -6500, Description: "Coffee House"},
+			{Time: int(current.Add(-48 * time.Hour).Unix()), Mcc: 5814, Amount: -7000, Description: "Coffee House"},
+			{Time: int(current.Add(-72 * time.Hour).Unix()), Mcc: 5411, Amount: -30000, Description: "Silpo"},
+		}, nil).Times(1)
+	statementRepo.EXPECT().Statement(userID, "other", "account", gomock.Any(), gomock.Any(), false).
+		Return(nil, nil).Times(1)
+
+	mappingRepo := NewMockMappingRepo(mockCtrl)
+	mappingRepo.EXPECT().Get(fmt.Sprintf("mapping_%s", userID)).Return(map[string]model.CategoryMapping{}, nil).
+		AnyTimes()
+
+	inline := uc.NewInline(nil, uc.NewTransaction(statementRepo, mappingRepo, nil, date))
+	got, err := inline.Search("token", "account", userID, model.SearchQuery{Text: "coffee"})
+	Ω(err).To(BeNil(), fmt.Sprintf(errDefaultMsg, err))
+	Ω(got.Transactions).To(HaveLen(2), errNotEqual)
+
+	from, to := current.Add(-24*time.Hour), current.Add(time.Hour)
+	got, err = inline.Search("token", "account", userID, model.SearchQuery{From: from, To: to})
+	Ω(err).To(BeNil(), fmt.Sprintf(errDefaultMsg, err))
+	Ω(got.Transactions).To(HaveLen(1), errNotEqual)
+	Ω(got.Transactions[0].Amount).To(Equal(int64(-6500)), errNotEqual)
+
+	// the statement cached with another token isn't shared
+	got, err = inline.Search("other", "account", userID, model.SearchQuery{Text: "coffee"})
+	Ω(err).To(BeNil(), fmt.Sprintf(errDefaultMsg, err))
+	Ω(got.Transactions).To(BeEmpty(), errNotEqual)
+
+	_, err = inline.Search("token", "account", userID, model.SearchQuery{Min: 200, Max: 100})
+	Ω(err).To(BeAssignableToTypeOf(model.ValidationError{}), errNotEqual)
+}
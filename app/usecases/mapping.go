@@ -4,6 +4,7 @@ import (
 	"encoding/csv"
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 
 	"github.com/google/uuid"
@@ -52,27 +53,54 @@ func (c *Mapping) Validate(name string) error {
 func (c *Mapping) Parse(userID uuid.UUID, r io.Reader) error {
 	lines, err := csv.NewReader(r).ReadAll()
 	if err != nil {
-		return errors.Errorf("can't read file: err=%s", err)
+		return model.NewValidationError("can't read file: err=%s", err)
 	}
 
-	mapping := make(map[string]model.CategoryMapping)
+	mapping := make([]model.CategoryMapping, 0, len(lines))
 	for _, line := range lines {
 		if len(line) != mappingLines {
-			return errors.New("mapping should have 3 column")
+			return model.NewValidationError("mapping should have 3 column")
 		}
 
-		categoryMapping := model.CategoryMapping{
+		mapping = append(mapping, model.CategoryMapping{
 			Mono:        line[0],
 			Description: line[1],
 			App:         line[2],
-		}
+		})
+	}
 
-		key := line[0] + line[1]
+	return c.Set(userID, mapping)
+}
 
-		mapping[key] = categoryMapping
+// Set - save category mapping in repository, replaces the previous one.
+func (c *Mapping) Set(userID uuid.UUID, mapping []model.CategoryMapping) error {
+	categoryMapping := make(map[string]model.CategoryMapping, len(mapping))
+	for _, m := range mapping {
+		categoryMapping[m.Mono+m.Description] = m
 	}
 
 	key := fmt.Sprintf("%s_%s", mappingKey, userID)
 
-	return c.mappingRepo.Set(key, mapping)
+	return c.mappingRepo.Set(key, categoryMapping)
+}
+
+// Get - returns category mapping sorted by MonoBank category and description.
+func (c *Mapping) Get(userID uuid.UUID) ([]model.CategoryMapping, error) {
+	key := fmt.Sprintf("%s_%s", mappingKey, userID)
+
+	categoryMapping, err := c.mappingRepo.Get(key)
+	if err != nil {
+		return nil, err
+	}
+
+	mapping := make([]model.CategoryMapping, 0, len(categoryMapping))
+	for _, m := range categoryMapping {
+		mapping = append(mapping, m)
+	}
+
+	sort.Slice(mapping, func(i, j int) bool {
+		return mapping[i].Mono+mapping[i].Description < mapping[j].Mono+mapping[j].Description
+	})
+
+	return mapping, nil
 }
@@ -11,6 +11,7 @@ import (
 	uc "github.com/Kalachevskyi/mono-chat/app/usecases"
 
 	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
 	. "github.com/onsi/gomega"
 )
 
@@ -25,7 +26,7 @@ func TestMapping_Parse(t *testing.T) {
 	}
 
 	type args struct {
-		chatID int64
+		userID uuid.UUID
 		r      func() io.Reader
 	}
 
@@ -43,7 +44,7 @@ func TestMapping_Parse(t *testing.T) {
 				},
 			},
 			args: args{
-				chatID: 0,
+				userID: uuid.Nil,
 				r: func() io.Reader {
 					data, err := ioutil.ReadFile("./testdata/mapping_file_error.json")
 					Ω(err).To(BeNil(), errNotEqual)
@@ -60,7 +61,7 @@ func TestMapping_Parse(t *testing.T) {
 				},
 			},
 			args: args{
-				chatID: 0,
+				userID: uuid.Nil,
 				r: func() io.Reader {
 					data, err := ioutil.ReadFile("./testdata/mapping_len_line_error.csv")
 					Ω(err).To(BeNil(), errNotEqual)
@@ -84,12 +85,12 @@ func TestMapping_Parse(t *testing.T) {
 						},
 					}
 					repo := NewMockMappingRepo(mockCtrl)
-					repo.EXPECT().Set("0_mapping", mapping)
+					repo.EXPECT().Set(fmt.Sprintf("mapping_%s", uuid.Nil), mapping)
 					return repo
 				},
 			},
 			args: args{
-				chatID: 0,
+				userID: uuid.Nil,
 				r: func() io.Reader {
 					data, err := ioutil.ReadFile("./testdata/mapping.csv")
 					Ω(err).To(BeNil(), errNotEqual)
@@ -102,7 +103,7 @@ func TestMapping_Parse(t *testing.T) {
 	for _, tt := range tests {
 		mappingRepo := tt.fields.mappingRepo()
 		m := uc.NewMapping(mappingRepo, nil)
-		err := m.Parse(tt.args.chatID, tt.args.r())
+		err := m.Parse(tt.args.userID, tt.args.r())
 		Ω(err != nil).To(Equal(tt.wantErr), errNotEqual)
 	}
 }
@@ -148,3 +149,37 @@ func TestNewMapping(t *testing.T) {
 	got := uc.NewMapping(nil, nil)
 	Ω(got).To(Equal(want), fmt.Sprintf(errDefaultMsg, got))
 }
+
+func TestMapping_Get(t *testing.T) {
+	RegisterTestingT(t)
+	mockCtrl := gomock.NewController(t)
+	userID := uuid.New()
+
+	repo := NewMockMappingRepo(mockCtrl)
+	repo.EXPECT().Get(fmt.Sprintf("mapping_%s", userID)).Return(map[string]model.CategoryMapping{
+		"7230":      {Mono: "7230", App: "Hair care"},
+		"4111Metro": {Mono: "4111", Description: "Metro", App: "Transport"},
+	}, nil).Times(1)
+
+	got, err := uc.NewMapping(repo, nil).Get(userID)
+	Ω(err).To(BeNil(), errNotEqual)
+	Ω(got).To(Equal([]model.CategoryMapping{
+		{Mono: "4111", Description: "Metro", App: "Transport"},
+		{Mono: "7230", App: "Hair care"},
+	}), fmt.Sprintf(errDefaultMsg, got))
+}
+
+func TestMapping_Set(t *testing.T) {
+	RegisterTestingT(t)
+	mockCtrl := gomock.NewController(t)
+	userID := uuid.New()
+
+	repo := NewMockMappingRepo(mockCtrl)
+	repo.EXPECT().Set(fmt.Sprintf("mapping_%s", userID), map[string]model.CategoryMapping{
+		"4111Metro": {Mono: "4111", Description: "Metro", App: "Transport"},
+	}).Return(nil).Times(1)
+
+	m := uc.NewMapping(repo, nil)
+	err := m.Set(userID, []model.CategoryMapping{{Mono: "4111", Description: "Metro", App: "Transport"}})
+	Ω(err).To(BeNil(), errNotEqual)
+}
@@ -10,6 +10,7 @@ import (
 
 	model "github.com/Kalachevskyi/mono-chat/app/model"
 	gomock "github.com/golang/mock/gomock"
+	uuid "github.com/google/uuid"
 )
 
 // MockLogger is a mock of Logger interface
@@ -84,3 +85,39 @@ func (m *MockMonoRepo) GetTransactions(token, account string, from, to time.Time
 func (mr *MockMonoRepoMockRecorder) GetTransactions(token, account, from, to interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTransactions", reflect.TypeOf((*MockMonoRepo)(nil).GetTransactions), token, account, from, to)
 }
+
+// MockStatementRepo is a mock of StatementRepo interface
+type MockStatementRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockStatementRepoMockRecorder
+}
+
+// MockStatementRepoMockRecorder is the mock recorder for MockStatementRepo
+type MockStatementRepoMockRecorder struct {
+	mock *MockStatementRepo
+}
+
+// NewMockStatementRepo creates a new mock instance
+func NewMockStatementRepo(ctrl *gomock.Controller) *MockStatementRepo {
+	mock := &MockStatementRepo{ctrl: ctrl}
+	mock.recorder = &MockStatementRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockStatementRepo) EXPECT() *MockStatementRepoMockRecorder {
+	return m.recorder
+}
+
+// Statement mocks base method
+func (m *MockStatementRepo) Statement(userID uuid.UUID, token, account string, from, to time.Time, refresh bool) ([]model.Transaction, error) {
+	ret := m.ctrl.Call(m, "Statement", userID, token, account, from, to, refresh)
+	ret0, _ := ret[0].([]model.Transaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Statement indicates an expected call of Statement
+func (mr *MockStatementRepoMockRecorder) Statement(userID, token, account, from, to, refresh interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Statement", reflect.TypeOf((*MockStatementRepo)(nil).Statement), userID, token, account, from, to, refresh)
+}
@@ -0,0 +1,54 @@
+package usecases
+
+import (
+	"strconv"
+
+	"github.com/pkg/errors"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+const offsetKey = "telegram_offset"
+
+//go:generate mockgen -destination=./offset_mock_test.go -package=usecases_test -source=./offset.go
+
+// OffsetRepo - represents Telegram offset repository interface.
+type OffsetRepo interface {
+	Set(key, val string) error
+	Get(key string) (string, error)
+}
+
+// NewOffset constructor for Offset.
+func NewOffset(repo OffsetRepo) *Offset {
+	return &Offset{repo: repo}
+}
+
+// Offset represents Telegram updates offset use-case, keeps the ID of the next update to process
+// so a restarted bot resumes where it stopped.
+type Offset struct {
+	repo OffsetRepo
+}
+
+// Get returns the saved offset, 0 if nothing is saved.
+func (o Offset) Get() (int, error) {
+	val, err := o.repo.Get(offsetKey)
+	if err == model.ErrNil {
+		return 0, nil
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	offset, err := strconv.Atoi(val)
+	if err != nil {
+		return 0, errors.Wrapf(err, "can't parse offset: %s", val)
+	}
+
+	return offset, nil
+}
+
+// Set saves the offset.
+func (o Offset) Set(offset int) error {
+	return o.repo.Set(offsetKey, strconv.Itoa(offset))
+}
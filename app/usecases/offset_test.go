@@ -0,0 +1,59 @@
+package usecases_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	. "github.com/onsi/gomega"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+	uc "github.com/Kalachevskyi/mono-chat/app/usecases"
+)
+
+func TestOffset_Get(t *testing.T) {
+	RegisterTestingT(t)
+	mockCtrl := gomock.NewController(t)
+
+	tests := []struct {
+		name    string
+		val     string
+		repoErr error
+		want    int
+		wantErr bool
+	}{
+		{name: "test-case1: saved offset", val: "734526", want: 734526},
+		{name: "test-case2: nothing is saved", repoErr: model.ErrNil, want: 0},
+		{name: "test-case3: repo error", repoErr: errors.New("connection refused"), wantErr: true},
+		{name: "test-case4: broken value", val: "abc", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewMockOffsetRepo(mockCtrl)
+			repo.EXPECT().Get("telegram_offset").Return(tt.val, tt.repoErr).Times(1)
+
+			got, err := uc.NewOffset(repo).Get()
+			if tt.wantErr {
+				Ω(err).NotTo(BeNil(), "expected error")
+
+				return
+			}
+
+			Ω(err).To(BeNil(), fmt.Sprintf(errDefaultMsg, err))
+			Ω(got).To(Equal(tt.want), fmt.Sprintf(errDefaultMsg, got))
+		})
+	}
+}
+
+func TestOffset_Set(t *testing.T) {
+	RegisterTestingT(t)
+	mockCtrl := gomock.NewController(t)
+
+	repo := NewMockOffsetRepo(mockCtrl)
+	repo.EXPECT().Set("telegram_offset", "734527").Return(nil).Times(1)
+
+	err := uc.NewOffset(repo).Set(734527)
+	Ω(err).To(BeNil(), fmt.Sprintf(errDefaultMsg, err))
+}
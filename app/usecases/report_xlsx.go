@@ -0,0 +1,110 @@
+package usecases
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"strconv"
+
+	"github.com/pkg/errors"
+)
+
+// xlsxReportParts - the static parts of the XLSX report, the workbook has the only sheet.
+var xlsxReportParts = []struct{ name, content string }{ //nolint:gochecknoglobals
+	{
+		name: "[Content_Types].xml",
+		content: `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+			`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+			`<Default Extension="xml" ContentType="application/xml"/>` +
+			`<Override PartName="/xl/workbook.xml" ` +
+			`ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+			`<Override PartName="/xl/worksheets/sheet1.xml" ` +
+			`ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+			`</Types>`,
+	},
+	{
+		name: "_rels/.rels",
+		content: `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Target="xl/workbook.xml" ` +
+			`Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument"/>` +
+			`</Relationships>`,
+	},
+	{
+		name: "xl/workbook.xml",
+		content: `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+			`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets><sheet name="Report" sheetId="1" r:id="rId1"/></sheets></workbook>`,
+	},
+	{
+		name: "xl/_rels/workbook.xml.rels",
+		content: `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="` + xlsxSheetRel + `" Target="worksheets/sheet1.xml"/>` +
+			`</Relationships>`,
+	},
+}
+
+// writeXLSX - writes the records to the sheet of the XLSX workbook, the cells of the "numeric" columns
+// are written as the numbers if they are parsed, the other ones as the text.
+func writeXLSX(records [][]string, numeric ...int) (io.Reader, error) {
+	buf := &bytes.Buffer{}
+	archive := zip.NewWriter(buf)
+	for _, part := range xlsxReportParts {
+		w, err := archive.Create(part.name)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+
+		if _, err := io.WriteString(w, part.content); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+
+	w, err := archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	if err := writeXLSXSheet(w, records, numeric); err != nil {
+		return nil, err
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	return buf, nil
+}
+
+// writeXLSXSheet - writes the worksheet part, the text is written as the inline strings.
+func writeXLSXSheet(w io.Writer, records [][]string, numeric []int) error {
+	isNumeric := make(map[int]bool, len(numeric))
+	for _, column := range numeric {
+		isNumeric[column] = true
+	}
+
+	sheet := &bytes.Buffer{}
+	sheet.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	for i, record := range records {
+		sheet.WriteString(`<row r="` + strconv.Itoa(i+1) + `">`)
+		for column, value := range record {
+			if _, err := strconv.ParseFloat(value, 64); err == nil && i > 0 && isNumeric[column] {
+				sheet.WriteString(`<c><v>` + value + `</v></c>`)
+
+				continue
+			}
+
+			sheet.WriteString(`<c t="inlineStr"><is><t xml:space="preserve">`)
+			if err := xml.EscapeText(sheet, []byte(value)); err != nil {
+				return errors.WithStack(err)
+			}
+			sheet.WriteString(`</t></is></c>`)
+		}
+		sheet.WriteString(`</row>`)
+	}
+	sheet.WriteString(`</sheetData></worksheet>`)
+
+	_, err := sheet.WriteTo(w)
+
+	return errors.WithStack(err)
+}
@@ -0,0 +1,271 @@
+package usecases
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+const (
+	scheduleKey        = "schedule"
+	scheduleRunKey     = "schedule_run"
+	scheduleUsersKey   = "schedule_users"
+	scheduleTimeFormat = "15:04"
+	maxSchedules       = 10
+)
+
+//go:generate mockgen -destination=./schedule_mock_test.go -package=usecases_test -source=./schedule.go
+
+// ScheduleRepo - represents report schedules repository interface.
+type ScheduleRepo interface {
+	Set(key string, val model.Schedule) error
+	GetAll(key string) ([]model.Schedule, error)
+	Delete(key, id string) error
+	SetTime(key, id string, t time.Time) error
+	GetTimes(key string) (map[string]time.Time, error)
+	AddMember(key, member string) error
+	RemoveMember(key, member string) error
+	Members(key string) ([]string, error)
+}
+
+// NewSchedule - builds schedule use-case, the schedules run in the user's current time zone,
+// "loc" is used if the user has no settings.
+func NewSchedule(repo ScheduleRepo, loc *time.Location, settings UserSettings, log Logger) *Schedule {
+	return &Schedule{repo: repo, loc: loc, settings: settings, log: log}
+}
+
+// Schedule - represents report schedules use-case.
+type Schedule struct {
+	repo     ScheduleRepo
+	loc      *time.Location
+	settings UserSettings
+	log      Logger
+}
+
+// Parse - parses the schedule from the arguments:
+// "daily 21:00 [format]", "weekly mon 09:00 [format]" or "monthly 1 09:00 [format]",
+// the format is "csv" (by default), "xlsx" or "summary".
+func (s *Schedule) Parse(args string) (model.Schedule, error) {
+	fields := strings.Fields(strings.ToLower(args))
+	if len(fields) == 0 {
+		return model.Schedule{}, model.NewValidationError("period is missing")
+	}
+
+	schedule := model.Schedule{Period: fields[0], Format: model.ReportFormatCSV}
+	fields = fields[1:]
+
+	switch schedule.Period {
+	case model.PeriodDaily:
+	case model.PeriodWeekly:
+		if len(fields) == 0 {
+			return model.Schedule{}, model.NewValidationError("weekday is missing")
+		}
+
+		weekday, err := parseWeekday(fields[0])
+		if err != nil {
+			return model.Schedule{}, err
+		}
+		schedule.Day, fields = int(weekday), fields[1:]
+	case model.PeriodMonthly:
+		if len(fields) == 0 {
+			return model.Schedule{}, model.NewValidationError("day of month is missing")
+		}
+
+		day, err := strconv.Atoi(fields[0])
+		if err != nil || day < 1 || day > 31 {
+			return model.Schedule{}, model.NewValidationError("day of month must be from 1 to 31: %s", fields[0])
+		}
+		schedule.Day, fields = day, fields[1:]
+	default:
+		return model.Schedule{}, model.NewValidationError("unknown period: %s", schedule.Period)
+	}
+
+	if len(fields) == 0 {
+		return model.Schedule{}, model.NewValidationError("time is missing")
+	}
+
+	at, err := time.Parse(scheduleTimeFormat, fields[0])
+	if err != nil {
+		return model.Schedule{}, model.NewValidationError("time must be in the format HH:MM: %s", fields[0])
+	}
+	schedule.Hour, schedule.Minute, fields = at.Hour(), at.Minute(), fields[1:]
+
+	if len(fields) > 0 {
+		schedule.Format, fields = fields[0], fields[1:]
+	}
+
+	switch schedule.Format {
+	case model.ReportFormatCSV, model.ReportFormatXLSX, model.ReportFormatSummary:
+	default:
+		return model.Schedule{}, model.NewValidationError("unknown report format: %s", schedule.Format)
+	}
+
+	if len(fields) > 0 {
+		return model.Schedule{}, model.NewValidationError("unexpected arguments: %s", strings.Join(fields, " "))
+	}
+
+	return schedule, nil
+}
+
+// Add - parses the schedule from the arguments and saves it for the user, the reports are sent to the chat.
+func (s *Schedule) Add(userID uuid.UUID, chatID int64, args string) (model.Schedule, error) {
+	schedule, err := s.Parse(args)
+	if err != nil {
+		return model.Schedule{}, err
+	}
+
+	schedules, err := s.List(userID)
+	if err != nil {
+		return model.Schedule{}, err
+	}
+
+	if len(schedules) >= maxSchedules {
+		return model.Schedule{}, model.NewValidationError("you can't have more than %d schedules", maxSchedules)
+	}
+
+	schedule.ID = strings.Split(uuid.New().String(), "-")[0]
+	schedule.ChatID = chatID
+	schedule.CreatedAt = time.Now()
+
+	if err := s.repo.Set(scheduleUserKey(userID), schedule); err != nil {
+		return model.Schedule{}, err
+	}
+
+	if err := s.repo.AddMember(scheduleUsersKey, userID.String()); err != nil {
+		return model.Schedule{}, err
+	}
+
+	return schedule, nil
+}
+
+// List - returns the user's schedules ordered by creation time.
+func (s *Schedule) List(userID uuid.UUID) ([]model.Schedule, error) {
+	schedules, err := s.repo.GetAll(scheduleUserKey(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(schedules, func(i, j int) bool {
+		return schedules[i].CreatedAt.Before(schedules[j].CreatedAt)
+	})
+
+	return schedules, nil
+}
+
+// Delete - removes the user's schedule, returns "model.ErrNil" if there is no schedule with the ID.
+func (s *Schedule) Delete(userID uuid.UUID, id string) error {
+	if err := s.repo.Delete(scheduleUserKey(userID), id); err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(scheduleRunUserKey(userID), id); err != nil && err != model.ErrNil {
+		return err
+	}
+
+	schedules, err := s.repo.GetAll(scheduleUserKey(userID))
+	if err != nil {
+		return err
+	}
+
+	if len(schedules) == 0 {
+		return s.repo.RemoveMember(scheduleUsersKey, userID.String())
+	}
+
+	return nil
+}
+
+// Location - returns the user's time zone the schedules run in.
+func (s *Schedule) Location(userID uuid.UUID) *time.Location {
+	return userLocation(s.settings, s.loc, userID)
+}
+
+// Due - returns the runs due at "now". If several runs of a schedule were missed, e.g. the service
+// was stopped, only the latest of them is returned.
+func (s *Schedule) Due(now time.Time) ([]model.ScheduledRun, error) {
+	users, err := s.repo.Members(scheduleUsersKey)
+	if err != nil {
+		return nil, err
+	}
+
+	runs := make([]model.ScheduledRun, 0)
+	for _, user := range users {
+		userID, err := uuid.Parse(user)
+		if err != nil {
+			s.log.Error(errors.Wrapf(err, "can't parse schedule user: %s", user))
+
+			continue
+		}
+
+		userRuns, err := s.due(userID, now)
+		if err != nil {
+			return nil, err
+		}
+		runs = append(runs, userRuns...)
+	}
+
+	return runs, nil
+}
+
+// Done - saves the time of the run, the next run is calculated from it.
+func (s *Schedule) Done(run model.ScheduledRun) error {
+	return s.repo.SetTime(scheduleRunUserKey(run.UserID), run.Schedule.ID, run.At)
+}
+
+func (s *Schedule) due(userID uuid.UUID, now time.Time) ([]model.ScheduledRun, error) {
+	schedules, err := s.repo.GetAll(scheduleUserKey(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	lastRuns, err := s.repo.GetTimes(scheduleRunUserKey(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	// the time zone is resolved on every check, so the schedules follow the changes of the user's settings
+	loc := s.Location(userID)
+	runs := make([]model.ScheduledRun, 0)
+	for _, schedule := range schedules {
+		lastRun, ok := lastRuns[schedule.ID]
+		if !ok {
+			lastRun = schedule.CreatedAt
+		}
+
+		var at time.Time
+		for next := schedule.Next(lastRun.In(loc)); !next.IsZero() && !next.After(now); next = schedule.Next(next) {
+			at = next
+		}
+
+		if !at.IsZero() {
+			runs = append(runs, model.ScheduledRun{UserID: userID, Schedule: schedule, At: at})
+		}
+	}
+
+	return runs, nil
+}
+
+func parseWeekday(val string) (time.Weekday, error) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		name := strings.ToLower(d.String())
+		if val == name || val == name[:3] {
+			return d, nil
+		}
+	}
+
+	return 0, model.NewValidationError("unknown weekday: %s", val)
+}
+
+func scheduleUserKey(userID uuid.UUID) string {
+	return fmt.Sprintf("%s_%v", scheduleKey, userID)
+}
+
+func scheduleRunUserKey(userID uuid.UUID) string {
+	return fmt.Sprintf("%s_%v", scheduleRunKey, userID)
+}
@@ -0,0 +1,193 @@
+package usecases_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	. "github.com/onsi/gomega"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+	uc "github.com/Kalachevskyi/mono-chat/app/usecases"
+)
+
+func TestSchedule_Parse(t *testing.T) {
+	RegisterTestingT(t)
+	s := uc.NewSchedule(nil, time.UTC, nil, nil)
+
+	tests := []struct {
+		args    string
+		want    model.Schedule
+		wantErr bool
+	}{
+		{args: "daily 21:00", want: model.Schedule{Period: model.PeriodDaily, Hour: 21, Format: model.ReportFormatCSV}},
+		{args: "Weekly MON 09:30 csv", want: model.Schedule{
+			Period: model.PeriodWeekly, Day: int(time.Monday), Hour: 9, Minute: 30, Format: model.ReportFormatCSV}},
+		{args: "monthly 31 09:00", want: model.Schedule{
+			Period: model.PeriodMonthly, Day: 31, Hour: 9, Format: model.ReportFormatCSV}},
+		{args: "monthly 1 09:00 xlsx", want: model.Schedule{
+			Period: model.PeriodMonthly, Day: 1, Hour: 9, Format: model.ReportFormatXLSX}},
+		{args: "", wantErr: true},
+		{args: "hourly 09:00", wantErr: true},
+		{args: "weekly 09:00", wantErr: true},
+		{args: "monthly 32 09:00", wantErr: true},
+		{args: "daily 25:00", wantErr: true},
+		{args: "daily 09:00 pdf", wantErr: true},
+		{args: "daily 09:00 csv now", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.args, func(t *testing.T) {
+			got, err := s.Parse(tt.args)
+			if tt.wantErr {
+				_, ok := err.(model.ValidationError)
+				Ω(ok).To(BeTrue(), fmt.Sprintf(errDefaultMsg, err))
+
+				return
+			}
+
+			Ω(err).To(BeNil(), fmt.Sprintf(errDefaultMsg, err))
+			Ω(got).To(Equal(tt.want), fmt.Sprintf(errDefaultMsg, got))
+		})
+	}
+}
+
+func TestSchedule_Due(t *testing.T) {
+	RegisterTestingT(t)
+	mockCtrl := gomock.NewController(t)
+	loc, err := time.LoadLocation("Europe/Kiev")
+	Ω(err).To(BeNil(), fmt.Sprintf(errDefaultMsg, err))
+
+	userID := uuid.New()
+	daily := model.Schedule{ID: "daily", Period: model.PeriodDaily, Hour: 21,
+		CreatedAt: time.Date(2020, 3, 1, 10, 0, 0, 0, loc)}
+	monthly := model.Schedule{ID: "monthly", Period: model.PeriodMonthly, Day: 31, Hour: 9,
+		CreatedAt: time.Date(2020, 1, 15, 10, 0, 0, 0, loc)}
+	weekly := model.Schedule{ID: "weekly", Period: model.PeriodWeekly, Day: int(time.Sunday), Hour: 9,
+		CreatedAt: time.Date(2020, 1, 15, 10, 0, 0, 0, loc)}
+
+	repo := NewMockScheduleRepo(mockCtrl)
+	repo.EXPECT().Members("schedule_users").Return([]string{userID.String()}, nil).Times(1)
+	repo.EXPECT().GetAll(fmt.Sprintf("schedule_%v", userID)).Return([]model.Schedule{daily, monthly, weekly}, nil).Times(1)
+	repo.EXPECT().GetTimes(fmt.Sprintf("schedule_run_%v", userID)).Return(map[string]time.Time{
+		"monthly": time.Date(2020, 2, 29, 9, 0, 0, 0, loc),
+		"weekly":  time.Date(2020, 3, 8, 9, 0, 0, 0, loc),
+	}, nil).Times(1)
+
+	// The service was stopped from March 2 to March 4, the daily runs are caught up once.
+	now := time.Date(2020, 3, 4, 12, 0, 0, 0, loc)
+	runs, err := uc.NewSchedule(repo, loc, nil, nil).Due(now)
+	Ω(err).To(BeNil(), fmt.Sprintf(errDefaultMsg, err))
+	Ω(runs).To(HaveLen(1), fmt.Sprintf(errDefaultMsg, runs))
+	Ω(runs[0].Schedule.ID).To(Equal("daily"), fmt.Sprintf(errDefaultMsg, runs[0]))
+	Ω(runs[0].At.Equal(time.Date(2020, 3, 3, 21, 0, 0, 0, loc))).To(BeTrue(), fmt.Sprintf(errDefaultMsg, runs[0].At))
+
+	Ω(monthly.Next(time.Date(2020, 2, 29, 9, 0, 0, 0, loc))).To(Equal(time.Date(2020, 3, 31, 9, 0, 0, 0, loc)),
+		errDefaultMsg)
+}
+
+func TestSchedule_Range(t *testing.T) {
+	RegisterTestingT(t)
+	loc, err := time.LoadLocation("Europe/Kiev")
+	Ω(err).To(BeNil(), fmt.Sprintf(errDefaultMsg, err))
+
+	tests := []struct {
+		name     string
+		schedule model.Schedule
+		run      time.Time
+		from     time.Time
+		to       time.Time
+	}{
+		{
+			name:     "daily",
+			schedule: model.Schedule{Period: model.PeriodDaily, Hour: 21},
+			run:      time.Date(2020, 3, 4, 21, 0, 0, 0, loc),
+			from:     time.Date(2020, 3, 3, 0, 0, 0, 0, loc),
+			to:       time.Date(2020, 3, 3, 23, 59, 59, 999999999, loc),
+		},
+		{
+			name:     "daily after the clocks change",
+			schedule: model.Schedule{Period: model.PeriodDaily, Hour: 9},
+			run:      time.Date(2020, 3, 30, 9, 0, 0, 0, loc),
+			from:     time.Date(2020, 3, 29, 0, 0, 0, 0, loc),
+			to:       time.Date(2020, 3, 29, 23, 59, 59, 999999999, loc),
+		},
+		{
+			name:     "weekly on Sunday",
+			schedule: model.Schedule{Period: model.PeriodWeekly, Day: int(time.Sunday), Hour: 9},
+			run:      time.Date(2020, 3, 8, 9, 0, 0, 0, loc),
+			from:     time.Date(2020, 2, 24, 0, 0, 0, 0, loc),
+			to:       time.Date(2020, 3, 1, 23, 59, 59, 999999999, loc),
+		},
+		{
+			name:     "weekly on Monday",
+			schedule: model.Schedule{Period: model.PeriodWeekly, Day: int(time.Monday), Hour: 9},
+			run:      time.Date(2020, 3, 9, 9, 0, 0, 0, loc),
+			from:     time.Date(2020, 3, 2, 0, 0, 0, 0, loc),
+			to:       time.Date(2020, 3, 8, 23, 59, 59, 999999999, loc),
+		},
+		{
+			name:     "monthly",
+			schedule: model.Schedule{Period: model.PeriodMonthly, Day: 1, Hour: 9},
+			run:      time.Date(2020, 3, 1, 9, 0, 0, 0, loc),
+			from:     time.Date(2020, 2, 1, 0, 0, 0, 0, loc),
+			to:       time.Date(2020, 2, 29, 23, 59, 59, 999999999, loc),
+		},
+		{
+			name:     "monthly on the last day",
+			schedule: model.Schedule{Period: model.PeriodMonthly, Day: 31, Hour: 9},
+			run:      time.Date(2020, 3, 31, 9, 0, 0, 0, loc),
+			from:     time.Date(2020, 2, 1, 0, 0, 0, 0, loc),
+			to:       time.Date(2020, 2, 29, 23, 59, 59, 999999999, loc),
+		},
+		{
+			name:     "monthly in January",
+			schedule: model.Schedule{Period: model.PeriodMonthly, Day: 15, Hour: 9},
+			run:      time.Date(2021, 1, 15, 9, 0, 0, 0, loc),
+			from:     time.Date(2020, 12, 1, 0, 0, 0, 0, loc),
+			to:       time.Date(2020, 12, 31, 23, 59, 59, 999999999, loc),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to := tt.schedule.Range(tt.run)
+			Ω(from).To(Equal(tt.from), fmt.Sprintf(errDefaultMsg, from))
+			Ω(to).To(Equal(tt.to), fmt.Sprintf(errDefaultMsg, to))
+		})
+	}
+}
+
+func TestSchedule_DueUserLocation(t *testing.T) {
+	RegisterTestingT(t)
+	mockCtrl := gomock.NewController(t)
+	kiev, _ := time.LoadLocation("Europe/Kiev")
+	lisbon, _ := time.LoadLocation("Europe/Lisbon")
+
+	userID := uuid.New()
+	daily := model.Schedule{ID: "daily", Period: model.PeriodDaily, Hour: 21,
+		CreatedAt: time.Date(2020, 3, 1, 10, 0, 0, 0, kiev)}
+
+	repo := NewMockScheduleRepo(mockCtrl)
+	repo.EXPECT().Members("schedule_users").Return([]string{userID.String()}, nil).Times(2)
+	repo.EXPECT().GetAll(fmt.Sprintf("schedule_%v", userID)).Return([]model.Schedule{daily}, nil).Times(2)
+	repo.EXPECT().GetTimes(fmt.Sprintf("schedule_run_%v", userID)).Return(map[string]time.Time{
+		"daily": time.Date(2020, 3, 1, 21, 0, 0, 0, lisbon),
+	}, nil).Times(2)
+
+	// The user moved to Lisbon after the schedule was added, 21:00 in Lisbon is 23:00 in Kiev.
+	settings := NewMockUserSettings(mockCtrl)
+	settings.EXPECT().Location(userID).Return(lisbon).Times(2)
+	schedule := uc.NewSchedule(repo, kiev, settings, nil)
+
+	runs, err := schedule.Due(time.Date(2020, 3, 2, 21, 30, 0, 0, kiev))
+	Ω(err).To(BeNil(), fmt.Sprintf(errDefaultMsg, err))
+	Ω(runs).To(BeEmpty(), fmt.Sprintf(errDefaultMsg, runs))
+
+	runs, err = schedule.Due(time.Date(2020, 3, 2, 21, 0, 0, 0, lisbon))
+	Ω(err).To(BeNil(), fmt.Sprintf(errDefaultMsg, err))
+	Ω(runs).To(HaveLen(1), fmt.Sprintf(errDefaultMsg, runs))
+	Ω(runs[0].At.Equal(time.Date(2020, 3, 2, 21, 0, 0, 0, lisbon))).To(BeTrue(), fmt.Sprintf(errDefaultMsg, runs[0].At))
+}
@@ -0,0 +1,169 @@
+package usecases
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+// searchPeriod - the period searched if the query doesn't have one.
+const searchPeriod = 31 * timeDurationDay
+
+//nolint:gochecknoglobals
+var amountRangeRegexp = regexp.MustCompile(`^(\d+(?:[.,]\d{1,2})?)?\.\.(\d+(?:[.,]\d{1,2})?)?$`)
+
+// Search - returns the transactions of the period matching the query, the stored statement is used.
+// The last 31 days are searched if the query doesn't have the period.
+func (a *Transaction) Search(token, account string, userID uuid.UUID, q model.SearchQuery) (model.SearchResult, error) {
+	words := strings.Fields(strings.ToLower(q.Text))
+	if len(words) == 0 {
+		return model.SearchResult{}, model.NewValidationError("search text is empty")
+	}
+
+	if q.Max > 0 && q.Min > q.Max {
+		return model.SearchResult{}, model.NewValidationError("min amount must not be greater than max amount")
+	}
+
+	loc := a.Location(userID)
+	if q.From.IsZero() || q.To.IsZero() {
+		q.To = time.Now().In(loc)
+		q.From = q.To.Add(-searchPeriod)
+	}
+
+	transactions, err := a.statementRepo.Statement(userID, token, account, q.From, q.To, false)
+	if err != nil {
+		return model.SearchResult{}, err
+	}
+
+	return a.searchResult(userID, transactions, q, words), nil
+}
+
+// searchResult - returns the transactions of the query period matching the words and the amount range,
+// the latest first, every transaction matches if there are no words.
+func (a *Transaction) searchResult(userID uuid.UUID, transactions []model.Transaction, q model.SearchQuery,
+	words []string) model.SearchResult {
+	loc := a.Location(userID)
+	catMap := a.getCategoryMapping(userID)
+	result := model.SearchResult{SearchQuery: q, Transactions: make([]model.FoundTransaction, 0)}
+	for _, tr := range transactions {
+		trTime := time.Unix(int64(tr.Time), 0).In(loc)
+		if trTime.Before(q.From) || trTime.After(q.To) {
+			continue
+		}
+
+		amount := int64(math.Abs(float64(tr.Amount)))
+		if amount < q.Min || (q.Max > 0 && amount > q.Max) {
+			continue
+		}
+
+		category := a.reportCategory(catMap, tr)
+		if !matchWords(words, tr.Description, tr.Comment, tr.CounterName, category) {
+			continue
+		}
+
+		result.Transactions = append(result.Transactions, model.FoundTransaction{
+			Time:        trTime,
+			Description: tr.Description,
+			Comment:     tr.Comment,
+			CounterName: tr.CounterName,
+			Category:    category,
+			Mcc:         tr.Mcc,
+			Amount:      int64(tr.Amount),
+		})
+	}
+
+	sort.SliceStable(result.Transactions, func(i, j int) bool {
+		return result.Transactions[i].Time.After(result.Transactions[j].Time)
+	})
+
+	return result
+}
+
+// SearchReport - converts the found transactions to app csv report, the dates follow the user's locale.
+func (a *Transaction) SearchReport(userID uuid.UUID, result model.SearchResult) (io.Reader, error) {
+	locale := a.Locale(userID)
+	records := [][]string{
+		{
+			DateHeader.Str(),
+			DescriptionHeader.Str(),
+			CategoryHeader.Str(),
+			BankCategoryHeader.Str(),
+			AmountHeader.Str(),
+		},
+	}
+
+	for _, tr := range result.Transactions {
+		records = append(records, []string{
+			tr.Time.Format(locale.ReportDateTime),
+			strings.ReplaceAll(tr.Description, "\n", " "),
+			tr.Category,
+			strconv.Itoa(tr.Mcc),
+			fmt.Sprintf("%.2f", float64(tr.Amount)/accuracy),
+		})
+	}
+
+	buf := &bytes.Buffer{}
+	wr := csv.NewWriter(buf)
+
+	return a.writeRecords(buf, wr, records)
+}
+
+// ParseAmountRange - parses the amount range "min..max" in the currency units to minor units,
+// either bound can be omitted, e.g. "100..", "..250.50".
+func (a *Transaction) ParseAmountRange(s string) (min, max int64, err error) {
+	matches := amountRangeRegexp.FindStringSubmatch(s)
+	if matches == nil || (matches[1] == "" && matches[2] == "") {
+		return 0, 0, model.NewValidationError("amount range must look like 100..250.50: %s", s)
+	}
+
+	if min, err = parseAmount(matches[1]); err != nil {
+		return 0, 0, err
+	}
+
+	if max, err = parseAmount(matches[2]); err != nil {
+		return 0, 0, err
+	}
+
+	if max > 0 && min > max {
+		return 0, 0, model.NewValidationError("min amount must not be greater than max amount: %s", s)
+	}
+
+	return min, max, nil
+}
+
+// parseAmount - parses the non-negative amount in the currency units to minor units, empty is zero.
+func parseAmount(s string) (int64, error) {
+	if s == "" {
+		return 0, nil
+	}
+
+	amount, err := strconv.ParseFloat(strings.Replace(s, ",", ".", 1), 64)
+	if err != nil || amount < 0 || amount > math.MaxInt64/accuracy {
+		return 0, model.NewValidationError("invalid amount: %s", s)
+	}
+
+	return int64(math.Round(amount * accuracy)), nil
+}
+
+// matchWords - reports whether every word is in any of the fields ignoring case.
+func matchWords(words []string, fields ...string) bool {
+	text := strings.ToLower(strings.Join(fields, "\n"))
+	for _, word := range words {
+		if !strings.Contains(text, word) {
+			return false
+		}
+	}
+
+	return true
+}
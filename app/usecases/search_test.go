@@ -0,0 +1,73 @@
+package usecases_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	. "github.com/onsi/gomega"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+	uc "github.com/Kalachevskyi/mono-chat/app/usecases"
+)
+
+func TestTransaction_Search(t *testing.T) {
+	RegisterTestingT(t)
+	mockCtrl := gomock.NewController(t)
+	loc, _ := time.LoadLocation("Europe/Kiev")
+	date := uc.NewDate(loc, nil)
+
+	userID := uuid.New()
+	from := time.Date(2020, 3, 1, 0, 0, 0, 0, loc)
+	to := time.Date(2020, 3, 31, 23, 59, 59, 0, loc)
+	day := func(d int) int { return int(time.Date(2020, 3, d, 12, 0, 0, 0, loc).Unix()) }
+
+	statementRepo := NewMockStatementRepo(mockCtrl)
+	statementRepo.EXPECT().Statement(userID, "token", "account", from, to, false).Return([]model.Transaction{
+		{Time: day(2), Mcc: 742, Amount: -45000, Description: "Vet Clinic"},
+		{Time: day(3), Mcc: 4829, Amount: -30000, Description: "Transfer", Comment: "for the vet"},
+		{Time: day(4), Mcc: 742, Amount: -500000, Description: "Vet Clinic"},
+		{Time: day(5), Mcc: 5995, Amount: -20000, Description: "Zoo shop"},
+		{Time: day(6), Mcc: 5411, Amount: -10000, Description: "Silpo"},
+	}, nil).Times(1)
+
+	mappingRepo := NewMockMappingRepo(mockCtrl)
+	mappingRepo.EXPECT().Get(fmt.Sprintf("mapping_%s", userID)).Return(map[string]model.CategoryMapping{
+		"742":  {Mono: "742", App: "Pets"},
+		"5995": {Mono: "5995", App: "Pets"},
+	}, nil).Times(1)
+
+	tr := uc.NewTransaction(statementRepo, mappingRepo, nil, date)
+	got, err := tr.Search("token", "account", userID, model.SearchQuery{Text: "VET", From: from, To: to, Max: 100000})
+	Ω(err).To(BeNil(), fmt.Sprintf(errDefaultMsg, err))
+	Ω(got.Transactions).To(HaveLen(2), errNotEqual)
+	Ω(got.Transactions[0].Comment).To(Equal("for the vet"), errNotEqual)
+	Ω(got.Transactions[0].Category).To(Equal("4829"), errNotEqual)
+	Ω(got.Transactions[1].Category).To(Equal("Pets"), errNotEqual)
+	Ω(got.Transactions[1].Amount).To(Equal(int64(-45000)), errNotEqual)
+
+	_, err = tr.Search("token", "account", userID, model.SearchQuery{Text: " "})
+	Ω(err).To(BeAssignableToTypeOf(model.ValidationError{}), errNotEqual)
+}
+
+func TestTransaction_ParseAmountRange(t *testing.T) {
+	RegisterTestingT(t)
+	tr := uc.NewTransaction(nil, nil, nil, nil)
+
+	min, max, err := tr.ParseAmountRange("100..250,50")
+	Ω(err).To(BeNil(), fmt.Sprintf(errDefaultMsg, err))
+	Ω(min).To(Equal(int64(10000)), errNotEqual)
+	Ω(max).To(Equal(int64(25050)), errNotEqual)
+
+	min, max, err = tr.ParseAmountRange("..20")
+	Ω(err).To(BeNil(), fmt.Sprintf(errDefaultMsg, err))
+	Ω(min).To(Equal(int64(0)), errNotEqual)
+	Ω(max).To(Equal(int64(2000)), errNotEqual)
+
+	for _, s := range []string{"..", "100", "250..100", "a..b"} {
+		_, _, err = tr.ParseAmountRange(s)
+		Ω(err).NotTo(BeNil(), errNotEqual)
+	}
+}
@@ -0,0 +1,188 @@
+package usecases
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+const (
+	timezoneKey = "timezone"
+	localeKey   = "locale"
+	languageKey = "language"
+)
+
+//go:generate mockgen -destination=./settings_mock_test.go -package=usecases_test -source=./settings.go
+
+// SettingsRepo - represents the user settings repository interface.
+type SettingsRepo interface {
+	Set(key, val string) error
+	Get(key string) (string, error)
+}
+
+// UserSettings - represents the source of the users' time zones and locales.
+type UserSettings interface {
+	Location(userID uuid.UUID) *time.Location
+	Locale(userID uuid.UUID) model.Locale
+}
+
+// NewSettings - builds user settings use-case, "loc" is the time zone of the users who didn't choose one.
+func NewSettings(repo SettingsRepo, loc *time.Location, log Logger) *Settings {
+	return &Settings{repo: repo, loc: loc, log: log}
+}
+
+// Settings - represents the use-case of the user's time zone and locale.
+type Settings struct {
+	repo SettingsRepo
+	loc  *time.Location
+	log  Logger
+}
+
+// Get - returns the user's settings, the defaults if they aren't set.
+func (s *Settings) Get(userID uuid.UUID) model.Settings {
+	return model.Settings{Timezone: s.Location(userID).String(), Locale: s.Locale(userID).Tag}
+}
+
+// SetTimezone - saves the user's time zone, the IANA name, e.g. "Europe/Warsaw".
+func (s *Settings) SetTimezone(userID uuid.UUID, name string) (*time.Location, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || strings.EqualFold(name, "Local") {
+		return nil, model.NewValidationError("time zone must be an IANA name, e.g. Europe/Warsaw")
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, model.NewValidationError("unknown time zone %q, it must be an IANA name, e.g. Europe/Warsaw", name)
+	}
+
+	if err := s.repo.Set(timezoneUserKey(userID), loc.String()); err != nil {
+		return nil, err
+	}
+
+	return loc, nil
+}
+
+// SetLocale - saves the user's locale, one of model.Locales.
+func (s *Settings) SetLocale(userID uuid.UUID, tag string) (model.Locale, error) {
+	locale, ok := model.FindLocale(strings.TrimSpace(tag))
+	if !ok {
+		tags := make([]string, 0)
+		for _, l := range model.Locales() {
+			tags = append(tags, l.Tag)
+		}
+
+		return model.Locale{}, model.NewValidationError("unknown locale %q, the locales are: %s",
+			tag, strings.Join(tags, ", "))
+	}
+
+	if err := s.repo.Set(localeUserKey(userID), locale.Tag); err != nil {
+		return model.Locale{}, err
+	}
+
+	return locale, nil
+}
+
+// SetLanguage - saves the user's language of the bot messages, the IETF language tag, e.g. "uk" or "en-US".
+func (s *Settings) SetLanguage(userID uuid.UUID, tag string) (string, error) {
+	lang, ok := model.FindLanguage(tag)
+	if !ok {
+		return "", model.NewValidationError("unknown language %q, the languages are: %s, %s",
+			tag, model.LanguageUK, model.LanguageEN)
+	}
+
+	if err := s.repo.Set(languageUserKey(userID), lang); err != nil {
+		return "", err
+	}
+
+	return lang, nil
+}
+
+// Language - returns the user's language of the bot messages, model.ErrNil if it isn't chosen.
+func (s *Settings) Language(userID uuid.UUID) (string, error) {
+	lang, err := s.repo.Get(languageUserKey(userID))
+	if err != nil {
+		return "", err
+	}
+
+	if lang, ok := model.FindLanguage(lang); ok {
+		return lang, nil
+	}
+
+	return "", model.ErrNil
+}
+
+// Location - returns the user's time zone, the default one if it isn't set or can't be read.
+func (s *Settings) Location(userID uuid.UUID) *time.Location {
+	name, err := s.repo.Get(timezoneUserKey(userID))
+	if err == model.ErrNil {
+		return s.loc
+	}
+
+	if err != nil {
+		s.log.Error(err)
+
+		return s.loc
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		s.log.Error(errors.Wrapf(err, "can't load user time zone: %s", name))
+
+		return s.loc
+	}
+
+	return loc
+}
+
+// Locale - returns the user's locale, the default one if it isn't set or can't be read.
+func (s *Settings) Locale(userID uuid.UUID) model.Locale {
+	tag, err := s.repo.Get(localeUserKey(userID))
+	if err != nil && err != model.ErrNil {
+		s.log.Error(err)
+	}
+
+	if locale, ok := model.FindLocale(tag); ok {
+		return locale
+	}
+
+	locale, _ := model.FindLocale(model.DefaultLocale)
+
+	return locale
+}
+
+// userLocation - returns the user's time zone, "loc" if there are no settings.
+func userLocation(settings UserSettings, loc *time.Location, userID uuid.UUID) *time.Location {
+	if settings == nil {
+		return loc
+	}
+
+	return settings.Location(userID)
+}
+
+// userLocale - returns the user's locale, the default one if there are no settings.
+func userLocale(settings UserSettings, userID uuid.UUID) model.Locale {
+	if settings == nil {
+		locale, _ := model.FindLocale(model.DefaultLocale)
+
+		return locale
+	}
+
+	return settings.Locale(userID)
+}
+
+func timezoneUserKey(userID uuid.UUID) string {
+	return fmt.Sprintf("%s_%v", timezoneKey, userID)
+}
+
+func localeUserKey(userID uuid.UUID) string {
+	return fmt.Sprintf("%s_%v", localeKey, userID)
+}
+
+func languageUserKey(userID uuid.UUID) string {
+	return fmt.Sprintf("%s_%v", languageKey, userID)
+}
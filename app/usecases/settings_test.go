@@ -0,0 +1,126 @@
+package usecases_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+	uc "github.com/Kalachevskyi/mono-chat/app/usecases"
+)
+
+func TestSettings_SetTimezone(t *testing.T) {
+	RegisterTestingT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	userID := uuid.New()
+	key := fmt.Sprintf("timezone_%s", userID)
+	repo := NewMockSettingsRepo(mockCtrl)
+	repo.EXPECT().Set(key, "America/New_York").Return(nil).Times(1)
+
+	s := uc.NewSettings(repo, time.UTC, nil)
+	loc, err := s.SetTimezone(userID, " America/New_York ")
+	Ω(err).To(BeNil(), fmt.Sprintf(errDefaultMsg, err))
+	Ω(loc.String()).To(Equal("America/New_York"), errNotEqual)
+
+	for _, name := range []string{"", "Local", "Mars/Olympus"} {
+		_, err = s.SetTimezone(userID, name)
+		Ω(err).To(BeAssignableToTypeOf(model.ValidationError{}), errNotEqual)
+	}
+}
+
+func TestSettings_Location(t *testing.T) {
+	RegisterTestingT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	userID := uuid.New()
+	key := fmt.Sprintf("timezone_%s", userID)
+	repo := NewMockSettingsRepo(mockCtrl)
+	repo.EXPECT().Get(key).Return("Europe/Warsaw", nil).Times(1)
+	repo.EXPECT().Get(key).Return("", model.ErrNil).Times(1)
+	repo.EXPECT().Get(key).Return("", errors.New("some error")).Times(1)
+
+	logger := NewMockLogger(mockCtrl)
+	logger.EXPECT().Error(gomock.Any()).Times(1)
+
+	s := uc.NewSettings(repo, time.UTC, logger)
+	Ω(s.Location(userID).String()).To(Equal("Europe/Warsaw"), errNotEqual)
+	Ω(s.Location(userID)).To(Equal(time.UTC), errNotEqual)
+	Ω(s.Location(userID)).To(Equal(time.UTC), errNotEqual)
+}
+
+func TestSettings_Locale(t *testing.T) {
+	RegisterTestingT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	userID := uuid.New()
+	key := fmt.Sprintf("locale_%s", userID)
+	repo := NewMockSettingsRepo(mockCtrl)
+	repo.EXPECT().Set(key, "en-US").Return(nil).Times(1)
+	repo.EXPECT().Get(key).Return("en-US", nil).Times(1)
+	repo.EXPECT().Get(key).Return("", model.ErrNil).Times(1)
+
+	s := uc.NewSettings(repo, time.UTC, nil)
+	locale, err := s.SetLocale(userID, "EN-us")
+	Ω(err).To(BeNil(), fmt.Sprintf(errDefaultMsg, err))
+	Ω(locale.Tag).To(Equal("en-US"), errNotEqual)
+
+	_, err = s.SetLocale(userID, "xx")
+	Ω(err).To(BeAssignableToTypeOf(model.ValidationError{}), errNotEqual)
+
+	Ω(s.Locale(userID).Date).To(Equal("01/02/2006"), errNotEqual)
+	Ω(s.Locale(userID).Tag).To(Equal(model.DefaultLocale), errNotEqual)
+}
+
+func TestTransaction_ParseDateInUserLocation(t *testing.T) {
+	RegisterTestingT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	userID := uuid.New()
+	loc, _ := time.LoadLocation("America/New_York")
+	settings := NewMockUserSettings(mockCtrl)
+	settings.EXPECT().Location(userID).Return(loc).Times(1)
+
+	tr := uc.NewTransaction(nil, nil, nil, uc.NewDate(time.UTC, settings))
+	from, to, err := tr.ParseDate(userID, "01.03.2020-31.03.2020")
+	Ω(err).To(BeNil(), fmt.Sprintf(errDefaultMsg, err))
+	Ω(from).To(Equal(time.Date(2020, 3, 1, 0, 0, 0, 0, loc)), errNotEqual)
+	Ω(to.Location()).To(Equal(loc), errNotEqual)
+}
+
+func TestSettings_Language(t *testing.T) {
+	RegisterTestingT(t)
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	userID := uuid.New()
+	key := fmt.Sprintf("language_%s", userID)
+	repo := NewMockSettingsRepo(mockCtrl)
+	repo.EXPECT().Set(key, "en").Return(nil).Times(1)
+	repo.EXPECT().Get(key).Return("en", nil).Times(1)
+	repo.EXPECT().Get(key).Return("", model.ErrNil).Times(1)
+
+	s := uc.NewSettings(repo, time.UTC, nil)
+	lang, err := s.SetLanguage(userID, "en-US")
+	Ω(err).To(BeNil(), fmt.Sprintf(errDefaultMsg, err))
+	Ω(lang).To(Equal(model.LanguageEN), errNotEqual)
+
+	_, err = s.SetLanguage(userID, "ru")
+	Ω(err).To(BeAssignableToTypeOf(model.ValidationError{}), errNotEqual)
+
+	lang, err = s.Language(userID)
+	Ω(err).To(BeNil(), fmt.Sprintf(errDefaultMsg, err))
+	Ω(lang).To(Equal(model.LanguageEN), errNotEqual)
+
+	_, err = s.Language(userID)
+	Ω(err).To(Equal(model.ErrNil), errNotEqual)
+}
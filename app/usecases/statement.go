@@ -0,0 +1,252 @@
+package usecases
+
+import (
+	"bytes"
+	"encoding/csv"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"golang.org/x/text/encoding/charmap"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+// statementColumn - a column of the MonoBank statement used by the report.
+type statementColumn int
+
+// Columns of the MonoBank statement, the values are the indexes of the statement without the header:
+// both the 10-column layout and the newer 11-column one start with them.
+const (
+	dateColumn statementColumn = iota
+	descriptionColumn
+	mccColumn
+	amountColumn
+	statementColumns
+)
+
+// statementHeaders - the lowercase parts of the header names of the columns in Ukrainian and English,
+// e.g. "Дата i час операції", "Card currency amount, (UAH)".
+var statementHeaders = [statementColumns][]string{ //nolint:gochecknoglobals
+	dateColumn:        {"дата", "date"},
+	descriptionColumn: {"деталі", "опис", "description", "details"},
+	mccColumn:         {"mcc"},
+	amountColumn:      {"валюті картки", "card currency"},
+}
+
+// statementColumnNames - the names of the columns in the errors.
+var statementColumnNames = [statementColumns]string{ //nolint:gochecknoglobals
+	"date", "description", "MCC", "card currency amount",
+}
+
+// statementDatePatterns - the date formats of the statements of the different versions of the app.
+var statementDatePatterns = []string{ //nolint:gochecknoglobals
+	dateTimeReportPattern,
+	"02.01.2006 15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	time.RFC3339,
+}
+
+// excelEpoch - the day 0 of the Excel serial dates, see parseStatementDate.
+var excelEpoch = time.Date(1899, time.December, 30, 0, 0, 0, 0, time.UTC) //nolint:gochecknoglobals
+
+// statementDelimiters - the delimiters of the CSV statements.
+var statementDelimiters = []rune{',', ';', '\t'} //nolint:gochecknoglobals
+
+// statementRow - represents a transaction of the uploaded statement.
+type statementRow struct {
+	date        time.Time
+	description string
+	mcc         string
+	amount      string
+}
+
+// readCSVStatement - reads the CSV statement: UTF-8 with or without BOM or Windows-1251, comma, semicolon
+// or tab delimited, with or without the header row.
+func readCSVStatement(r io.Reader) ([]statementRow, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	data = decodeStatement(data)
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comma = detectDelimiter(data)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, model.NewValidationError("can't read file: err=%s", err)
+	}
+
+	return parseStatement(records)
+}
+
+// decodeStatement - removes the UTF-8 BOM, the text which isn't UTF-8 is decoded from Windows-1251.
+func decodeStatement(data []byte) []byte {
+	data = bytes.TrimPrefix(data, []byte("\ufeff"))
+	if utf8.Valid(data) {
+		return data
+	}
+
+	decoded, err := charmap.Windows1251.NewDecoder().Bytes(data)
+	if err != nil { // Windows-1251 decodes every byte
+		return data
+	}
+
+	return decoded
+}
+
+// detectDelimiter - returns the delimiter found most often outside the quotes of the first line, comma by default.
+func detectDelimiter(data []byte) rune {
+	counts := make(map[rune]int, len(statementDelimiters))
+	quoted := false
+	for _, c := range string(data) {
+		if c == '\n' && !quoted {
+			break
+		}
+
+		if c == '"' {
+			quoted = !quoted
+		}
+
+		if !quoted {
+			counts[c]++
+		}
+	}
+
+	delimiter := statementDelimiters[0]
+	for _, d := range statementDelimiters {
+		if counts[d] > counts[delimiter] {
+			delimiter = d
+		}
+	}
+
+	return delimiter
+}
+
+// parseStatement - converts the records to the rows, the columns are found by the header row
+// if the statement has it, the errors name the line of the statement.
+func parseStatement(records [][]string) ([]statementRow, error) {
+	columns := [statementColumns]int{0, 1, 2, 3}
+	first := 0
+	if len(records) > 0 && isStatementHeader(records[0]) {
+		var err error
+		if columns, err = headerColumns(records[0]); err != nil {
+			return nil, err
+		}
+		first = 1
+	}
+
+	minColumns := 0
+	for _, i := range columns {
+		if i+1 > minColumns {
+			minColumns = i + 1
+		}
+	}
+
+	rows := make([]statementRow, 0, len(records)-first)
+	for i := first; i < len(records); i++ {
+		record, line := records[i], i+1
+		if isBlankRecord(record) {
+			continue
+		}
+
+		if len(record) < minColumns {
+			return nil, model.NewValidationError("line %d: the statement must have at least %d columns, got %d",
+				line, minColumns, len(record))
+		}
+
+		date, err := parseStatementDate(record[columns[dateColumn]])
+		if err != nil {
+			return nil, model.NewValidationError("line %d: can't parse the date %q", line,
+				record[columns[dateColumn]])
+		}
+
+		rows = append(rows, statementRow{
+			date:        date,
+			description: strings.ReplaceAll(strings.TrimSpace(record[columns[descriptionColumn]]), "\n", " "),
+			mcc:         strings.TrimSpace(record[columns[mccColumn]]),
+			amount:      strings.TrimSpace(record[columns[amountColumn]]),
+		})
+	}
+
+	return rows, nil
+}
+
+// isBlankRecord - whether the record has no values, e.g. the empty rows of the sheets.
+func isBlankRecord(record []string) bool {
+	for _, cell := range record {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isStatementHeader - whether the record is the header row: the first cell isn't a date.
+func isStatementHeader(record []string) bool {
+	if len(record) == 0 {
+		return false
+	}
+
+	_, err := parseStatementDate(record[0])
+
+	return err != nil
+}
+
+// headerColumns - returns the indexes of the columns by the names of the header.
+func headerColumns(header []string) ([statementColumns]int, error) {
+	var columns [statementColumns]int
+	for column, names := range statementHeaders {
+		columns[column] = -1
+		for i, cell := range header {
+			if containsAny(strings.ToLower(cell), names) {
+				columns[column] = i
+
+				break
+			}
+		}
+
+		if columns[column] < 0 {
+			return columns, model.NewValidationError("line 1: the %s column isn't found in the header",
+				statementColumnNames[column])
+		}
+	}
+
+	return columns, nil
+}
+
+// parseStatementDate - parses the date of the statement in any of statementDatePatterns or the Excel serial date,
+// the number of the days since excelEpoch with the time as the fraction, e.g. "43893.5" is 03.03.2020 12:00:00.
+func parseStatementDate(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, pattern := range statementDatePatterns {
+		if date, err := time.Parse(pattern, s); err == nil {
+			return date, nil
+		}
+	}
+
+	if days, err := strconv.ParseFloat(s, 64); err == nil && days > 0 {
+		return excelEpoch.Add(time.Duration(days * float64(24*time.Hour))).Round(time.Second), nil
+	}
+
+	return time.Time{}, errors.Errorf("unknown date format: %s", s)
+}
+
+func containsAny(s string, parts []string) bool {
+	for _, p := range parts {
+		if strings.Contains(s, p) {
+			return true
+		}
+	}
+
+	return false
+}
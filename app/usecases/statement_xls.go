@@ -0,0 +1,244 @@
+package usecases
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"io"
+	"io/ioutil"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/extrame/xls"
+	"github.com/pkg/errors"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+const (
+	// xlsMaxRows - the limit of the rows of the XLS statement.
+	xlsMaxRows = 1 << 16
+	// xlsxMaxColumns - the number of the columns of the XLSX sheet, the last one is "XFD".
+	xlsxMaxColumns = 16384
+	// xlsxMaxPartSize - the limit of the decompressed size of a part of the XLSX workbook.
+	xlsxMaxPartSize = 64 << 20
+	// xlsxSheetRel - the relationship type of the worksheets of the XLSX workbook.
+	xlsxSheetRel = "http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet"
+)
+
+// readXLSStatement - reads the statement of the first sheet of the XLS (Excel 97-2003) workbook.
+func readXLSStatement(r io.Reader) (rows []statementRow, err error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	defer func() { // the XLS parser panics on the broken files
+		if p := recover(); p != nil {
+			rows, err = nil, model.NewValidationError("can't read file: err=%s", p)
+		}
+	}()
+
+	wb, err := xls.OpenReader(bytes.NewReader(data), "utf-8")
+	if err != nil {
+		return nil, model.NewValidationError("can't read file: err=%s", err)
+	}
+
+	if wb == nil || wb.NumSheets() == 0 {
+		return nil, model.NewValidationError("can't read file: err=%s", "the workbook has no sheets")
+	}
+
+	sheet := wb.GetSheet(0)
+	records := make([][]string, 0, int(sheet.MaxRow)+1)
+	for _, record := range wb.ReadAllCells(xlsMaxRows) {
+		if len(records) > int(sheet.MaxRow) { // the rows of the other sheets
+			break
+		}
+		records = append(records, record)
+	}
+
+	return parseStatement(records)
+}
+
+// xlsxWorkbook - represents the sheets of "xl/workbook.xml".
+type xlsxWorkbook struct {
+	Sheets []struct {
+		ID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+// xlsxRelationships - represents "xl/_rels/workbook.xml.rels".
+type xlsxRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Type   string `xml:"Type,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+// xlsxSharedStrings - represents "xl/sharedStrings.xml", the rich text strings have several runs.
+type xlsxSharedStrings struct {
+	Items []struct {
+		Text string   `xml:"t"`
+		Runs []string `xml:"r>t"`
+	} `xml:"si"`
+}
+
+// xlsxSheet - represents the cells of a worksheet.
+type xlsxSheet struct {
+	Rows []struct {
+		Cells []struct {
+			Ref    string `xml:"r,attr"`
+			Type   string `xml:"t,attr"`
+			Value  string `xml:"v"`
+			Inline string `xml:"is>t"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// readXLSXStatement - reads the statement of the first sheet of the XLSX workbook, the dates can be
+// the text or the Excel serial numbers.
+func readXLSXStatement(r io.Reader) ([]statementRow, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, model.NewValidationError("can't read file: err=%s", err)
+	}
+
+	files := make(map[string]*zip.File, len(archive.File))
+	for _, f := range archive.File {
+		files[f.Name] = f
+	}
+
+	sheetName, err := xlsxFirstSheet(files)
+	if err != nil {
+		return nil, model.NewValidationError("can't read file: err=%s", err)
+	}
+
+	var sharedStrings xlsxSharedStrings
+	if _, ok := files["xl/sharedStrings.xml"]; ok {
+		if err := decodeXLSXPart(files, "xl/sharedStrings.xml", &sharedStrings); err != nil {
+			return nil, model.NewValidationError("can't read file: err=%s", err)
+		}
+	}
+
+	strs := make([]string, 0, len(sharedStrings.Items))
+	for _, item := range sharedStrings.Items {
+		strs = append(strs, item.Text+strings.Join(item.Runs, ""))
+	}
+
+	var sheet xlsxSheet
+	if err := decodeXLSXPart(files, sheetName, &sheet); err != nil {
+		return nil, model.NewValidationError("can't read file: err=%s", err)
+	}
+
+	records := make([][]string, 0, len(sheet.Rows))
+	for _, row := range sheet.Rows {
+		var record []string
+		for i, cell := range row.Cells {
+			column, ok := xlsxColumn(cell.Ref, i)
+			if !ok {
+				return nil, model.NewValidationError("can't read file: err=%s", "invalid cell reference: "+cell.Ref)
+			}
+
+			for len(record) <= column {
+				record = append(record, "")
+			}
+
+			switch cell.Type {
+			case "s":
+				n, err := strconv.Atoi(cell.Value)
+				if err != nil || n < 0 || n >= len(strs) {
+					return nil, model.NewValidationError("can't read file: err=%s", "invalid shared string: "+cell.Ref)
+				}
+				record[column] = strs[n]
+			case "inlineStr":
+				record[column] = cell.Inline
+			default:
+				record[column] = cell.Value
+			}
+		}
+		records = append(records, record)
+	}
+
+	return parseStatement(records)
+}
+
+// xlsxFirstSheet - returns the name of the part of the first sheet of the workbook.
+func xlsxFirstSheet(files map[string]*zip.File) (string, error) {
+	var wb xlsxWorkbook
+	if err := decodeXLSXPart(files, "xl/workbook.xml", &wb); err != nil {
+		return "", err
+	}
+
+	var rels xlsxRelationships
+	if err := decodeXLSXPart(files, "xl/_rels/workbook.xml.rels", &rels); err != nil {
+		return "", err
+	}
+
+	if len(wb.Sheets) == 0 {
+		return "", errors.New("the workbook has no sheets")
+	}
+
+	for _, rel := range rels.Relationships {
+		if rel.ID != wb.Sheets[0].ID || rel.Type != xlsxSheetRel {
+			continue
+		}
+
+		if strings.HasPrefix(rel.Target, "/") {
+			return strings.TrimPrefix(rel.Target, "/"), nil
+		}
+
+		return path.Join("xl", rel.Target), nil
+	}
+
+	return "", errors.Errorf("the sheet isn't found: id=%s", wb.Sheets[0].ID)
+}
+
+// decodeXLSXPart - decodes the XML part of the workbook.
+func decodeXLSXPart(files map[string]*zip.File, name string, v interface{}) error {
+	f, ok := files[name]
+	if !ok {
+		return errors.Errorf("the part isn't found: %s", name)
+	}
+
+	rc, err := f.Open()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	// the part over the limit is cut and fails to decode
+	err = xml.NewDecoder(io.LimitReader(rc, xlsxMaxPartSize)).Decode(v)
+	if closeErr := rc.Close(); err == nil {
+		err = closeErr
+	}
+
+	return errors.Wrapf(err, "can't decode %s", name)
+}
+
+// xlsxColumn - returns the zero based column of the cell reference, e.g. "C12" is 2,
+// the position of the cell is used if the reference is missing. False if the column is after "XFD".
+func xlsxColumn(ref string, position int) (int, bool) {
+	column := 0
+	for _, c := range ref {
+		if c < 'A' || c > 'Z' {
+			break
+		}
+
+		column = column*26 + int(c-'A'+1)
+		if column > xlsxMaxColumns {
+			return 0, false
+		}
+	}
+
+	if column == 0 {
+		column = position + 1
+	}
+
+	return column - 1, column <= xlsxMaxColumns
+}
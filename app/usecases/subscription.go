@@ -0,0 +1,299 @@
+package usecases
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+const (
+	subscriptionChatsKey   = "subscription_chats"
+	subscriptionWarningKey = "subscription_warning"
+	// subscriptionMinCharges - the number of charges needed to detect a subscription.
+	subscriptionMinCharges = 3
+	// subscriptionAmountTolerance - the charges of a subscription differ from their median by 25% at most.
+	subscriptionAmountTolerance = 0.25
+	// subscriptionPriceChange - the last charge differing from the previous one by 3% is the price change,
+	// smaller differences are caused by the exchange rates.
+	subscriptionPriceChange = 0.03
+)
+
+// subscriptionPeriod - represents a period of the recurring charges.
+type subscriptionPeriod struct {
+	name      string
+	days      float64 // typical interval between the charges
+	tolerance float64 // max deviation of an interval, in days
+	perMonth  float64
+	grace     time.Duration // the charge is missed if it didn't happen during the grace period
+	next      func(time.Time) time.Time
+}
+
+//nolint:gochecknoglobals
+var subscriptionPeriods = []subscriptionPeriod{
+	{
+		name:      model.SubscriptionWeekly,
+		days:      7,
+		tolerance: 1.5,
+		perMonth:  52.0 / 12,
+		grace:     2 * timeDurationDay,
+		next:      func(t time.Time) time.Time { return t.AddDate(0, 0, 7) },
+	},
+	{
+		name:      model.SubscriptionMonthly,
+		days:      30.4,
+		tolerance: 4,
+		perMonth:  1,
+		grace:     5 * timeDurationDay,
+		next:      func(t time.Time) time.Time { return t.AddDate(0, 1, 0) },
+	},
+}
+
+//go:generate mockgen -destination=./subscription_mock_test.go -package=usecases_test -source=./subscription.go
+
+// SubscriptionRepo - represents subscriptions repository interface.
+type SubscriptionRepo interface {
+	SetField(key, field, val string) error
+	GetFields(key string) (map[string]string, error)
+	Delete(key, field string) error
+}
+
+// NewSubscription - builds subscriptions use-case.
+func NewSubscription(repo SubscriptionRepo, log Logger) *Subscription {
+	return &Subscription{repo: repo, log: log}
+}
+
+// Subscription - represents the use-case detecting recurring charges and warning about their changes.
+type Subscription struct {
+	repo SubscriptionRepo
+	log  Logger
+}
+
+// Detect - returns the recurring charges of the transactions: the same description and MCC,
+// a similar amount and a weekly or monthly interval. The subscriptions missed twice are considered
+// canceled. The subscriptions are ordered by monthly cost descending, the charge dates are in the "now" time zone.
+func (s *Subscription) Detect(transactions []model.Transaction, now time.Time) []model.Subscription {
+	groups := make(map[string][]model.Transaction)
+	for _, tr := range transactions {
+		if tr.Amount >= 0 || tr.Hold {
+			continue
+		}
+
+		key := subscriptionKey(tr)
+		groups[key] = append(groups[key], tr)
+	}
+
+	subscriptions := make([]model.Subscription, 0)
+	for key, charges := range groups {
+		sub, ok := s.detect(key, charges, now)
+		if ok {
+			subscriptions = append(subscriptions, sub)
+		}
+	}
+
+	sort.Slice(subscriptions, func(i, j int) bool {
+		si, sj := subscriptions[i], subscriptions[j]
+		if si.MonthlyCost == sj.MonthlyCost {
+			return si.Key < sj.Key
+		}
+
+		return si.MonthlyCost > sj.MonthlyCost
+	})
+
+	return subscriptions
+}
+
+func (s *Subscription) detect(key string, charges []model.Transaction, now time.Time) (model.Subscription, bool) {
+	if len(charges) < subscriptionMinCharges {
+		return model.Subscription{}, false
+	}
+
+	sort.Slice(charges, func(i, j int) bool { return charges[i].Time < charges[j].Time })
+
+	intervals := make([]float64, 0, len(charges)-1)
+	amounts := make([]float64, 0, len(charges))
+	for i, tr := range charges {
+		amounts = append(amounts, -float64(tr.Amount))
+		if i > 0 {
+			intervals = append(intervals, float64(tr.Time-charges[i-1].Time)/timeDurationDay.Seconds())
+		}
+	}
+
+	period, ok := matchPeriod(intervals)
+	if !ok {
+		return model.Subscription{}, false
+	}
+
+	amountMedian := median(amounts)
+	for _, amount := range amounts {
+		if math.Abs(amount-amountMedian) > amountMedian*subscriptionAmountTolerance {
+			return model.Subscription{}, false
+		}
+	}
+
+	last, previous := charges[len(charges)-1], charges[len(charges)-2]
+	sub := model.Subscription{
+		Key:            key,
+		Description:    last.Description,
+		Mcc:            last.Mcc,
+		Period:         period.name,
+		Amount:         -int64(last.Amount),
+		PreviousAmount: -int64(previous.Amount),
+		Charges:        len(charges),
+		LastCharge:     time.Unix(int64(last.Time), 0).In(now.Location()),
+	}
+	sub.MonthlyCost = int64(math.Round(float64(sub.Amount) * period.perMonth))
+	sub.NextCharge = period.next(sub.LastCharge)
+	sub.PriceChanged = math.Abs(float64(sub.Amount-sub.PreviousAmount)) >
+		float64(sub.PreviousAmount)*subscriptionPriceChange
+	sub.Missed = now.After(sub.NextCharge.Add(period.grace))
+
+	if now.After(period.next(sub.NextCharge).Add(period.grace)) {
+		return model.Subscription{}, false
+	}
+
+	return sub, true
+}
+
+// Watch - enables the warnings of the user's subscriptions, they are sent to the chat.
+func (s *Subscription) Watch(userID uuid.UUID, chatID int64) error {
+	return s.repo.SetField(subscriptionChatsKey, userID.String(), strconv.FormatInt(chatID, 10))
+}
+
+// Unwatch - disables the warnings of the user's subscriptions.
+func (s *Subscription) Unwatch(userID uuid.UUID) error {
+	if err := s.repo.Delete(subscriptionChatsKey, userID.String()); err != nil && err != model.ErrNil {
+		return err
+	}
+
+	return nil
+}
+
+// Watched - returns the chats of the users the warnings are enabled for.
+func (s *Subscription) Watched() (map[uuid.UUID]int64, error) {
+	fields, err := s.repo.GetFields(subscriptionChatsKey)
+	if err != nil {
+		return nil, err
+	}
+
+	chats := make(map[uuid.UUID]int64, len(fields))
+	for user, chat := range fields {
+		userID, err := uuid.Parse(user)
+		if err != nil {
+			s.log.Error(errors.Wrapf(err, "can't parse subscription user: %s", user))
+
+			continue
+		}
+
+		chatID, err := strconv.ParseInt(chat, 10, 64)
+		if err != nil {
+			s.log.Error(errors.Wrapf(err, "can't parse subscription chat: %s", chat))
+
+			continue
+		}
+		chats[userID] = chatID
+	}
+
+	return chats, nil
+}
+
+// Warnings - returns the warnings about the price changes and the missed charges not sent yet.
+func (s *Subscription) Warnings(userID uuid.UUID, subscriptions []model.Subscription) (
+	[]model.SubscriptionWarning, error) {
+	sent, err := s.repo.GetFields(subscriptionWarningUserKey(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	warnings := make([]model.SubscriptionWarning, 0)
+	for _, sub := range subscriptions {
+		if sub.PriceChanged {
+			warnings = appendWarning(warnings, sent, model.WarningPriceChanged, sub)
+		}
+
+		if sub.Missed {
+			warnings = appendWarning(warnings, sent, model.WarningMissed, sub)
+		}
+	}
+
+	return warnings, nil
+}
+
+// WarningSent - saves the warning as sent, it is sent once per charge.
+func (s *Subscription) WarningSent(userID uuid.UUID, w model.SubscriptionWarning) error {
+	return s.repo.SetField(subscriptionWarningUserKey(userID), warningField(w.Kind, w.Subscription),
+		warningValue(w.Kind, w.Subscription))
+}
+
+// appendWarning - appends the warning unless it is sent already.
+func appendWarning(warnings []model.SubscriptionWarning, sent map[string]string, kind string,
+	sub model.Subscription) []model.SubscriptionWarning {
+	if sent[warningField(kind, sub)] == warningValue(kind, sub) {
+		return warnings
+	}
+
+	return append(warnings, model.SubscriptionWarning{Kind: kind, Subscription: sub})
+}
+
+// matchPeriod - returns the period all the intervals match.
+func matchPeriod(intervals []float64) (subscriptionPeriod, bool) {
+	typical := median(intervals)
+	for _, period := range subscriptionPeriods {
+		if math.Abs(typical-period.days) > period.tolerance {
+			continue
+		}
+
+		for _, interval := range intervals {
+			if math.Abs(interval-period.days) > period.tolerance {
+				return subscriptionPeriod{}, false
+			}
+		}
+
+		return period, true
+	}
+
+	return subscriptionPeriod{}, false
+}
+
+func median(vals []float64) float64 {
+	sorted := append([]float64(nil), vals...)
+	sort.Float64s(sorted)
+
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
+
+// subscriptionKey - returns the key of the charge: the description ignoring case and spaces, and the MCC.
+func subscriptionKey(tr model.Transaction) string {
+	description := strings.Join(strings.Fields(strings.ToLower(tr.Description)), " ")
+
+	return fmt.Sprintf("%s|%d", description, tr.Mcc)
+}
+
+func warningField(kind string, sub model.Subscription) string {
+	return kind + "|" + sub.Key
+}
+
+// warningValue - identifies the charge the warning is about: the changed one or the expected one.
+func warningValue(kind string, sub model.Subscription) string {
+	if kind == model.WarningMissed {
+		return strconv.FormatInt(sub.NextCharge.Unix(), 10)
+	}
+
+	return strconv.FormatInt(sub.LastCharge.Unix(), 10)
+}
+
+func subscriptionWarningUserKey(userID uuid.UUID) string {
+	return fmt.Sprintf("%s_%v", subscriptionWarningKey, userID)
+}
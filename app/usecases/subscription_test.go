@@ -0,0 +1,95 @@
+package usecases_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	. "github.com/onsi/gomega"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+	uc "github.com/Kalachevskyi/mono-chat/app/usecases"
+)
+
+func TestSubscription_Detect(t *testing.T) {
+	RegisterTestingT(t)
+	at := func(month time.Month, day int) int {
+		return int(time.Date(2020, month, day, 10, 0, 0, 0, time.UTC).Unix())
+	}
+
+	transactions := []model.Transaction{
+		// monthly, the price is changed
+		{Time: at(5, 3), Mcc: 4899, Amount: -19900, Description: "Netflix"},
+		{Time: at(6, 3), Mcc: 4899, Amount: -19900, Description: "NETFLIX"},
+		{Time: at(7, 2), Mcc: 4899, Amount: -19900, Description: "Netflix"},
+		{Time: at(8, 3), Mcc: 4899, Amount: -22900, Description: "Netflix"},
+		// weekly, the last charge is missed
+		{Time: at(7, 6), Mcc: 7997, Amount: -30000, Description: "Gym"},
+		{Time: at(7, 13), Mcc: 7997, Amount: -30000, Description: "Gym"},
+		{Time: at(7, 20), Mcc: 7997, Amount: -30000, Description: "Gym"},
+		{Time: at(7, 27), Mcc: 7997, Amount: -30000, Description: "Gym"},
+		{Time: at(8, 3), Mcc: 7997, Amount: -30000, Description: "Gym"},
+		// irregular
+		{Time: at(6, 1), Mcc: 5411, Amount: -10000, Description: "Silpo"},
+		{Time: at(6, 20), Mcc: 5411, Amount: -10000, Description: "Silpo"},
+		{Time: at(7, 1), Mcc: 5411, Amount: -10000, Description: "Silpo"},
+		// not enough charges
+		{Time: at(7, 15), Mcc: 4814, Amount: -5000, Description: "Kyivstar"},
+		{Time: at(8, 15), Mcc: 4814, Amount: -5000, Description: "Kyivstar"},
+		// income
+		{Time: at(6, 5), Mcc: 4829, Amount: 100000, Description: "Salary"},
+		{Time: at(7, 5), Mcc: 4829, Amount: 100000, Description: "Salary"},
+		{Time: at(8, 5), Mcc: 4829, Amount: 100000, Description: "Salary"},
+	}
+
+	s := uc.NewSubscription(nil, nil)
+	got := s.Detect(transactions, time.Date(2020, 8, 14, 10, 0, 0, 0, time.UTC))
+	Ω(got).To(HaveLen(2), errNotEqual)
+
+	gym := got[0]
+	Ω(gym.Period).To(Equal(model.SubscriptionWeekly), errNotEqual)
+	Ω(gym.MonthlyCost).To(Equal(int64(130000)), errNotEqual)
+	Ω(gym.NextCharge).To(Equal(time.Date(2020, 8, 10, 10, 0, 0, 0, time.UTC)), errNotEqual)
+	Ω(gym.Missed).To(BeTrue(), errNotEqual)
+	Ω(gym.PriceChanged).To(BeFalse(), errNotEqual)
+
+	netflix := got[1]
+	Ω(netflix.Key).To(Equal("netflix|4899"), errNotEqual)
+	Ω(netflix.Period).To(Equal(model.SubscriptionMonthly), errNotEqual)
+	Ω(netflix.Charges).To(Equal(4), errNotEqual)
+	Ω(netflix.Amount).To(Equal(int64(22900)), errNotEqual)
+	Ω(netflix.PreviousAmount).To(Equal(int64(19900)), errNotEqual)
+	Ω(netflix.PriceChanged).To(BeTrue(), errNotEqual)
+	Ω(netflix.NextCharge).To(Equal(time.Date(2020, 9, 3, 10, 0, 0, 0, time.UTC)), errNotEqual)
+	Ω(netflix.Missed).To(BeFalse(), errNotEqual)
+
+	// the subscription missed twice is canceled
+	Ω(s.Detect(transactions, time.Date(2020, 8, 30, 10, 0, 0, 0, time.UTC))).To(HaveLen(1), errNotEqual)
+}
+
+func TestSubscription_Warnings(t *testing.T) {
+	RegisterTestingT(t)
+	mockCtrl := gomock.NewController(t)
+	userID := uuid.New()
+	last := time.Date(2020, 8, 3, 10, 0, 0, 0, time.UTC)
+	next := last.AddDate(0, 1, 0)
+
+	repo := NewMockSubscriptionRepo(mockCtrl)
+	repo.EXPECT().GetFields(fmt.Sprintf("subscription_warning_%s", userID)).Return(map[string]string{
+		"price|netflix|4899": fmt.Sprint(last.Unix()),
+	}, nil).Times(1)
+
+	subscriptions := []model.Subscription{
+		{Key: "netflix|4899", PriceChanged: true, Missed: true, LastCharge: last, NextCharge: next},
+		{Key: "spotify|4899", PriceChanged: true, LastCharge: last, NextCharge: next},
+	}
+
+	got, err := uc.NewSubscription(repo, nil).Warnings(userID, subscriptions)
+	Ω(err).To(BeNil(), fmt.Sprintf(errDefaultMsg, err))
+	Ω(got).To(Equal([]model.SubscriptionWarning{
+		{Kind: model.WarningMissed, Subscription: subscriptions[0]},
+		{Kind: model.WarningPriceChanged, Subscription: subscriptions[1]},
+	}), errNotEqual)
+}
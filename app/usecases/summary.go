@@ -0,0 +1,212 @@
+package usecases
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jinzhu/now"
+	"github.com/pkg/errors"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+const (
+	summaryTopCategories    = 5
+	summaryBiggestPurchases = 3
+	// maxStatementRange - the longest period MonoBank returns the statement for in one request.
+	maxStatementRange = 31*timeDurationDay + time.Hour
+)
+
+// Summary - returns the spending summary of the period compared with the previous period: the calendar day,
+// week, month, quarter or year before the period if it's one of them, otherwise the period of the same length,
+// e.g. February for March and the 10 days before "01.03..10.03". The categories are mapped with the user's
+// category mapping. MonoBank allows one statement request per minute, so if both periods don't fit into
+// one request and the second one is rate limited, the summary is returned without the comparison.
+func (a *Transaction) Summary(token, account string, userID uuid.UUID, from, to time.Time) (model.Summary, error) {
+	prevFrom, prevTo := previousPeriod(from.In(a.Location(userID)), to)
+
+	var current, previous []model.Transaction
+	if to.Sub(prevFrom) <= maxStatementRange {
+		transactions, err := a.statementRepo.Statement(userID, token, account, prevFrom, to, false)
+		if err != nil {
+			return model.Summary{}, err
+		}
+
+		for _, tr := range transactions {
+			if int64(tr.Time) < from.Unix() {
+				previous = append(previous, tr)
+
+				continue
+			}
+			current = append(current, tr)
+		}
+	} else {
+		var err error
+		if current, err = a.statementRepo.Statement(userID, token, account, from, to, false); err != nil {
+			return model.Summary{}, err
+		}
+
+		previous, err = a.statementRepo.Statement(userID, token, account, prevFrom, prevTo, false)
+		if errors.Cause(err) == model.ErrRateLimited {
+			return a.summarize(userID, current, from, to), nil
+		}
+
+		if err != nil {
+			return model.Summary{}, err
+		}
+	}
+
+	summary := a.summarize(userID, current, from, to)
+	prevTotals := totals(previous, prevFrom, prevTo)
+	summary.Previous = &prevTotals
+
+	return summary, nil
+}
+
+func (a *Transaction) summarize(userID uuid.UUID, transactions []model.Transaction, from, to time.Time) model.Summary {
+	catMap := a.getCategoryMapping(userID)
+	loc := a.Location(userID)
+	summary := model.Summary{Totals: totals(transactions, from, to)}
+
+	byCategory := make(map[string]int64)
+	purchases := make([]model.Purchase, 0)
+	for _, tr := range transactions {
+		if tr.Amount >= 0 {
+			continue
+		}
+
+		category := a.expenseCategory(catMap, tr)
+		amount := -int64(tr.Amount)
+		byCategory[category] += amount
+		purchases = append(purchases, model.Purchase{
+			Time:        time.Unix(int64(tr.Time), 0).In(loc),
+			Description: tr.Description,
+			Category:    category,
+			Amount:      amount,
+		})
+	}
+
+	summary.TopCategories = categoryTotals(byCategory, summary.Expenses)
+	if len(summary.TopCategories) > summaryTopCategories {
+		summary.TopCategories = summary.TopCategories[:summaryTopCategories]
+	}
+
+	sort.SliceStable(purchases, func(i, j int) bool {
+		return purchases[i].Amount > purchases[j].Amount
+	})
+
+	if len(purchases) > summaryBiggestPurchases {
+		purchases = purchases[:summaryBiggestPurchases]
+	}
+	summary.BiggestPurchases = purchases
+
+	return summary
+}
+
+// previousPeriod - returns the period before "from": the calendar period of the same kind if the period is
+// a calendar one in the location of "from", otherwise the period of the same length.
+func previousPeriod(from, to time.Time) (prevFrom, prevTo time.Time) {
+	prevTo = from.Add(-time.Nanosecond)
+	if years, months, days, ok := calendarPeriod(from, to); ok {
+		return from.AddDate(-years, -months, -days), prevTo
+	}
+
+	return prevTo.Add(-to.Sub(from)), prevTo
+}
+
+// calendarPeriod - returns the length of the period if it's a day, a week from Monday, a month, a quarter or a year.
+func calendarPeriod(from, to time.Time) (years, months, days int, ok bool) {
+	if !from.Equal(now.New(from).BeginningOfDay()) {
+		return 0, 0, 0, false
+	}
+
+	_, month, day := from.Date()
+	switch {
+	case isPeriodEnd(from.AddDate(0, 0, 1), to):
+		return 0, 0, 1, true
+	case from.Weekday() == time.Monday && isPeriodEnd(from.AddDate(0, 0, 7), to):
+		return 0, 0, 7, true
+	case day == 1 && isPeriodEnd(from.AddDate(0, 1, 0), to):
+		return 0, 1, 0, true
+	case day == 1 && month%3 == 1 && isPeriodEnd(from.AddDate(0, 3, 0), to):
+		return 0, 3, 0, true
+	case day == 1 && month == time.January && isPeriodEnd(from.AddDate(1, 0, 0), to):
+		return 1, 0, 0, true
+	default:
+		return 0, 0, 0, false
+	}
+}
+
+// isPeriodEnd - whether "to" is the end of the period followed by "next", the end can be truncated to seconds.
+func isPeriodEnd(next, to time.Time) bool {
+	d := next.Sub(to)
+
+	return d > 0 && d <= time.Second
+}
+
+func totals(transactions []model.Transaction, from, to time.Time) model.Totals {
+	t := model.Totals{From: from, To: to}
+	for _, tr := range transactions {
+		if tr.Amount > 0 {
+			t.Income += int64(tr.Amount)
+
+			continue
+		}
+		t.Expenses -= int64(tr.Amount)
+	}
+
+	return t
+}
+
+// expenseCategory - returns the mapped category of the transaction, the MCC if it isn't mapped.
+func (a *Transaction) expenseCategory(catMap categoryMapping, tr model.Transaction) string {
+	category := strconv.Itoa(tr.Mcc)
+	if c, err := a.mapCategory(catMap, category, tr.Description); err == nil {
+		category = c
+	}
+
+	return category
+}
+
+// categoryTotals - returns the category totals sorted by amount descending.
+func categoryTotals(byCategory map[string]int64, expenses int64) []model.CategoryTotal {
+	categories := make([]model.CategoryTotal, 0, len(byCategory))
+	for category, amount := range byCategory {
+		categories = append(categories, model.CategoryTotal{
+			Category: category,
+			Amount:   amount,
+			Share:    float64(amount) / float64(expenses),
+		})
+	}
+
+	sort.Slice(categories, func(i, j int) bool {
+		ci, cj := categories[i], categories[j]
+		if ci.Amount == cj.Amount {
+			return ci.Category < cj.Category
+		}
+
+		return ci.Amount > cj.Amount
+	})
+
+	return categories
+}
+
+// Spending - returns the expenses of the period by mapped category, the same as in the reports.
+func (a *Transaction) Spending(token, account string, userID uuid.UUID, from, to time.Time) (map[string]int64, error) {
+	transactions, err := a.statementRepo.Statement(userID, token, account, from, to, false)
+	if err != nil {
+		return nil, err
+	}
+
+	catMap := a.getCategoryMapping(userID)
+	spending := make(map[string]int64)
+	for _, tr := range transactions {
+		if tr.Amount < 0 {
+			spending[a.expenseCategory(catMap, tr)] -= int64(tr.Amount)
+		}
+	}
+
+	return spending, nil
+}
@@ -0,0 +1,131 @@
+package usecases_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+	uc "github.com/Kalachevskyi/mono-chat/app/usecases"
+)
+
+func TestTransaction_Summary(t *testing.T) {
+	RegisterTestingT(t)
+	mockCtrl := gomock.NewController(t)
+	loc, _ := time.LoadLocation("Europe/Kiev")
+	date := uc.NewDate(loc, nil)
+
+	userID := uuid.New()
+	from := time.Date(2020, 3, 2, 0, 0, 0, 0, loc)
+	to := time.Date(2020, 3, 8, 23, 59, 59, 0, loc)
+	prevFrom := from.AddDate(0, 0, -7) // the previous calendar week
+	day := func(d int) int { return int(time.Date(2020, 3, d, 12, 0, 0, 0, loc).Unix()) }
+
+	transactions := []model.Transaction{
+		{Time: day(1), Mcc: 5411, Amount: -10000, Description: "Silpo"},     // previous week
+		{Time: day(1), Mcc: 4829, Amount: 50000, Description: "Salary"},     // previous week
+		{Time: day(2), Mcc: 5411, Amount: -20000, Description: "Silpo"},     // Food
+		{Time: day(3), Mcc: 5411, Amount: -5000, Description: "ATB"},        // Food
+		{Time: day(4), Mcc: 4121, Amount: -15000, Description: "Uklon"},     // Transport
+		{Time: day(5), Mcc: 5732, Amount: -60000, Description: "Rozetka"},   // not mapped
+		{Time: day(6), Mcc: 4829, Amount: 100000, Description: "Salary"},    // income
+		{Time: day(7), Mcc: 5814, Amount: -1000, Description: "McDonald's"}, // not mapped
+		{Time: day(8), Mcc: 5411, Amount: -4000, Description: "Novus"},      // Food
+	}
+
+	statementRepo := NewMockStatementRepo(mockCtrl)
+	statementRepo.EXPECT().Statement(userID, "token", "account", prevFrom, to, false).Return(transactions, nil).
+		Times(1)
+
+	mappingRepo := NewMockMappingRepo(mockCtrl)
+	mappingRepo.EXPECT().Get(fmt.Sprintf("mapping_%s", userID)).Return(map[string]model.CategoryMapping{
+		"5411": {Mono: "5411", App: "Food"},
+		"4121": {Mono: "4121", App: "Transport"},
+	}, nil).Times(1)
+
+	tr := uc.NewTransaction(statementRepo, mappingRepo, nil, date)
+	got, err := tr.Summary("token", "account", userID, from, to)
+	Ω(err).To(BeNil(), fmt.Sprintf(errDefaultMsg, err))
+
+	Ω(got.Totals).To(Equal(model.Totals{From: from, To: to, Income: 100000, Expenses: 105000}), errNotEqual)
+	Ω(got.Net()).To(Equal(int64(-5000)), errNotEqual)
+	Ω(got.TopCategories).To(HaveLen(4), errNotEqual)
+	Ω(got.TopCategories[0].Category).To(Equal("5732"), errNotEqual)
+	Ω(got.TopCategories[1]).To(Equal(model.CategoryTotal{Category: "Food", Amount: 29000, Share: 29000.0 / 105000}),
+		errNotEqual)
+	Ω(got.BiggestPurchases).To(HaveLen(3), errNotEqual)
+	Ω(got.BiggestPurchases[0].Description).To(Equal("Rozetka"), errNotEqual)
+	Ω(got.BiggestPurchases[1].Category).To(Equal("Food"), errNotEqual)
+	Ω(got.Previous).NotTo(BeNil(), errNotEqual)
+	Ω(got.Previous.Income).To(Equal(int64(50000)), errNotEqual)
+	Ω(got.Previous.Expenses).To(Equal(int64(10000)), errNotEqual)
+}
+
+func TestTransaction_SummaryRateLimited(t *testing.T) {
+	RegisterTestingT(t)
+	mockCtrl := gomock.NewController(t)
+	loc, _ := time.LoadLocation("Europe/Kiev")
+	date := uc.NewDate(loc, nil)
+
+	userID := uuid.New()
+	from := time.Date(2020, 3, 1, 0, 0, 0, 0, loc)
+	to := time.Date(2020, 3, 31, 23, 59, 59, 0, loc)
+
+	statementRepo := NewMockStatementRepo(mockCtrl)
+	statementRepo.EXPECT().Statement(userID, "token", "account", from, to, false).Return(nil, nil).Times(1)
+	statementRepo.EXPECT().Statement(userID, "token", "account", gomock.Any(), gomock.Any(), false).
+		Return(nil, errors.WithStack(model.ErrRateLimited)).Times(1)
+
+	mappingRepo := NewMockMappingRepo(mockCtrl)
+	mappingRepo.EXPECT().Get(fmt.Sprintf("mapping_%s", userID)).Return(nil, model.ErrNil).Times(1)
+
+	logger := NewMockLogger(mockCtrl)
+	logger.EXPECT().Error(gomock.Any()).Times(1)
+
+	tr := uc.NewTransaction(statementRepo, mappingRepo, logger, date)
+	got, err := tr.Summary("token", "account", userID, from, to)
+	Ω(err).To(BeNil(), fmt.Sprintf(errDefaultMsg, err))
+	Ω(got.Previous).To(BeNil(), errNotEqual)
+}
+
+func TestTransaction_SummaryPreviousPeriod(t *testing.T) {
+	RegisterTestingT(t)
+	mockCtrl := gomock.NewController(t)
+	loc, _ := time.LoadLocation("Europe/Kiev")
+	date := uc.NewDate(loc, nil)
+	userID := uuid.New()
+	day := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, loc) }
+
+	tests := []struct {
+		name     string
+		from, to time.Time
+		prevFrom time.Time
+	}{
+		{name: "month", from: day(2020, 3, 1), to: day(2020, 4, 1).Add(-time.Nanosecond), prevFrom: day(2020, 2, 1)},
+		{name: "quarter", from: day(2020, 4, 1), to: day(2020, 7, 1).Add(-time.Nanosecond), prevFrom: day(2020, 1, 1)},
+		{name: "year", from: day(2020, 1, 1), to: day(2021, 1, 1).Add(-time.Second), prevFrom: day(2019, 1, 1)},
+		{name: "arbitrary range", from: day(2020, 1, 15), to: day(2020, 2, 15).Add(-time.Nanosecond),
+			prevFrom: day(2019, 12, 15)},
+	}
+
+	for _, tt := range tests {
+		prevTo := tt.from.Add(-time.Nanosecond)
+		statementRepo := NewMockStatementRepo(mockCtrl)
+		statementRepo.EXPECT().Statement(userID, "token", "account", tt.from, tt.to, false).Return(nil, nil).Times(1)
+		statementRepo.EXPECT().Statement(userID, "token", "account", tt.prevFrom, prevTo, false).Return(nil, nil).
+			Times(1)
+
+		mappingRepo := NewMockMappingRepo(mockCtrl)
+		mappingRepo.EXPECT().Get(fmt.Sprintf("mapping_%s", userID)).Return(nil, nil).Times(1)
+
+		tr := uc.NewTransaction(statementRepo, mappingRepo, nil, date)
+		got, err := tr.Summary("token", "account", userID, tt.from, tt.to)
+		Ω(err).To(BeNil(), tt.name)
+		Ω(got.Previous).To(Equal(&model.Totals{From: tt.prevFrom, To: prevTo}), tt.name)
+	}
+}
@@ -2,8 +2,20 @@ package usecases
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/pkg/errors"
+
+	"github.com/Kalachevskyi/mono-chat/app/model"
+)
+
+const (
+	tokenKey         = "token"
+	tokenVerifiedKey = "token_verified"
+	tokenVisibleLen  = 4 // number of the token characters visible on each side of the mask
 )
 
 //go:generate mockgen -destination=./token_mock_test.go -package=usecases_test -source=./token.go
@@ -15,25 +27,81 @@ type TokenRepo interface {
 }
 
 // NewToken - builds Token report use-case.
-func NewToken(repo TokenRepo) *Token {
-	return &Token{repo: repo}
+func NewToken(repo TokenRepo, clientInfoRepo ClientInfoRepo) *Token {
+	return &Token{repo: repo, clientInfoRepo: clientInfoRepo}
 }
 
 // Token - represents Token use-case for processing token.
 type Token struct {
-	repo TokenRepo
+	repo           TokenRepo
+	clientInfoRepo ClientInfoRepo
 }
 
-// Set - save token by key.
-func (c *Token) Set(userID uuid.UUID, token string) error {
-	key := fmt.Sprintf("token_%v", userID)
+// Set - verify token by MonoBank client info, save it by key.
+func (c *Token) Set(userID uuid.UUID, token string) (model.ClientInfo, error) {
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return model.ClientInfo{}, model.NewValidationError("token can't be empty")
+	}
+
+	clientInfo, err := c.clientInfoRepo.GetClientInfo(token)
+	if err != nil {
+		return model.ClientInfo{}, err
+	}
 
-	return c.repo.Set(key, token)
+	if err := c.repo.Set(fmt.Sprintf("%s_%v", tokenKey, userID), token); err != nil {
+		return model.ClientInfo{}, err
+	}
+
+	verifiedAt := strconv.FormatInt(time.Now().Unix(), 10)
+	if err := c.repo.Set(fmt.Sprintf("%s_%v", tokenVerifiedKey, userID), verifiedAt); err != nil {
+		return model.ClientInfo{}, err
+	}
+
+	return clientInfo, nil
 }
 
 // Get - return token by key.
 func (c *Token) Get(userID uuid.UUID) (string, error) {
-	key := fmt.Sprintf("token_%v", userID)
+	key := fmt.Sprintf("%s_%v", tokenKey, userID)
 
 	return c.repo.Get(key)
 }
+
+// Status - returns masked token and the time of its last verification,
+// "VerifiedAt" is zero if the token was saved before verification was introduced.
+func (c *Token) Status(userID uuid.UUID) (model.TokenStatus, error) {
+	token, err := c.Get(userID)
+	if err != nil {
+		return model.TokenStatus{}, err
+	}
+
+	status := model.TokenStatus{Masked: maskToken(token)}
+
+	verifiedAt, err := c.repo.Get(fmt.Sprintf("%s_%v", tokenVerifiedKey, userID))
+	if err == model.ErrNil {
+		return status, nil
+	}
+
+	if err != nil {
+		return model.TokenStatus{}, err
+	}
+
+	unix, err := strconv.ParseInt(verifiedAt, 10, 64)
+	if err != nil {
+		return model.TokenStatus{}, errors.WithStack(err)
+	}
+	status.VerifiedAt = time.Unix(unix, 0)
+
+	return status, nil
+}
+
+// maskToken - hides the token except a few characters on each side.
+func maskToken(token string) string {
+	const mask = "****"
+	if len(token) <= 2*tokenVisibleLen {
+		return mask
+	}
+
+	return token[:tokenVisibleLen] + mask + token[len(token)-tokenVisibleLen:]
+}
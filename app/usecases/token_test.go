@@ -3,11 +3,15 @@ package usecases_test
 import (
 	"errors"
 	"fmt"
+	"strconv"
 	"testing"
+	"time"
 
+	"github.com/Kalachevskyi/mono-chat/app/model"
 	uc "github.com/Kalachevskyi/mono-chat/app/usecases"
 
 	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
 	. "github.com/onsi/gomega"
 )
 
@@ -16,7 +20,7 @@ const errDefaultMsg = `unexpected value "%v"`
 func TestNewToken(t *testing.T) {
 	RegisterTestingT(t)
 	want := &uc.Token{}
-	got := uc.NewToken(nil)
+	got := uc.NewToken(nil, nil)
 	Ω(got).To(Equal(want), fmt.Sprintf(errDefaultMsg, got))
 }
 
@@ -24,10 +28,10 @@ func TestToken_Get(t *testing.T) {
 	RegisterTestingT(t)
 	mockCtrl := gomock.NewController(t)
 	type fields struct {
-		repo func(chatID int64, want string) uc.TokenRepo
+		repo func(userID uuid.UUID, want string) uc.TokenRepo
 	}
 	type args struct {
-		chatID int64
+		userID uuid.UUID
 	}
 
 	tests := []struct {
@@ -40,34 +44,34 @@ func TestToken_Get(t *testing.T) {
 	}{
 		{
 			name: "test-case1: success execution",
-			fields: fields{repo: func(chatID int64, want string) uc.TokenRepo {
+			fields: fields{repo: func(userID uuid.UUID, want string) uc.TokenRepo {
 				repo := NewMockTokenRepo(mockCtrl)
-				key := fmt.Sprintf("token_%v", chatID)
+				key := fmt.Sprintf("token_%v", userID)
 				repo.EXPECT().Get(key).Return(want, nil).Times(1)
 				return repo
 			}},
-			args:    args{1},
+			args:    args{uuid.New()},
 			want:    "l1lms13d0vc8ks",
 			wantErr: false,
 		},
 		{
 			name: "test-case2: repo error",
-			fields: fields{repo: func(chatID int64, want string) uc.TokenRepo {
+			fields: fields{repo: func(userID uuid.UUID, want string) uc.TokenRepo {
 				repo := NewMockTokenRepo(mockCtrl)
-				key := fmt.Sprintf("token_%v", chatID)
+				key := fmt.Sprintf("token_%v", userID)
 				err := errors.New("some error")
 				repo.EXPECT().Get(key).Return("", err).Times(1)
 				return repo
 			}},
-			args:    args{1},
+			args:    args{uuid.New()},
 			want:    "",
 			wantErr: true,
 			err:     "some error",
 		},
 	}
 	for _, tt := range tests {
-		c := uc.NewToken(tt.fields.repo(tt.args.chatID, tt.want))
-		got, err := c.Get(tt.args.chatID)
+		c := uc.NewToken(tt.fields.repo(tt.args.userID, tt.want), nil)
+		got, err := c.Get(tt.args.userID)
 		if tt.wantErr {
 			Ω(err).NotTo(BeNil(), fmt.Sprintf(errDefaultMsg, err))
 			Ω(err.Error()).To(Equal(tt.err), fmt.Sprintf(errDefaultMsg, err.Error()))
@@ -80,10 +84,11 @@ func TestToken_Set(t *testing.T) {
 	RegisterTestingT(t)
 	mockCtrl := gomock.NewController(t)
 	type fields struct {
-		repo func(chatID int64, token string) uc.TokenRepo
+		repo           func(userID uuid.UUID, token string) uc.TokenRepo
+		clientInfoRepo func(token string) uc.ClientInfoRepo
 	}
 	type args struct {
-		chatID int64
+		userID uuid.UUID
 		token  string
 	}
 
@@ -91,41 +96,144 @@ func TestToken_Set(t *testing.T) {
 		name    string
 		fields  fields
 		args    args
+		want    model.ClientInfo
 		wantErr bool
 		err     string
 	}{
 		{
 			name: "test-case1: success execution",
-			fields: fields{repo: func(chatID int64, token string) uc.TokenRepo {
-				repo := NewMockTokenRepo(mockCtrl)
-				key := fmt.Sprintf("token_%v", chatID)
-				repo.EXPECT().Set(key, token).Return(nil).Times(1)
-				return repo
-			}},
-			args:    args{1, "l1lms13d0vc8ks"},
+			fields: fields{
+				repo: func(userID uuid.UUID, token string) uc.TokenRepo {
+					repo := NewMockTokenRepo(mockCtrl)
+					repo.EXPECT().Set(fmt.Sprintf("token_%v", userID), token).Return(nil).Times(1)
+					repo.EXPECT().Set(fmt.Sprintf("token_verified_%v", userID), gomock.Any()).Return(nil).Times(1)
+					return repo
+				},
+				clientInfoRepo: func(token string) uc.ClientInfoRepo {
+					repo := NewMockClientInfoRepo(mockCtrl)
+					repo.EXPECT().GetClientInfo(token).Return(model.ClientInfo{Name: "Іван"}, nil).Times(1)
+					return repo
+				},
+			},
+			args:    args{uuid.New(), "l1lms13d0vc8ks"},
+			want:    model.ClientInfo{Name: "Іван"},
 			wantErr: false,
 		},
 		{
 			name: "test-case2: repo error",
-			fields: fields{repo: func(chatID int64, token string) uc.TokenRepo {
-				repo := NewMockTokenRepo(mockCtrl)
-				key := fmt.Sprintf("token_%v", chatID)
-				err := errors.New("some error")
-				repo.EXPECT().Set(key, token).Return(err).Times(1)
-				return repo
-			}},
-			args:    args{1, "l1lms13d0vc8ks"},
+			fields: fields{
+				repo: func(userID uuid.UUID, token string) uc.TokenRepo {
+					repo := NewMockTokenRepo(mockCtrl)
+					key := fmt.Sprintf("token_%v", userID)
+					err := errors.New("some error")
+					repo.EXPECT().Set(key, token).Return(err).Times(1)
+					return repo
+				},
+				clientInfoRepo: func(token string) uc.ClientInfoRepo {
+					repo := NewMockClientInfoRepo(mockCtrl)
+					repo.EXPECT().GetClientInfo(token).Return(model.ClientInfo{}, nil).Times(1)
+					return repo
+				},
+			},
+			args:    args{uuid.New(), "l1lms13d0vc8ks"},
 			wantErr: true,
 			err:     "some error",
 		},
+		{
+			name: "test-case3: token rejected by MonoBank",
+			fields: fields{
+				repo: func(userID uuid.UUID, token string) uc.TokenRepo {
+					return NewMockTokenRepo(mockCtrl)
+				},
+				clientInfoRepo: func(token string) uc.ClientInfoRepo {
+					repo := NewMockClientInfoRepo(mockCtrl)
+					repo.EXPECT().GetClientInfo(token).Return(model.ClientInfo{}, model.ErrInvalidToken).Times(1)
+					return repo
+				},
+			},
+			args:    args{uuid.New(), "l1lms13d0vc8ks"},
+			wantErr: true,
+			err:     model.ErrInvalidToken.Error(),
+		},
+		{
+			name: "test-case4: empty token",
+			fields: fields{
+				repo: func(userID uuid.UUID, token string) uc.TokenRepo {
+					return NewMockTokenRepo(mockCtrl)
+				},
+				clientInfoRepo: func(token string) uc.ClientInfoRepo {
+					return NewMockClientInfoRepo(mockCtrl)
+				},
+			},
+			args:    args{uuid.New(), " "},
+			wantErr: true,
+			err:     "token can't be empty",
+		},
 	}
 	for _, tt := range tests {
-		tokeRepo := tt.fields.repo(tt.args.chatID, tt.args.token)
-		c := uc.NewToken(tokeRepo)
-		err := c.Set(tt.args.chatID, tt.args.token)
+		tokeRepo := tt.fields.repo(tt.args.userID, tt.args.token)
+		c := uc.NewToken(tokeRepo, tt.fields.clientInfoRepo(tt.args.token))
+		got, err := c.Set(tt.args.userID, tt.args.token)
 		if tt.wantErr {
 			Ω(err).NotTo(BeNil(), fmt.Sprintf(errDefaultMsg, err))
 			Ω(err.Error()).To(Equal(tt.err), fmt.Sprintf(errDefaultMsg, err.Error()))
+			continue
 		}
+		Ω(err).To(BeNil(), fmt.Sprintf(errDefaultMsg, err))
+		Ω(got).To(Equal(tt.want), fmt.Sprintf(errDefaultMsg, got))
+	}
+}
+
+func TestToken_Status(t *testing.T) {
+	RegisterTestingT(t)
+	mockCtrl := gomock.NewController(t)
+	verifiedAt := time.Unix(1554466347, 0)
+	type fields struct {
+		repo func(userID uuid.UUID) uc.TokenRepo
+	}
+
+	tests := []struct {
+		name    string
+		fields  fields
+		want    model.TokenStatus
+		wantErr bool
+	}{
+		{
+			name: "test-case1: success execution",
+			fields: fields{repo: func(userID uuid.UUID) uc.TokenRepo {
+				repo := NewMockTokenRepo(mockCtrl)
+				repo.EXPECT().Get(fmt.Sprintf("token_%v", userID)).Return("uKkCy8v3-l1lms13d0vc8ks", nil).Times(1)
+				unix := strconv.FormatInt(verifiedAt.Unix(), 10)
+				repo.EXPECT().Get(fmt.Sprintf("token_verified_%v", userID)).Return(unix, nil).Times(1)
+				return repo
+			}},
+			want: model.TokenStatus{Masked: "uKkC****c8ks", VerifiedAt: verifiedAt},
+		},
+		{
+			name: "test-case2: token was never verified",
+			fields: fields{repo: func(userID uuid.UUID) uc.TokenRepo {
+				repo := NewMockTokenRepo(mockCtrl)
+				repo.EXPECT().Get(fmt.Sprintf("token_%v", userID)).Return("short", nil).Times(1)
+				repo.EXPECT().Get(fmt.Sprintf("token_verified_%v", userID)).Return("", model.ErrNil).Times(1)
+				return repo
+			}},
+			want: model.TokenStatus{Masked: "****"},
+		},
+		{
+			name: "test-case3: token is not set",
+			fields: fields{repo: func(userID uuid.UUID) uc.TokenRepo {
+				repo := NewMockTokenRepo(mockCtrl)
+				repo.EXPECT().Get(fmt.Sprintf("token_%v", userID)).Return("", model.ErrNil).Times(1)
+				return repo
+			}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		userID := uuid.New()
+		c := uc.NewToken(tt.fields.repo(userID), nil)
+		got, err := c.Status(userID)
+		Ω(err != nil).To(Equal(tt.wantErr), fmt.Sprintf(errDefaultMsg, err))
+		Ω(got).To(Equal(tt.want), fmt.Sprintf(errDefaultMsg, got))
 	}
 }
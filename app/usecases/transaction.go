@@ -29,34 +29,67 @@ type MonoRepo interface {
 	GetTransactions(token, account string, from, to time.Time) ([]model.Transaction, error)
 }
 
+// StatementRepo - represents the account's statement repository interface, the statement is fetched
+// from the bank if "refresh" is set.
+type StatementRepo interface {
+	Statement(userID uuid.UUID, token, account string, from, to time.Time, refresh bool) ([]model.Transaction, error)
+}
+
 type categoryMapping map[string]model.CategoryMapping
 
 // NewTransaction - builds Transaction report use-case.
-func NewTransaction(trRepo MonoRepo, mapRepo MappingRepo, log Logger, date *Date) *Transaction {
+func NewTransaction(trRepo StatementRepo, mapRepo MappingRepo, log Logger, date *Date) *Transaction {
 	return &Transaction{
-		apiRepo:     trRepo,
-		mappingRepo: mapRepo,
-		log:         log,
-		Date:        date,
+		statementRepo: trRepo,
+		mappingRepo:   mapRepo,
+		log:           log,
+		Date:          date,
 	}
 }
 
 // Transaction - represents Transaction  use-case for processing bank Transactions.
 type Transaction struct {
-	apiRepo     MonoRepo
-	mappingRepo MappingRepo
-	log         Logger
+	statementRepo StatementRepo
+	mappingRepo   MappingRepo
+	log           Logger
 	*Date
 }
 
-// GetTransactions - get bank transactions, convert it to app csv report.
-func (a *Transaction) GetTransactions(token, account string, userID uuid.UUID, from, to time.Time) (io.Reader, error) {
-	transactions, err := a.apiRepo.GetTransactions(token, account, from, to)
+// GetTransactions - get bank transactions, convert it to app csv report. The stored statement is used
+// unless "refresh" is set.
+func (a *Transaction) GetTransactions(token, account string, userID uuid.UUID, from, to time.Time, refresh bool) (
+	io.Reader, error) {
+	records, err := a.reportRecords(token, account, userID, from, to, refresh)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := &bytes.Buffer{}
+	wr := csv.NewWriter(buf)
+
+	return a.writeRecords(buf, wr, records)
+}
+
+// XLSXReport - get bank transactions, convert it to app report in the XLSX format, the amounts are numbers.
+func (a *Transaction) XLSXReport(token, account string, userID uuid.UUID, from, to time.Time) (io.Reader, error) {
+	records, err := a.reportRecords(token, account, userID, from, to, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return writeXLSX(records, int(AmountHeader)-1) // the headers start from 1
+}
+
+// reportRecords - returns the header and the transactions of the app report.
+func (a *Transaction) reportRecords(token, account string, userID uuid.UUID, from, to time.Time, refresh bool) (
+	[][]string, error) {
+	transactions, err := a.statementRepo.Statement(userID, token, account, from, to, refresh)
 	if err != nil {
 		return nil, err
 	}
 
 	catMap := a.getCategoryMapping(userID)
+	loc, locale := a.Location(userID), a.Locale(userID)
 	records := [][]string{
 		{
 			DateHeader.Str(),
@@ -69,24 +102,16 @@ func (a *Transaction) GetTransactions(token, account string, userID uuid.UUID, f
 
 	for _, tr := range transactions {
 		description := strings.ReplaceAll(tr.Description, "\n", " ")
-		category := strconv.Itoa(tr.Mcc)
 		bankCategory := strconv.Itoa(tr.Mcc)
 		amount := fmt.Sprintf("%.2f", float64(tr.Amount)/accuracy)
-		unixTime := time.Unix(int64(tr.Time), 0).In(a.loc)
-		date := unixTime.Format(dateTimeReportPattern)
-
-		if c, err := a.mapCategory(catMap, category, description); err == nil {
-			category = c
-		}
+		unixTime := time.Unix(int64(tr.Time), 0).In(loc)
+		date := unixTime.Format(locale.ReportDateTime)
 
-		record := []string{date, description, category, bankCategory, amount}
+		record := []string{date, description, a.reportCategory(catMap, tr), bankCategory, amount}
 		records = append(records, record)
 	}
 
-	buf := &bytes.Buffer{}
-	wr := csv.NewWriter(buf)
-
-	return a.writeRecords(buf, wr, records)
+	return records, nil
 }
 
 func (a *Transaction) writeRecords(r io.Reader, w *csv.Writer, record [][]string) (io.Reader, error) {
@@ -113,6 +138,17 @@ func (a Transaction) mapCategory(m categoryMapping, category, description string
 	return "", errors.New("can't find mapping")
 }
 
+// reportCategory - returns the mapped category of the transaction, the MCC if it isn't mapped.
+func (a Transaction) reportCategory(m categoryMapping, tr model.Transaction) string {
+	category := strconv.Itoa(tr.Mcc)
+	description := strings.ReplaceAll(tr.Description, "\n", " ")
+	if c, err := a.mapCategory(m, category, description); err == nil {
+		return c
+	}
+
+	return category
+}
+
 func (a *Transaction) getCategoryMapping(userID uuid.UUID) categoryMapping {
 	key := fmt.Sprintf("%s_%s", mappingKey, userID)
 	categoryMapping, err := a.mappingRepo.Get(key) // Category mapping
@@ -123,14 +159,19 @@ func (a *Transaction) getCategoryMapping(userID uuid.UUID) categoryMapping {
 	return categoryMapping
 }
 
-// Locale - return the transaction.
-func (a *Transaction) Locale() *time.Location {
-	return a.loc
+// Location - returns the user's time zone.
+func (a *Transaction) Location(userID uuid.UUID) *time.Location {
+	return userLocation(a.settings, a.loc, userID)
+}
+
+// Locale - returns the user's locale.
+func (a *Transaction) Locale(userID uuid.UUID) model.Locale {
+	return userLocale(a.settings, userID)
 }
 
-// ParseDate - parse date from string.
-func (a *Transaction) ParseDate(period string) (from time.Time, to time.Time, err error) {
-	filter, err := a.getFilter(period)
+// ParseDate - parses the period in the user's time zone, see Date.parseRange for the grammar.
+func (a *Transaction) ParseDate(userID uuid.UUID, period string) (from time.Time, to time.Time, err error) {
+	filter, err := a.forUser(userID).parseRange(period, time.Now())
 	if err != nil {
 		return
 	}
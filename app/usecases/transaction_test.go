@@ -1,11 +1,12 @@
 package usecases_test
 
 import (
+	"archive/zip"
 	"bytes"
 	"encoding/csv"
 	"fmt"
 	"io"
-	"strconv"
+	"io/ioutil"
 	"testing"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 	uc "github.com/Kalachevskyi/mono-chat/app/usecases"
 
 	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
 )
@@ -20,7 +22,7 @@ import (
 func TestNewTransaction(t *testing.T) {
 	RegisterTestingT(t)
 	want := &uc.Transaction{}
-	got := uc.NewTransaction(nil, nil, nil, uc.Date{})
+	got := uc.NewTransaction(nil, nil, nil, nil)
 	Ω(got).To(Equal(want), fmt.Sprintf(errDefaultMsg, got))
 }
 
@@ -32,15 +34,16 @@ func TestTransaction_GetTransactions(t *testing.T) {
 	type args struct {
 		token   string
 		account string
-		chatID  int64
+		userID  uuid.UUID
 		from    time.Time
 		to      time.Time
+		refresh bool
 	}
 	type fields struct {
-		apiRepo     func(args) uc.MonoRepo
-		mappingRepo func(args) uc.MappingRepo
-		log         func() uc.Logger
-		Date        uc.Date
+		statementRepo func(args) uc.StatementRepo
+		mappingRepo   func(args) uc.MappingRepo
+		log           func() uc.Logger
+		Date          uc.Date
 	}
 
 	tests := []struct {
@@ -53,10 +56,10 @@ func TestTransaction_GetTransactions(t *testing.T) {
 		{
 			name: `test-case1: error from the repo GetTransactions`,
 			fields: fields{
-				apiRepo: func(a args) uc.MonoRepo {
-					repo := NewMockMonoRepo(mockCtrl)
+				statementRepo: func(a args) uc.StatementRepo {
+					repo := NewMockStatementRepo(mockCtrl)
 					err := errors.New("some error")
-					repo.EXPECT().GetTransactions(a.token, a.account, a.from, a.to).Return(nil, err).Times(1)
+					repo.EXPECT().Statement(a.userID, a.token, a.account, a.from, a.to, a.refresh).Return(nil, err).Times(1)
 					return repo
 				},
 				mappingRepo: func(a args) uc.MappingRepo { return nil },
@@ -65,13 +68,14 @@ func TestTransaction_GetTransactions(t *testing.T) {
 			args: args{
 				token:   "some_token",
 				account: "some_account",
+				refresh: true,
 			},
 			wantErr: true,
 		},
 		{
 			name: `test-case1: success execution`,
 			fields: fields{
-				apiRepo: func(a args) uc.MonoRepo {
+				statementRepo: func(a args) uc.StatementRepo {
 					transactions := []model.Transaction{
 						{
 							ID:          "ZuHWzqkKGVo=",
@@ -81,12 +85,12 @@ func TestTransaction_GetTransactions(t *testing.T) {
 							Description: "Покупка щастя",
 						},
 					}
-					repo := NewMockMonoRepo(mockCtrl)
-					repo.EXPECT().GetTransactions(a.token, a.account, a.from, a.to).Return(transactions, nil).Times(1)
+					repo := NewMockStatementRepo(mockCtrl)
+					repo.EXPECT().Statement(a.userID, a.token, a.account, a.from, a.to, a.refresh).Return(transactions, nil).Times(1)
 					return repo
 				},
 				mappingRepo: func(a args) uc.MappingRepo {
-					key := fmt.Sprintf("%s%s", strconv.Itoa(int(a.chatID)), "_mapping")
+					key := fmt.Sprintf("mapping_%s", a.userID)
 					catMap := map[string]model.CategoryMapping{"7997": {}}
 					repo := NewMockMappingRepo(mockCtrl)
 					repo.EXPECT().Get(key).Return(catMap, nil).Times(1)
@@ -126,8 +130,9 @@ func TestTransaction_GetTransactions(t *testing.T) {
 		},
 	}
 	for _, tt := range tests {
-		tr := uc.NewTransaction(tt.fields.apiRepo(tt.args), tt.fields.mappingRepo(tt.args), tt.fields.log(), *date)
-		got, err := tr.GetTransactions(tt.args.token, tt.args.account, tt.args.chatID, tt.args.from, tt.args.to)
+		tr := uc.NewTransaction(tt.fields.statementRepo(tt.args), tt.fields.mappingRepo(tt.args), tt.fields.log(), date)
+		got, err := tr.GetTransactions(tt.args.token, tt.args.account, tt.args.userID, tt.args.from, tt.args.to,
+			tt.args.refresh)
 		if tt.wantErr {
 			Ω(err).NotTo(BeNil(), errNotEqual)
 			continue
@@ -137,3 +142,42 @@ func TestTransaction_GetTransactions(t *testing.T) {
 		Ω(got).To(Equal(tt.want()), fmt.Sprintf(errDefaultMsg, got))
 	}
 }
+
+func TestTransaction_XLSXReport(t *testing.T) {
+	RegisterTestingT(t)
+	mockCtrl := gomock.NewController(t)
+	date, _ := uc.NewDateOld(nil)
+	userID, from, to := uuid.New(), time.Unix(1554400000, 0), time.Unix(1554500000, 0)
+
+	statementRepo := NewMockStatementRepo(mockCtrl)
+	statementRepo.EXPECT().Statement(userID, "some_token", "some_account", from, to, false).Return([]model.Transaction{
+		{ID: "ZuHWzqkKGVo=", Mcc: 7997, Amount: -95000, Time: 1554466347, Description: "Tom & Jerry"},
+	}, nil).Times(1)
+	mappingRepo := NewMockMappingRepo(mockCtrl)
+	mappingRepo.EXPECT().Get(fmt.Sprintf("mapping_%s", userID)).Return(nil, nil).Times(1)
+
+	tr := uc.NewTransaction(statementRepo, mappingRepo, nil, date)
+	report, err := tr.XLSXReport("some_token", "some_account", userID, from, to)
+	Ω(err).To(BeNil(), fmt.Sprintf(errDefaultMsg, err))
+
+	data, _ := ioutil.ReadAll(report)
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	Ω(err).To(BeNil(), fmt.Sprintf(errDefaultMsg, err))
+
+	parts := make(map[string]string, len(archive.File))
+	for _, f := range archive.File {
+		rc, err := f.Open()
+		Ω(err).To(BeNil(), fmt.Sprintf(errDefaultMsg, err))
+		content, _ := ioutil.ReadAll(rc)
+		parts[f.Name] = string(content)
+	}
+
+	Ω(parts).To(HaveKey("[Content_Types].xml"), errNotEqual)
+	Ω(parts).To(HaveKey("xl/workbook.xml"), errNotEqual)
+	Ω(parts["xl/worksheets/sheet1.xml"]).To(ContainSubstring(`<row r="2">`+
+		`<c t="inlineStr"><is><t xml:space="preserve">05.04.2019 15:12:27</t></is></c>`+
+		`<c t="inlineStr"><is><t xml:space="preserve">Tom &amp; Jerry</t></is></c>`+
+		`<c t="inlineStr"><is><t xml:space="preserve">7997</t></is></c>`+
+		`<c t="inlineStr"><is><t xml:space="preserve">7997</t></is></c>`+
+		`<c><v>-950.00</v></c></row>`), errNotEqual)
+}
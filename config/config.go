@@ -16,9 +16,15 @@
 package config
 
 import (
+	"regexp"
+	"time"
+
 	"github.com/pkg/errors"
 )
 
+// webhookSecretPattern - characters allowed by Telegram in the webhook secret token.
+var webhookSecretPattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,256}$`) //nolint:gochecknoglobals
+
 // Config - app configuration.
 type Config struct {
 	Token       string // Telegram token
@@ -28,6 +34,14 @@ type Config struct {
 	EncodingLog string // Valid values are "json" and "console",
 	RedisURL    string // Example localhost:6379
 	HTTPPort    int
+	Workers     int // Number of goroutines processing Telegram updates
+	QueueSize   int // Max number of queued updates per worker
+	// WebhookURL - public URL of the HTTP service, e.g. https://example.com, if it is set
+	// Telegram updates are delivered by the webhook instead of long polling.
+	WebhookURL    string
+	WebhookSecret string // Secret token checked in the webhook requests
+	// ShutdownTimeout - time to finish the processed updates and HTTP requests on SIGINT or SIGTERM.
+	ShutdownTimeout time.Duration
 }
 
 // Validate - verify app configuration.
@@ -36,7 +50,7 @@ func (c *Config) Validate() error {
 		return errors.New(`config parameter "token" can't be empty`)
 	}
 
-	if c.Timeout == 0 {
+	if c.Timeout == 0 && c.WebhookURL == "" {
 		return errors.New(`config parameter "timeout" can't be empty`)
 	}
 
@@ -44,5 +58,21 @@ func (c *Config) Validate() error {
 		return errors.New(`config parameter "redis_url" can't be empty`)
 	}
 
+	if c.Workers < 1 {
+		return errors.New(`config parameter "workers" must be positive`)
+	}
+
+	if c.QueueSize < 1 {
+		return errors.New(`config parameter "queue_size" must be positive`)
+	}
+
+	if c.ShutdownTimeout <= 0 {
+		return errors.New(`config parameter "shutdown_timeout" must be positive`)
+	}
+
+	if c.WebhookURL != "" && !webhookSecretPattern.MatchString(c.WebhookSecret) {
+		return errors.New(`config parameter "webhook_secret" must contain 1-256 characters A-Z, a-z, 0-9, _ and -`)
+	}
+
 	return nil
 }
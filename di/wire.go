@@ -24,6 +24,7 @@ var (
 	mappingUseCaseSet = wire.NewSet(
 		uc.NewMapping,
 		wire.Bind(new(h.MappingUC), new(*uc.Mapping)),
+		wire.Bind(new(hr.MappingUC), new(*uc.Mapping)),
 	)
 
 	transactionUseCaseSet = wire.NewSet(
@@ -43,9 +44,44 @@ var (
 		wire.Bind(new(h.ChatUserUC), new(*uc.ChatUser)),
 	)
 
-	userUseCaseSet = wire.NewSet(
-		uc.NewUser,
-		wire.Bind(new(hr.UserUC), new(*uc.User)),
+	apiKeyUseCaseSet = wire.NewSet(
+		uc.NewAPIKey,
+		wire.Bind(new(h.APIKeyUC), new(*uc.APIKey)),
+		wire.Bind(new(hr.APIKeyUC), new(*uc.APIKey)),
+	)
+
+	scheduleUseCaseSet = wire.NewSet(
+		uc.NewSchedule,
+		wire.Bind(new(h.ScheduleUC), new(*uc.Schedule)),
+	)
+
+	budgetUseCaseSet = wire.NewSet(
+		uc.NewBudget,
+		wire.Bind(new(h.BudgetUC), new(*uc.Budget)),
+	)
+
+	historyUseCaseSet = wire.NewSet(
+		uc.NewHistory,
+		wire.Bind(new(h.HistoryUC), new(*uc.History)),
+		wire.Bind(new(uc.StatementRepo), new(*uc.History)),
+	)
+
+	subscriptionUseCaseSet = wire.NewSet(
+		uc.NewSubscription,
+		wire.Bind(new(h.SubscriptionUC), new(*uc.Subscription)),
+	)
+
+	settingsUseCaseSet = wire.NewSet(
+		uc.NewSettings,
+		wire.Bind(new(h.SettingsUC), new(*uc.Settings)),
+		wire.Bind(new(h.LanguageUC), new(*uc.Settings)),
+		wire.Bind(new(hr.SettingsUC), new(*uc.Settings)),
+		wire.Bind(new(uc.UserSettings), new(*uc.Settings)),
+	)
+
+	inlineUseCaseSet = wire.NewSet(
+		uc.NewInline,
+		wire.Bind(new(h.InlineUC), new(*uc.Inline)),
 	)
 
 	tokenUseCaseSet = wire.NewSet(
@@ -57,6 +93,12 @@ var (
 	clientInfoUseCaseSet = wire.NewSet(
 		uc.NewClientInfo,
 		wire.Bind(new(h.ClientInfoUC), new(*uc.ClientInfo)),
+		wire.Bind(new(hr.ClientInfoUC), new(*uc.ClientInfo)),
+	)
+
+	dialogUseCaseSet = wire.NewSet(
+		uc.NewDialog,
+		wire.Bind(new(h.DialogUC), new(*uc.Dialog)),
 	)
 
 	mappingRepo = wire.NewSet(
@@ -69,6 +111,8 @@ var (
 		wire.Bind(new(uc.TokenRepo), new(*ar.Generic)),
 		wire.Bind(new(uc.AccountRepo), new(*ar.Generic)),
 		wire.Bind(new(uc.ChatUserRepo), new(*ar.Generic)),
+		wire.Bind(new(uc.OffsetRepo), new(*ar.Generic)),
+		wire.Bind(new(uc.SettingsRepo), new(*ar.Generic)),
 	)
 
 	telegramRepo = wire.NewSet(
@@ -76,18 +120,39 @@ var (
 		wire.Bind(new(uc.TelegramRepo), new(*telegram.Telegram)),
 	)
 
-	userRepo = wire.NewSet(
-		ar.NewUser,
-		wire.Bind(new(uc.UserRepo), new(*ar.User)),
+	apiKeyRepo = wire.NewSet(
+		ar.NewAPIKey,
+		wire.Bind(new(uc.APIKeyRepo), new(*ar.APIKey)),
 	)
 
-	monoRepo = wire.NewSet(
-		mono.NewMono,
-		wire.Bind(new(uc.MonoRepo), new(*mono.Mono)),
+	scheduleRepo = wire.NewSet(
+		ar.NewSchedule,
+		wire.Bind(new(uc.ScheduleRepo), new(*ar.Schedule)),
+	)
+
+	budgetRepo = wire.NewSet(
+		ar.NewBudget,
+		wire.Bind(new(uc.BudgetRepo), new(*ar.Budget)),
+	)
+
+	historyRepo = wire.NewSet(
+		ar.NewHistory,
+		wire.Bind(new(uc.TransactionRepo), new(*ar.History)),
+	)
+
+	subscriptionRepo = wire.NewSet(
+		ar.NewSubscription,
+		wire.Bind(new(uc.SubscriptionRepo), new(*ar.Subscription)),
+	)
+
+	dialogRepo = wire.NewSet(
+		ar.NewDialog,
+		wire.Bind(new(uc.DialogRepo), new(*ar.Dialog)),
 	)
 
-	clientInfoRepo = wire.NewSet(
+	monoRepo = wire.NewSet(
 		mono.NewMono,
+		wire.Bind(new(uc.MonoRepo), new(*mono.Mono)),
 		wire.Bind(new(uc.ClientInfoRepo), new(*mono.Mono)),
 	)
 
@@ -105,6 +170,7 @@ var (
 func InjectReport(ToolsWrapper) *h.FileReport {
 	wire.Build(
 		toolsWrapperSet,
+		settingsUseCaseSet,
 		h.NewFileReport,
 		uc.NewDate,
 		chatUserUseCaseSet,
@@ -119,9 +185,44 @@ func InjectReport(ToolsWrapper) *h.FileReport {
 	return nil
 }
 
+func InjectRouter(ToolsWrapper, map[h.HandlerKey]h.Handler) *h.Router {
+	wire.Build(
+		h.NewRouter,
+		toolsWrapperSet,
+		settingsUseCaseSet,
+		ucLoggerBind,
+		chatUserUseCaseSet,
+		tokenUseCaseSet,
+		accountUseCaseSet,
+		genericRepo,
+		monoRepo,
+		monoLoggerBind,
+		h.NewBotWrapper,
+		apiLoggerBind,
+		dialogUseCaseSet,
+		dialogRepo,
+	)
+	return nil
+}
+
+func InjectBotWrapper(ToolsWrapper) *h.BotWrapper {
+	wire.Build(
+		toolsWrapperSet,
+		settingsUseCaseSet,
+		chatUserUseCaseSet,
+		genericRepo,
+		h.NewBotWrapper,
+		ucLoggerBind,
+		apiLoggerBind,
+	)
+	return nil
+}
+
 func InjectMapping(ToolsWrapper) *h.Mapping {
 	wire.Build(
 		toolsWrapperSet,
+		settingsUseCaseSet,
+		ucLoggerBind,
 		h.NewMapping,
 		h.NewBotWrapper,
 		mappingUseCaseSet,
@@ -130,6 +231,8 @@ func InjectMapping(ToolsWrapper) *h.Mapping {
 		mappingRepo,
 		telegramRepo,
 		apiLoggerBind,
+		dialogUseCaseSet,
+		dialogRepo,
 	)
 	return nil
 }
@@ -138,12 +241,100 @@ func InjectTransaction(ToolsWrapper) *h.Transaction {
 	wire.Build(
 		h.NewTransaction,
 		toolsWrapperSet,
+		settingsUseCaseSet,
+		chatUserUseCaseSet,
+		genericRepo,
+		transactionUseCaseSet,
+		historyUseCaseSet,
+		mappingRepo,
+		historyRepo,
+		uc.NewDate,
+		monoRepo,
+		h.NewBotWrapper,
+		apiLoggerBind,
+		monoLoggerBind,
+		ucLoggerBind,
+	)
+	return nil
+}
+
+func InjectSummary(ToolsWrapper) *h.Summary {
+	wire.Build(
+		h.NewSummary,
+		toolsWrapperSet,
+		settingsUseCaseSet,
+		chatUserUseCaseSet,
+		genericRepo,
+		transactionUseCaseSet,
+		historyUseCaseSet,
+		mappingRepo,
+		historyRepo,
+		uc.NewDate,
+		monoRepo,
+		h.NewBotWrapper,
+		apiLoggerBind,
+		monoLoggerBind,
+		ucLoggerBind,
+	)
+	return nil
+}
+
+func InjectChart(ToolsWrapper) *h.Chart {
+	wire.Build(
+		h.NewChart,
+		toolsWrapperSet,
+		settingsUseCaseSet,
+		chatUserUseCaseSet,
+		genericRepo,
+		transactionUseCaseSet,
+		historyUseCaseSet,
+		mappingRepo,
+		historyRepo,
+		uc.NewDate,
+		monoRepo,
+		h.NewBotWrapper,
+		apiLoggerBind,
+		monoLoggerBind,
+		ucLoggerBind,
+	)
+	return nil
+}
+
+func InjectSearch(ToolsWrapper) *h.Search {
+	wire.Build(
+		h.NewSearch,
+		toolsWrapperSet,
+		settingsUseCaseSet,
+		chatUserUseCaseSet,
+		genericRepo,
+		transactionUseCaseSet,
+		historyUseCaseSet,
+		mappingRepo,
+		historyRepo,
+		uc.NewDate,
+		monoRepo,
+		h.NewBotWrapper,
+		apiLoggerBind,
+		monoLoggerBind,
+		ucLoggerBind,
+	)
+	return nil
+}
+
+func InjectInlineQuery(ToolsWrapper) *h.InlineQuery {
+	wire.Build(
+		h.NewInlineQuery,
+		toolsWrapperSet,
+		inlineUseCaseSet,
+		settingsUseCaseSet,
 		tokenUseCaseSet,
 		chatUserUseCaseSet,
 		genericRepo,
 		transactionUseCaseSet,
+		historyUseCaseSet,
 		accountUseCaseSet,
 		mappingRepo,
+		historyRepo,
 		uc.NewDate,
 		monoRepo,
 		h.NewBotWrapper,
@@ -158,11 +349,17 @@ func InjectToken(ToolsWrapper) *h.Token {
 	wire.Build(
 		h.NewToken,
 		toolsWrapperSet,
+		settingsUseCaseSet,
+		ucLoggerBind,
 		tokenUseCaseSet,
 		chatUserUseCaseSet,
 		genericRepo,
+		monoRepo,
 		h.NewBotWrapper,
 		apiLoggerBind,
+		monoLoggerBind,
+		dialogUseCaseSet,
+		dialogRepo,
 	)
 	return nil
 }
@@ -171,12 +368,53 @@ func InjectClientInfo(ToolsWrapper) *h.ClientInfo {
 	wire.Build(
 		h.NewClientInfo,
 		toolsWrapperSet,
-		tokenUseCaseSet,
+		settingsUseCaseSet,
+		ucLoggerBind,
 		clientInfoUseCaseSet,
 		chatUserUseCaseSet,
 		genericRepo,
-		clientInfoRepo,
+		monoRepo,
+		monoLoggerBind,
+		h.NewBotWrapper,
+		apiLoggerBind,
+	)
+	return nil
+}
+
+func InjectExport(ToolsWrapper) *h.Export {
+	wire.Build(
+		h.NewExport,
+		toolsWrapperSet,
+		settingsUseCaseSet,
+		chatUserUseCaseSet,
+		genericRepo,
+		clientInfoUseCaseSet,
+		transactionUseCaseSet,
+		historyUseCaseSet,
+		dialogUseCaseSet,
+		mappingRepo,
+		historyRepo,
+		dialogRepo,
+		uc.NewDate,
+		monoRepo,
+		h.NewBotWrapper,
+		apiLoggerBind,
 		monoLoggerBind,
+		ucLoggerBind,
+	)
+	return nil
+}
+
+func InjectDialog(ToolsWrapper) *h.Dialog {
+	wire.Build(
+		h.NewDialog,
+		toolsWrapperSet,
+		settingsUseCaseSet,
+		ucLoggerBind,
+		chatUserUseCaseSet,
+		genericRepo,
+		dialogUseCaseSet,
+		dialogRepo,
 		h.NewBotWrapper,
 		apiLoggerBind,
 	)
@@ -187,6 +425,8 @@ func InjectAccount(ToolsWrapper) *h.Account {
 	wire.Build(
 		h.NewAccount,
 		toolsWrapperSet,
+		settingsUseCaseSet,
+		ucLoggerBind,
 		accountUseCaseSet,
 		chatUserUseCaseSet,
 		genericRepo,
@@ -196,14 +436,239 @@ func InjectAccount(ToolsWrapper) *h.Account {
 	return nil
 }
 
+func InjectHelp(ToolsWrapper) *h.Help {
+	wire.Build(
+		h.NewHelp,
+		toolsWrapperSet,
+		settingsUseCaseSet,
+		ucLoggerBind,
+		chatUserUseCaseSet,
+		genericRepo,
+		h.NewBotWrapper,
+		apiLoggerBind,
+	)
+	return nil
+}
+
+func InjectStart(ToolsWrapper) *h.Start {
+	wire.Build(
+		h.NewStart,
+		toolsWrapperSet,
+		settingsUseCaseSet,
+		ucLoggerBind,
+		chatUserUseCaseSet,
+		tokenUseCaseSet,
+		accountUseCaseSet,
+		mappingUseCaseSet,
+		genericRepo,
+		mappingRepo,
+		telegramRepo,
+		monoRepo,
+		h.NewBotWrapper,
+		apiLoggerBind,
+		monoLoggerBind,
+	)
+	return nil
+}
+
 func InjectUserChat(ToolsWrapper) *h.ChatUser {
 	wire.Build(
 		h.NewChatUser,
 		toolsWrapperSet,
+		settingsUseCaseSet,
+		ucLoggerBind,
+		chatUserUseCaseSet,
+		genericRepo,
+		h.NewBotWrapper,
+		apiLoggerBind,
+	)
+	return nil
+}
+
+func InjectAPIKey(ToolsWrapper) *h.APIKey {
+	wire.Build(
+		h.NewAPIKey,
+		toolsWrapperSet,
+		settingsUseCaseSet,
+		ucLoggerBind,
+		apiKeyUseCaseSet,
+		chatUserUseCaseSet,
+		genericRepo,
+		apiKeyRepo,
+		h.NewBotWrapper,
+		apiLoggerBind,
+	)
+	return nil
+}
+
+func InjectSchedule(ToolsWrapper) *h.Schedule {
+	wire.Build(
+		h.NewSchedule,
+		toolsWrapperSet,
+		settingsUseCaseSet,
+		scheduleUseCaseSet,
+		chatUserUseCaseSet,
+		genericRepo,
+		scheduleRepo,
+		h.NewBotWrapper,
+		apiLoggerBind,
+		ucLoggerBind,
+	)
+	return nil
+}
+
+func InjectScheduler(ToolsWrapper) *h.Scheduler {
+	wire.Build(
+		h.NewScheduler,
+		toolsWrapperSet,
+		chatUserUseCaseSet,
+		settingsUseCaseSet,
+		scheduleUseCaseSet,
+		tokenUseCaseSet,
+		accountUseCaseSet,
+		transactionUseCaseSet,
+		historyUseCaseSet,
+		genericRepo,
+		scheduleRepo,
+		mappingRepo,
+		historyRepo,
+		monoRepo,
+		uc.NewDate,
+		h.NewBotWrapper,
+		apiLoggerBind,
+		ucLoggerBind,
+		monoLoggerBind,
+	)
+	return nil
+}
+
+func InjectBudget(ToolsWrapper) *h.Budget {
+	wire.Build(
+		h.NewBudget,
+		toolsWrapperSet,
+		settingsUseCaseSet,
+		budgetUseCaseSet,
+		tokenUseCaseSet,
+		accountUseCaseSet,
+		transactionUseCaseSet,
+		historyUseCaseSet,
+		chatUserUseCaseSet,
+		genericRepo,
+		budgetRepo,
+		mappingRepo,
+		historyRepo,
+		monoRepo,
+		uc.NewDate,
+		h.NewBotWrapper,
+		apiLoggerBind,
+		ucLoggerBind,
+		monoLoggerBind,
+	)
+	return nil
+}
+
+func InjectBudgetWatcher(ToolsWrapper) *h.BudgetWatcher {
+	wire.Build(
+		h.NewBudgetWatcher,
+		toolsWrapperSet,
+		chatUserUseCaseSet,
+		settingsUseCaseSet,
+		budgetUseCaseSet,
+		tokenUseCaseSet,
+		accountUseCaseSet,
+		transactionUseCaseSet,
+		historyUseCaseSet,
+		genericRepo,
+		budgetRepo,
+		mappingRepo,
+		historyRepo,
+		monoRepo,
+		uc.NewDate,
+		h.NewBotWrapper,
+		apiLoggerBind,
+		ucLoggerBind,
+		monoLoggerBind,
+	)
+	return nil
+}
+
+func InjectSubscription(ToolsWrapper) *h.Subscription {
+	wire.Build(
+		h.NewSubscription,
+		toolsWrapperSet,
+		settingsUseCaseSet,
+		subscriptionUseCaseSet,
+		historyUseCaseSet,
+		chatUserUseCaseSet,
+		genericRepo,
+		subscriptionRepo,
+		historyRepo,
+		monoRepo,
+		h.NewBotWrapper,
+		apiLoggerBind,
+		ucLoggerBind,
+		monoLoggerBind,
+	)
+	return nil
+}
+
+func InjectSubscriptionWatcher(ToolsWrapper) *h.SubscriptionWatcher {
+	wire.Build(
+		h.NewSubscriptionWatcher,
+		toolsWrapperSet,
+		chatUserUseCaseSet,
+		settingsUseCaseSet,
+		subscriptionUseCaseSet,
+		historyUseCaseSet,
+		accountUseCaseSet,
+		genericRepo,
+		subscriptionRepo,
+		historyRepo,
+		monoRepo,
+		h.NewBotWrapper,
+		apiLoggerBind,
+		ucLoggerBind,
+		monoLoggerBind,
+	)
+	return nil
+}
+
+func InjectHistorySync(ToolsWrapper) *h.HistorySync {
+	wire.Build(
+		h.NewHistorySync,
+		toolsWrapperSet,
+		historyUseCaseSet,
+		tokenUseCaseSet,
+		accountUseCaseSet,
+		genericRepo,
+		historyRepo,
+		monoRepo,
+		apiLoggerBind,
+		ucLoggerBind,
+		monoLoggerBind,
+	)
+	return nil
+}
+
+func InjectSettings(ToolsWrapper) *h.Settings {
+	wire.Build(
+		h.NewSettings,
+		toolsWrapperSet,
+		settingsUseCaseSet,
 		chatUserUseCaseSet,
 		genericRepo,
 		h.NewBotWrapper,
 		apiLoggerBind,
+		ucLoggerBind,
+	)
+	return nil
+}
+
+func InjectOffset(ToolsWrapper) *uc.Offset {
+	wire.Build(
+		uc.NewOffset,
+		toolsWrapperSet,
+		genericRepo,
 	)
 	return nil
 }
@@ -212,15 +677,16 @@ func InjectTransactionRest(ToolsWrapper) *hr.Transaction {
 	wire.Build(
 		hr.NewTransaction,
 		toolsWrapperSet,
+		settingsUseCaseSet,
 		tokenUseCaseSet,
 		genericRepo,
 		transactionUseCaseSet,
+		historyUseCaseSet,
 		accountUseCaseSet,
-		userUseCaseSet,
 		mappingRepo,
+		historyRepo,
 		uc.NewDate,
 		monoRepo,
-		userRepo,
 		ucLoggerBind,
 		apiRestLoggerBind,
 		monoLoggerBind,
@@ -232,16 +698,27 @@ func InjectHTTPService(tw ToolsWrapper, port int) *hr.Service {
 	wire.Build(
 		hr.NewService,
 		hr.NewTransaction,
+		hr.NewAccount,
+		hr.NewToken,
+		hr.NewMapping,
+		hr.NewSettings,
+		hr.NewAuth,
 		toolsWrapperSet,
+		settingsUseCaseSet,
 		tokenUseCaseSet,
 		genericRepo,
 		transactionUseCaseSet,
+		historyUseCaseSet,
 		accountUseCaseSet,
-		userUseCaseSet,
+		apiKeyUseCaseSet,
+		clientInfoUseCaseSet,
+		mappingUseCaseSet,
 		mappingRepo,
+		historyRepo,
+		telegramRepo,
+		apiKeyRepo,
 		uc.NewDate,
 		monoRepo,
-		userRepo,
 		ucLoggerBind,
 		apiRestLoggerBind,
 		monoLoggerBind,
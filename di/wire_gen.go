@@ -21,18 +21,49 @@ import (
 
 func InjectReport(toolsWrapper ToolsWrapper) *telegram.FileReport {
 	location := toolsWrapper.Loc
-	date := usecases.NewDate(location)
 	client := toolsWrapper.RedisClient
-	mapping := redis.NewMapping(client)
+	generic := redis.NewGeneric(client)
 	sugaredLogger := toolsWrapper.Log
+	settings := usecases.NewSettings(generic, location, sugaredLogger)
+	date := usecases.NewDate(location, settings)
+	mapping := redis.NewMapping(client)
 	telegramTelegram := telegram2.NewTelegram()
 	fileReport := usecases.NewFileReport(date, mapping, sugaredLogger, telegramTelegram)
+	botAPI := toolsWrapper.Bot
+	chatUser := usecases.NewChatUser(generic)
+	botWrapper := telegram.NewBotWrapper(botAPI, sugaredLogger, settings, chatUser)
+	telegramFileReport := telegram.NewFileReport(fileReport, botWrapper)
+	return telegramFileReport
+}
+
+func InjectRouter(toolsWrapper ToolsWrapper, arg map[telegram.HandlerKey]telegram.Handler) *telegram.Router {
+	client := toolsWrapper.RedisClient
 	generic := redis.NewGeneric(client)
 	chatUser := usecases.NewChatUser(generic)
+	sugaredLogger := toolsWrapper.Log
+	monoMono := mono.NewMono(sugaredLogger)
+	token := usecases.NewToken(generic, monoMono)
+	account := usecases.NewAccount(generic)
+	dialog := redis.NewDialog(client)
+	usecasesDialog := usecases.NewDialog(dialog)
 	botAPI := toolsWrapper.Bot
-	botWrapper := telegram.NewBotWrapper(botAPI, sugaredLogger)
-	telegramFileReport := telegram.NewFileReport(fileReport, chatUser, botWrapper)
-	return telegramFileReport
+	location := toolsWrapper.Loc
+	settings := usecases.NewSettings(generic, location, sugaredLogger)
+	botWrapper := telegram.NewBotWrapper(botAPI, sugaredLogger, settings, chatUser)
+	router := telegram.NewRouter(arg, chatUser, token, account, usecasesDialog, botWrapper)
+	return router
+}
+
+func InjectBotWrapper(toolsWrapper ToolsWrapper) *telegram.BotWrapper {
+	botAPI := toolsWrapper.Bot
+	sugaredLogger := toolsWrapper.Log
+	client := toolsWrapper.RedisClient
+	generic := redis.NewGeneric(client)
+	location := toolsWrapper.Loc
+	settings := usecases.NewSettings(generic, location, sugaredLogger)
+	chatUser := usecases.NewChatUser(generic)
+	botWrapper := telegram.NewBotWrapper(botAPI, sugaredLogger, settings, chatUser)
+	return botWrapper
 }
 
 func InjectMapping(toolsWrapper ToolsWrapper) *telegram.Mapping {
@@ -40,114 +71,458 @@ func InjectMapping(toolsWrapper ToolsWrapper) *telegram.Mapping {
 	mapping := redis.NewMapping(client)
 	telegramTelegram := telegram2.NewTelegram()
 	usecasesMapping := usecases.NewMapping(mapping, telegramTelegram)
-	generic := redis.NewGeneric(client)
-	chatUser := usecases.NewChatUser(generic)
+	dialog := redis.NewDialog(client)
+	usecasesDialog := usecases.NewDialog(dialog)
 	botAPI := toolsWrapper.Bot
 	sugaredLogger := toolsWrapper.Log
-	botWrapper := telegram.NewBotWrapper(botAPI, sugaredLogger)
-	telegramMapping := telegram.NewMapping(usecasesMapping, chatUser, botWrapper)
+	generic := redis.NewGeneric(client)
+	location := toolsWrapper.Loc
+	settings := usecases.NewSettings(generic, location, sugaredLogger)
+	chatUser := usecases.NewChatUser(generic)
+	botWrapper := telegram.NewBotWrapper(botAPI, sugaredLogger, settings, chatUser)
+	telegramMapping := telegram.NewMapping(usecasesMapping, usecasesDialog, botWrapper)
 	return telegramMapping
 }
 
 func InjectTransaction(toolsWrapper ToolsWrapper) *telegram.Transaction {
 	client := toolsWrapper.RedisClient
+	history := redis.NewHistory(client)
+	sugaredLogger := toolsWrapper.Log
+	monoMono := mono.NewMono(sugaredLogger)
+	usecasesHistory := usecases.NewHistory(history, monoMono, sugaredLogger)
+	mapping := redis.NewMapping(client)
+	location := toolsWrapper.Loc
 	generic := redis.NewGeneric(client)
-	token := usecases.NewToken(generic)
+	settings := usecases.NewSettings(generic, location, sugaredLogger)
+	date := usecases.NewDate(location, settings)
+	transaction := usecases.NewTransaction(usecasesHistory, mapping, sugaredLogger, date)
+	botAPI := toolsWrapper.Bot
+	chatUser := usecases.NewChatUser(generic)
+	botWrapper := telegram.NewBotWrapper(botAPI, sugaredLogger, settings, chatUser)
+	telegramTransaction := telegram.NewTransaction(transaction, botWrapper)
+	return telegramTransaction
+}
+
+func InjectSummary(toolsWrapper ToolsWrapper) *telegram.Summary {
+	client := toolsWrapper.RedisClient
+	history := redis.NewHistory(client)
 	sugaredLogger := toolsWrapper.Log
 	monoMono := mono.NewMono(sugaredLogger)
+	usecasesHistory := usecases.NewHistory(history, monoMono, sugaredLogger)
 	mapping := redis.NewMapping(client)
 	location := toolsWrapper.Loc
-	date := usecases.NewDate(location)
-	transaction := usecases.NewTransaction(monoMono, mapping, sugaredLogger, date)
-	account := usecases.NewAccount(generic)
+	generic := redis.NewGeneric(client)
+	settings := usecases.NewSettings(generic, location, sugaredLogger)
+	date := usecases.NewDate(location, settings)
+	transaction := usecases.NewTransaction(usecasesHistory, mapping, sugaredLogger, date)
+	botAPI := toolsWrapper.Bot
 	chatUser := usecases.NewChatUser(generic)
+	botWrapper := telegram.NewBotWrapper(botAPI, sugaredLogger, settings, chatUser)
+	summary := telegram.NewSummary(transaction, botWrapper)
+	return summary
+}
+
+func InjectChart(toolsWrapper ToolsWrapper) *telegram.Chart {
+	client := toolsWrapper.RedisClient
+	history := redis.NewHistory(client)
+	sugaredLogger := toolsWrapper.Log
+	monoMono := mono.NewMono(sugaredLogger)
+	usecasesHistory := usecases.NewHistory(history, monoMono, sugaredLogger)
+	mapping := redis.NewMapping(client)
+	location := toolsWrapper.Loc
+	generic := redis.NewGeneric(client)
+	settings := usecases.NewSettings(generic, location, sugaredLogger)
+	date := usecases.NewDate(location, settings)
+	transaction := usecases.NewTransaction(usecasesHistory, mapping, sugaredLogger, date)
 	botAPI := toolsWrapper.Bot
-	botWrapper := telegram.NewBotWrapper(botAPI, sugaredLogger)
-	telegramTransaction := telegram.NewTransaction(token, transaction, account, chatUser, botWrapper)
-	return telegramTransaction
+	chatUser := usecases.NewChatUser(generic)
+	botWrapper := telegram.NewBotWrapper(botAPI, sugaredLogger, settings, chatUser)
+	chart := telegram.NewChart(transaction, botWrapper)
+	return chart
 }
 
-func InjectToken(toolsWrapper ToolsWrapper) *telegram.Token {
+func InjectSearch(toolsWrapper ToolsWrapper) *telegram.Search {
 	client := toolsWrapper.RedisClient
+	history := redis.NewHistory(client)
+	sugaredLogger := toolsWrapper.Log
+	monoMono := mono.NewMono(sugaredLogger)
+	usecasesHistory := usecases.NewHistory(history, monoMono, sugaredLogger)
+	mapping := redis.NewMapping(client)
+	location := toolsWrapper.Loc
 	generic := redis.NewGeneric(client)
-	token := usecases.NewToken(generic)
+	settings := usecases.NewSettings(generic, location, sugaredLogger)
+	date := usecases.NewDate(location, settings)
+	transaction := usecases.NewTransaction(usecasesHistory, mapping, sugaredLogger, date)
+	botAPI := toolsWrapper.Bot
 	chatUser := usecases.NewChatUser(generic)
+	botWrapper := telegram.NewBotWrapper(botAPI, sugaredLogger, settings, chatUser)
+	search := telegram.NewSearch(transaction, botWrapper)
+	return search
+}
+
+func InjectInlineQuery(toolsWrapper ToolsWrapper) *telegram.InlineQuery {
+	sugaredLogger := toolsWrapper.Log
+	monoMono := mono.NewMono(sugaredLogger)
+	client := toolsWrapper.RedisClient
+	history := redis.NewHistory(client)
+	usecasesHistory := usecases.NewHistory(history, monoMono, sugaredLogger)
+	mapping := redis.NewMapping(client)
+	location := toolsWrapper.Loc
+	generic := redis.NewGeneric(client)
+	settings := usecases.NewSettings(generic, location, sugaredLogger)
+	date := usecases.NewDate(location, settings)
+	transaction := usecases.NewTransaction(usecasesHistory, mapping, sugaredLogger, date)
+	inline := usecases.NewInline(monoMono, transaction)
+	token := usecases.NewToken(generic, monoMono)
+	account := usecases.NewAccount(generic)
 	botAPI := toolsWrapper.Bot
+	chatUser := usecases.NewChatUser(generic)
+	botWrapper := telegram.NewBotWrapper(botAPI, sugaredLogger, settings, chatUser)
+	inlineQuery := telegram.NewInlineQuery(inline, token, account, transaction, botWrapper)
+	return inlineQuery
+}
+
+func InjectToken(toolsWrapper ToolsWrapper) *telegram.Token {
+	client := toolsWrapper.RedisClient
+	generic := redis.NewGeneric(client)
 	sugaredLogger := toolsWrapper.Log
-	botWrapper := telegram.NewBotWrapper(botAPI, sugaredLogger)
-	telegramToken := telegram.NewToken(token, chatUser, botWrapper)
+	monoMono := mono.NewMono(sugaredLogger)
+	token := usecases.NewToken(generic, monoMono)
+	dialog := redis.NewDialog(client)
+	usecasesDialog := usecases.NewDialog(dialog)
+	botAPI := toolsWrapper.Bot
+	location := toolsWrapper.Loc
+	settings := usecases.NewSettings(generic, location, sugaredLogger)
+	chatUser := usecases.NewChatUser(generic)
+	botWrapper := telegram.NewBotWrapper(botAPI, sugaredLogger, settings, chatUser)
+	telegramToken := telegram.NewToken(token, usecasesDialog, botWrapper)
 	return telegramToken
 }
 
 func InjectClientInfo(toolsWrapper ToolsWrapper) *telegram.ClientInfo {
+	sugaredLogger := toolsWrapper.Log
+	monoMono := mono.NewMono(sugaredLogger)
+	clientInfo := usecases.NewClientInfo(monoMono)
+	botAPI := toolsWrapper.Bot
 	client := toolsWrapper.RedisClient
 	generic := redis.NewGeneric(client)
-	token := usecases.NewToken(generic)
+	location := toolsWrapper.Loc
+	settings := usecases.NewSettings(generic, location, sugaredLogger)
+	chatUser := usecases.NewChatUser(generic)
+	botWrapper := telegram.NewBotWrapper(botAPI, sugaredLogger, settings, chatUser)
+	telegramClientInfo := telegram.NewClientInfo(clientInfo, botWrapper)
+	return telegramClientInfo
+}
+
+func InjectExport(toolsWrapper ToolsWrapper) *telegram.Export {
 	sugaredLogger := toolsWrapper.Log
 	monoMono := mono.NewMono(sugaredLogger)
 	clientInfo := usecases.NewClientInfo(monoMono)
+	client := toolsWrapper.RedisClient
+	history := redis.NewHistory(client)
+	usecasesHistory := usecases.NewHistory(history, monoMono, sugaredLogger)
+	mapping := redis.NewMapping(client)
+	location := toolsWrapper.Loc
+	generic := redis.NewGeneric(client)
+	settings := usecases.NewSettings(generic, location, sugaredLogger)
+	date := usecases.NewDate(location, settings)
+	transaction := usecases.NewTransaction(usecasesHistory, mapping, sugaredLogger, date)
+	dialog := redis.NewDialog(client)
+	usecasesDialog := usecases.NewDialog(dialog)
+	botAPI := toolsWrapper.Bot
 	chatUser := usecases.NewChatUser(generic)
+	botWrapper := telegram.NewBotWrapper(botAPI, sugaredLogger, settings, chatUser)
+	export := telegram.NewExport(clientInfo, transaction, usecasesDialog, botWrapper)
+	return export
+}
+
+func InjectDialog(toolsWrapper ToolsWrapper) *telegram.Dialog {
+	client := toolsWrapper.RedisClient
+	dialog := redis.NewDialog(client)
+	usecasesDialog := usecases.NewDialog(dialog)
 	botAPI := toolsWrapper.Bot
-	botWrapper := telegram.NewBotWrapper(botAPI, sugaredLogger)
-	telegramClientInfo := telegram.NewClientInfo(token, clientInfo, chatUser, botWrapper)
-	return telegramClientInfo
+	sugaredLogger := toolsWrapper.Log
+	generic := redis.NewGeneric(client)
+	location := toolsWrapper.Loc
+	settings := usecases.NewSettings(generic, location, sugaredLogger)
+	chatUser := usecases.NewChatUser(generic)
+	botWrapper := telegram.NewBotWrapper(botAPI, sugaredLogger, settings, chatUser)
+	telegramDialog := telegram.NewDialog(usecasesDialog, botWrapper)
+	return telegramDialog
 }
 
 func InjectAccount(toolsWrapper ToolsWrapper) *telegram.Account {
 	client := toolsWrapper.RedisClient
 	generic := redis.NewGeneric(client)
 	account := usecases.NewAccount(generic)
-	chatUser := usecases.NewChatUser(generic)
 	botAPI := toolsWrapper.Bot
 	sugaredLogger := toolsWrapper.Log
-	botWrapper := telegram.NewBotWrapper(botAPI, sugaredLogger)
-	telegramAccount := telegram.NewAccount(account, chatUser, botWrapper)
+	location := toolsWrapper.Loc
+	settings := usecases.NewSettings(generic, location, sugaredLogger)
+	chatUser := usecases.NewChatUser(generic)
+	botWrapper := telegram.NewBotWrapper(botAPI, sugaredLogger, settings, chatUser)
+	telegramAccount := telegram.NewAccount(account, botWrapper)
 	return telegramAccount
 }
 
+func InjectHelp(toolsWrapper ToolsWrapper) *telegram.Help {
+	botAPI := toolsWrapper.Bot
+	sugaredLogger := toolsWrapper.Log
+	client := toolsWrapper.RedisClient
+	generic := redis.NewGeneric(client)
+	location := toolsWrapper.Loc
+	settings := usecases.NewSettings(generic, location, sugaredLogger)
+	chatUser := usecases.NewChatUser(generic)
+	botWrapper := telegram.NewBotWrapper(botAPI, sugaredLogger, settings, chatUser)
+	help := telegram.NewHelp(botWrapper)
+	return help
+}
+
+func InjectStart(toolsWrapper ToolsWrapper) *telegram.Start {
+	client := toolsWrapper.RedisClient
+	generic := redis.NewGeneric(client)
+	sugaredLogger := toolsWrapper.Log
+	monoMono := mono.NewMono(sugaredLogger)
+	token := usecases.NewToken(generic, monoMono)
+	account := usecases.NewAccount(generic)
+	mapping := redis.NewMapping(client)
+	telegramTelegram := telegram2.NewTelegram()
+	usecasesMapping := usecases.NewMapping(mapping, telegramTelegram)
+	botAPI := toolsWrapper.Bot
+	location := toolsWrapper.Loc
+	settings := usecases.NewSettings(generic, location, sugaredLogger)
+	chatUser := usecases.NewChatUser(generic)
+	botWrapper := telegram.NewBotWrapper(botAPI, sugaredLogger, settings, chatUser)
+	start := telegram.NewStart(token, account, usecasesMapping, botWrapper)
+	return start
+}
+
 func InjectUserChat(toolsWrapper ToolsWrapper) *telegram.ChatUser {
 	client := toolsWrapper.RedisClient
 	generic := redis.NewGeneric(client)
 	chatUser := usecases.NewChatUser(generic)
 	botAPI := toolsWrapper.Bot
 	sugaredLogger := toolsWrapper.Log
-	botWrapper := telegram.NewBotWrapper(botAPI, sugaredLogger)
+	location := toolsWrapper.Loc
+	settings := usecases.NewSettings(generic, location, sugaredLogger)
+	botWrapper := telegram.NewBotWrapper(botAPI, sugaredLogger, settings, chatUser)
 	telegramChatUser := telegram.NewChatUser(chatUser, botWrapper)
 	return telegramChatUser
 }
 
-func InjectTransactionRest(toolsWrapper ToolsWrapper) *rest.Transaction {
+func InjectAPIKey(toolsWrapper ToolsWrapper) *telegram.APIKey {
+	client := toolsWrapper.RedisClient
+	apiKey := redis.NewAPIKey(client)
+	usecasesAPIKey := usecases.NewAPIKey(apiKey)
+	botAPI := toolsWrapper.Bot
 	sugaredLogger := toolsWrapper.Log
+	generic := redis.NewGeneric(client)
+	location := toolsWrapper.Loc
+	settings := usecases.NewSettings(generic, location, sugaredLogger)
+	chatUser := usecases.NewChatUser(generic)
+	botWrapper := telegram.NewBotWrapper(botAPI, sugaredLogger, settings, chatUser)
+	telegramAPIKey := telegram.NewAPIKey(usecasesAPIKey, botWrapper)
+	return telegramAPIKey
+}
+
+func InjectSchedule(toolsWrapper ToolsWrapper) *telegram.Schedule {
+	client := toolsWrapper.RedisClient
+	schedule := redis.NewSchedule(client)
+	location := toolsWrapper.Loc
+	generic := redis.NewGeneric(client)
+	sugaredLogger := toolsWrapper.Log
+	settings := usecases.NewSettings(generic, location, sugaredLogger)
+	usecasesSchedule := usecases.NewSchedule(schedule, location, settings, sugaredLogger)
+	botAPI := toolsWrapper.Bot
+	chatUser := usecases.NewChatUser(generic)
+	botWrapper := telegram.NewBotWrapper(botAPI, sugaredLogger, settings, chatUser)
+	telegramSchedule := telegram.NewSchedule(usecasesSchedule, botWrapper)
+	return telegramSchedule
+}
+
+func InjectScheduler(toolsWrapper ToolsWrapper) *telegram.Scheduler {
+	client := toolsWrapper.RedisClient
+	schedule := redis.NewSchedule(client)
+	location := toolsWrapper.Loc
+	generic := redis.NewGeneric(client)
+	sugaredLogger := toolsWrapper.Log
+	settings := usecases.NewSettings(generic, location, sugaredLogger)
+	usecasesSchedule := usecases.NewSchedule(schedule, location, settings, sugaredLogger)
 	monoMono := mono.NewMono(sugaredLogger)
+	token := usecases.NewToken(generic, monoMono)
+	account := usecases.NewAccount(generic)
+	history := redis.NewHistory(client)
+	usecasesHistory := usecases.NewHistory(history, monoMono, sugaredLogger)
+	mapping := redis.NewMapping(client)
+	date := usecases.NewDate(location, settings)
+	transaction := usecases.NewTransaction(usecasesHistory, mapping, sugaredLogger, date)
+	botAPI := toolsWrapper.Bot
+	chatUser := usecases.NewChatUser(generic)
+	botWrapper := telegram.NewBotWrapper(botAPI, sugaredLogger, settings, chatUser)
+	scheduler := telegram.NewScheduler(usecasesSchedule, token, account, transaction, botWrapper)
+	return scheduler
+}
+
+func InjectBudget(toolsWrapper ToolsWrapper) *telegram.Budget {
 	client := toolsWrapper.RedisClient
+	budget := redis.NewBudget(client)
 	mapping := redis.NewMapping(client)
+	sugaredLogger := toolsWrapper.Log
+	usecasesBudget := usecases.NewBudget(budget, mapping, sugaredLogger)
+	generic := redis.NewGeneric(client)
+	monoMono := mono.NewMono(sugaredLogger)
+	token := usecases.NewToken(generic, monoMono)
+	account := usecases.NewAccount(generic)
+	history := redis.NewHistory(client)
+	usecasesHistory := usecases.NewHistory(history, monoMono, sugaredLogger)
 	location := toolsWrapper.Loc
-	date := usecases.NewDate(location)
-	transaction := usecases.NewTransaction(monoMono, mapping, sugaredLogger, date)
-	user := redis.NewUser(client)
-	usecasesUser := usecases.NewUser(user)
+	settings := usecases.NewSettings(generic, location, sugaredLogger)
+	date := usecases.NewDate(location, settings)
+	transaction := usecases.NewTransaction(usecasesHistory, mapping, sugaredLogger, date)
+	botAPI := toolsWrapper.Bot
+	chatUser := usecases.NewChatUser(generic)
+	botWrapper := telegram.NewBotWrapper(botAPI, sugaredLogger, settings, chatUser)
+	telegramBudget := telegram.NewBudget(usecasesBudget, token, account, transaction, botWrapper)
+	return telegramBudget
+}
+
+func InjectBudgetWatcher(toolsWrapper ToolsWrapper) *telegram.BudgetWatcher {
+	client := toolsWrapper.RedisClient
+	budget := redis.NewBudget(client)
+	mapping := redis.NewMapping(client)
+	sugaredLogger := toolsWrapper.Log
+	usecasesBudget := usecases.NewBudget(budget, mapping, sugaredLogger)
 	generic := redis.NewGeneric(client)
+	monoMono := mono.NewMono(sugaredLogger)
+	token := usecases.NewToken(generic, monoMono)
 	account := usecases.NewAccount(generic)
-	token := usecases.NewToken(generic)
-	restTransaction := rest.NewTransaction(sugaredLogger, transaction, usecasesUser, account, token)
+	history := redis.NewHistory(client)
+	usecasesHistory := usecases.NewHistory(history, monoMono, sugaredLogger)
+	location := toolsWrapper.Loc
+	settings := usecases.NewSettings(generic, location, sugaredLogger)
+	date := usecases.NewDate(location, settings)
+	transaction := usecases.NewTransaction(usecasesHistory, mapping, sugaredLogger, date)
+	botAPI := toolsWrapper.Bot
+	chatUser := usecases.NewChatUser(generic)
+	botWrapper := telegram.NewBotWrapper(botAPI, sugaredLogger, settings, chatUser)
+	budgetWatcher := telegram.NewBudgetWatcher(usecasesBudget, token, account, transaction, botWrapper)
+	return budgetWatcher
+}
+
+func InjectSubscription(toolsWrapper ToolsWrapper) *telegram.Subscription {
+	client := toolsWrapper.RedisClient
+	subscription := redis.NewSubscription(client)
+	sugaredLogger := toolsWrapper.Log
+	usecasesSubscription := usecases.NewSubscription(subscription, sugaredLogger)
+	history := redis.NewHistory(client)
+	monoMono := mono.NewMono(sugaredLogger)
+	usecasesHistory := usecases.NewHistory(history, monoMono, sugaredLogger)
+	generic := redis.NewGeneric(client)
+	location := toolsWrapper.Loc
+	settings := usecases.NewSettings(generic, location, sugaredLogger)
+	botAPI := toolsWrapper.Bot
+	chatUser := usecases.NewChatUser(generic)
+	botWrapper := telegram.NewBotWrapper(botAPI, sugaredLogger, settings, chatUser)
+	telegramSubscription := telegram.NewSubscription(usecasesSubscription, usecasesHistory, settings, botWrapper)
+	return telegramSubscription
+}
+
+func InjectSubscriptionWatcher(toolsWrapper ToolsWrapper) *telegram.SubscriptionWatcher {
+	client := toolsWrapper.RedisClient
+	subscription := redis.NewSubscription(client)
+	sugaredLogger := toolsWrapper.Log
+	usecasesSubscription := usecases.NewSubscription(subscription, sugaredLogger)
+	history := redis.NewHistory(client)
+	monoMono := mono.NewMono(sugaredLogger)
+	usecasesHistory := usecases.NewHistory(history, monoMono, sugaredLogger)
+	generic := redis.NewGeneric(client)
+	account := usecases.NewAccount(generic)
+	location := toolsWrapper.Loc
+	settings := usecases.NewSettings(generic, location, sugaredLogger)
+	botAPI := toolsWrapper.Bot
+	chatUser := usecases.NewChatUser(generic)
+	botWrapper := telegram.NewBotWrapper(botAPI, sugaredLogger, settings, chatUser)
+	subscriptionWatcher := telegram.NewSubscriptionWatcher(usecasesSubscription, usecasesHistory, account, settings, botWrapper)
+	return subscriptionWatcher
+}
+
+func InjectHistorySync(toolsWrapper ToolsWrapper) *telegram.HistorySync {
+	client := toolsWrapper.RedisClient
+	history := redis.NewHistory(client)
+	sugaredLogger := toolsWrapper.Log
+	monoMono := mono.NewMono(sugaredLogger)
+	usecasesHistory := usecases.NewHistory(history, monoMono, sugaredLogger)
+	generic := redis.NewGeneric(client)
+	token := usecases.NewToken(generic, monoMono)
+	account := usecases.NewAccount(generic)
+	historySync := telegram.NewHistorySync(usecasesHistory, token, account, sugaredLogger)
+	return historySync
+}
+
+func InjectSettings(toolsWrapper ToolsWrapper) *telegram.Settings {
+	client := toolsWrapper.RedisClient
+	generic := redis.NewGeneric(client)
+	location := toolsWrapper.Loc
+	sugaredLogger := toolsWrapper.Log
+	settings := usecases.NewSettings(generic, location, sugaredLogger)
+	botAPI := toolsWrapper.Bot
+	chatUser := usecases.NewChatUser(generic)
+	botWrapper := telegram.NewBotWrapper(botAPI, sugaredLogger, settings, chatUser)
+	telegramSettings := telegram.NewSettings(settings, botWrapper)
+	return telegramSettings
+}
+
+func InjectOffset(toolsWrapper ToolsWrapper) *usecases.Offset {
+	client := toolsWrapper.RedisClient
+	generic := redis.NewGeneric(client)
+	offset := usecases.NewOffset(generic)
+	return offset
+}
+
+func InjectTransactionRest(toolsWrapper ToolsWrapper) *rest.Transaction {
+	sugaredLogger := toolsWrapper.Log
+	client := toolsWrapper.RedisClient
+	history := redis.NewHistory(client)
+	monoMono := mono.NewMono(sugaredLogger)
+	usecasesHistory := usecases.NewHistory(history, monoMono, sugaredLogger)
+	mapping := redis.NewMapping(client)
+	location := toolsWrapper.Loc
+	generic := redis.NewGeneric(client)
+	settings := usecases.NewSettings(generic, location, sugaredLogger)
+	date := usecases.NewDate(location, settings)
+	transaction := usecases.NewTransaction(usecasesHistory, mapping, sugaredLogger, date)
+	account := usecases.NewAccount(generic)
+	token := usecases.NewToken(generic, monoMono)
+	restTransaction := rest.NewTransaction(sugaredLogger, transaction, account, token)
 	return restTransaction
 }
 
 func InjectHTTPService(tw ToolsWrapper, port int) *rest.Service {
 	sugaredLogger := tw.Log
-	monoMono := mono.NewMono(sugaredLogger)
 	client := tw.RedisClient
+	history := redis.NewHistory(client)
+	monoMono := mono.NewMono(sugaredLogger)
+	usecasesHistory := usecases.NewHistory(history, monoMono, sugaredLogger)
 	mapping := redis.NewMapping(client)
 	location := tw.Loc
-	date := usecases.NewDate(location)
-	transaction := usecases.NewTransaction(monoMono, mapping, sugaredLogger, date)
-	user := redis.NewUser(client)
-	usecasesUser := usecases.NewUser(user)
 	generic := redis.NewGeneric(client)
+	settings := usecases.NewSettings(generic, location, sugaredLogger)
+	date := usecases.NewDate(location, settings)
+	transaction := usecases.NewTransaction(usecasesHistory, mapping, sugaredLogger, date)
 	account := usecases.NewAccount(generic)
-	token := usecases.NewToken(generic)
-	restTransaction := rest.NewTransaction(sugaredLogger, transaction, usecasesUser, account, token)
-	service := rest.NewService(restTransaction, port)
+	token := usecases.NewToken(generic, monoMono)
+	restTransaction := rest.NewTransaction(sugaredLogger, transaction, account, token)
+	clientInfo := usecases.NewClientInfo(monoMono)
+	restAccount := rest.NewAccount(sugaredLogger, account, token, clientInfo)
+	restToken := rest.NewToken(sugaredLogger, token)
+	telegramTelegram := telegram2.NewTelegram()
+	usecasesMapping := usecases.NewMapping(mapping, telegramTelegram)
+	restMapping := rest.NewMapping(sugaredLogger, usecasesMapping)
+	restSettings := rest.NewSettings(sugaredLogger, settings)
+	apiKey := redis.NewAPIKey(client)
+	usecasesAPIKey := usecases.NewAPIKey(apiKey)
+	auth := rest.NewAuth(sugaredLogger, usecasesAPIKey)
+	service := rest.NewService(sugaredLogger, restTransaction, restAccount, restToken, restMapping, restSettings, auth, port)
 	return service
 }
 
@@ -156,7 +531,7 @@ func InjectHTTPService(tw ToolsWrapper, port int) *rest.Service {
 var (
 	fileReportUseCaseSet = wire.NewSet(usecases.NewFileReport, wire.Bind(new(telegram.CsvUC), new(*usecases.FileReport)))
 
-	mappingUseCaseSet = wire.NewSet(usecases.NewMapping, wire.Bind(new(telegram.MappingUC), new(*usecases.Mapping)))
+	mappingUseCaseSet = wire.NewSet(usecases.NewMapping, wire.Bind(new(telegram.MappingUC), new(*usecases.Mapping)), wire.Bind(new(rest.MappingUC), new(*usecases.Mapping)))
 
 	transactionUseCaseSet = wire.NewSet(usecases.NewTransaction, wire.Bind(new(telegram.TransactionUC), new(*usecases.Transaction)), wire.Bind(new(rest.TransactionUC), new(*usecases.Transaction)))
 
@@ -164,23 +539,45 @@ var (
 
 	chatUserUseCaseSet = wire.NewSet(usecases.NewChatUser, wire.Bind(new(telegram.ChatUserUC), new(*usecases.ChatUser)))
 
-	userUseCaseSet = wire.NewSet(usecases.NewUser, wire.Bind(new(rest.UserUC), new(*usecases.User)))
+	apiKeyUseCaseSet = wire.NewSet(usecases.NewAPIKey, wire.Bind(new(telegram.APIKeyUC), new(*usecases.APIKey)), wire.Bind(new(rest.APIKeyUC), new(*usecases.APIKey)))
+
+	scheduleUseCaseSet = wire.NewSet(usecases.NewSchedule, wire.Bind(new(telegram.ScheduleUC), new(*usecases.Schedule)))
+
+	budgetUseCaseSet = wire.NewSet(usecases.NewBudget, wire.Bind(new(telegram.BudgetUC), new(*usecases.Budget)))
+
+	historyUseCaseSet = wire.NewSet(usecases.NewHistory, wire.Bind(new(telegram.HistoryUC), new(*usecases.History)), wire.Bind(new(usecases.StatementRepo), new(*usecases.History)))
+
+	subscriptionUseCaseSet = wire.NewSet(usecases.NewSubscription, wire.Bind(new(telegram.SubscriptionUC), new(*usecases.Subscription)))
+
+	settingsUseCaseSet = wire.NewSet(usecases.NewSettings, wire.Bind(new(telegram.SettingsUC), new(*usecases.Settings)), wire.Bind(new(telegram.LanguageUC), new(*usecases.Settings)), wire.Bind(new(rest.SettingsUC), new(*usecases.Settings)), wire.Bind(new(usecases.UserSettings), new(*usecases.Settings)))
+
+	inlineUseCaseSet = wire.NewSet(usecases.NewInline, wire.Bind(new(telegram.InlineUC), new(*usecases.Inline)))
 
 	tokenUseCaseSet = wire.NewSet(usecases.NewToken, wire.Bind(new(telegram.TokenUC), new(*usecases.Token)), wire.Bind(new(rest.TokenUC), new(*usecases.Token)))
 
-	clientInfoUseCaseSet = wire.NewSet(usecases.NewClientInfo, wire.Bind(new(telegram.ClientInfoUC), new(*usecases.ClientInfo)))
+	clientInfoUseCaseSet = wire.NewSet(usecases.NewClientInfo, wire.Bind(new(telegram.ClientInfoUC), new(*usecases.ClientInfo)), wire.Bind(new(rest.ClientInfoUC), new(*usecases.ClientInfo)))
+
+	dialogUseCaseSet = wire.NewSet(usecases.NewDialog, wire.Bind(new(telegram.DialogUC), new(*usecases.Dialog)))
 
 	mappingRepo = wire.NewSet(redis.NewMapping, wire.Bind(new(usecases.MappingRepo), new(*redis.Mapping)))
 
-	genericRepo = wire.NewSet(redis.NewGeneric, wire.Bind(new(usecases.TokenRepo), new(*redis.Generic)), wire.Bind(new(usecases.AccountRepo), new(*redis.Generic)), wire.Bind(new(usecases.ChatUserRepo), new(*redis.Generic)))
+	genericRepo = wire.NewSet(redis.NewGeneric, wire.Bind(new(usecases.TokenRepo), new(*redis.Generic)), wire.Bind(new(usecases.AccountRepo), new(*redis.Generic)), wire.Bind(new(usecases.ChatUserRepo), new(*redis.Generic)), wire.Bind(new(usecases.OffsetRepo), new(*redis.Generic)), wire.Bind(new(usecases.SettingsRepo), new(*redis.Generic)))
 
 	telegramRepo = wire.NewSet(telegram2.NewTelegram, wire.Bind(new(usecases.TelegramRepo), new(*telegram2.Telegram)))
 
-	userRepo = wire.NewSet(redis.NewUser, wire.Bind(new(usecases.UserRepo), new(*redis.User)))
+	apiKeyRepo = wire.NewSet(redis.NewAPIKey, wire.Bind(new(usecases.APIKeyRepo), new(*redis.APIKey)))
+
+	scheduleRepo = wire.NewSet(redis.NewSchedule, wire.Bind(new(usecases.ScheduleRepo), new(*redis.Schedule)))
+
+	budgetRepo = wire.NewSet(redis.NewBudget, wire.Bind(new(usecases.BudgetRepo), new(*redis.Budget)))
+
+	historyRepo = wire.NewSet(redis.NewHistory, wire.Bind(new(usecases.TransactionRepo), new(*redis.History)))
+
+	subscriptionRepo = wire.NewSet(redis.NewSubscription, wire.Bind(new(usecases.SubscriptionRepo), new(*redis.Subscription)))
 
-	monoRepo = wire.NewSet(mono.NewMono, wire.Bind(new(usecases.MonoRepo), new(*mono.Mono)))
+	dialogRepo = wire.NewSet(redis.NewDialog, wire.Bind(new(usecases.DialogRepo), new(*redis.Dialog)))
 
-	clientInfoRepo = wire.NewSet(mono.NewMono, wire.Bind(new(usecases.ClientInfoRepo), new(*mono.Mono)))
+	monoRepo = wire.NewSet(mono.NewMono, wire.Bind(new(usecases.MonoRepo), new(*mono.Mono)), wire.Bind(new(usecases.ClientInfoRepo), new(*mono.Mono)))
 
 	apiLoggerBind      = wire.Bind(new(telegram.Logger), new(*zap.SugaredLogger))
 	apiRestLoggerBind  = wire.Bind(new(rest.Logger), new(*zap.SugaredLogger))
@@ -1,8 +1,9 @@
 module github.com/Kalachevskyi/mono-chat
 
-go 1.12
+go 1.18
 
 require (
+	github.com/extrame/xls v0.0.1
 	github.com/go-redis/redis v6.15.2+incompatible
 	github.com/go-telegram-bot-api/telegram-bot-api v4.6.4+incompatible
 	github.com/golang/mock v1.3.1
@@ -10,24 +11,27 @@ require (
 	github.com/google/wire v0.5.0
 	github.com/gorilla/mux v1.8.0
 	github.com/jinzhu/now v1.0.1
+	github.com/onsi/gomega v1.5.0
+	github.com/pkg/errors v0.8.1
+	github.com/urfave/cli v1.21.0
+	go.uber.org/zap v1.9.1
+	golang.org/x/image v0.18.0
+	golang.org/x/text v0.16.0
+)
+
+require (
+	github.com/extrame/ole2 v0.0.0-20160812065207-d69429661ad7 // indirect
 	github.com/kyoh86/richgo v0.3.3 // indirect
 	github.com/kyoh86/xdg v1.0.0 // indirect
 	github.com/mattn/go-isatty v0.0.9 // indirect
 	github.com/onsi/ginkgo v1.8.0 // indirect
-	github.com/onsi/gomega v1.5.0
-	github.com/pkg/errors v0.8.1
 	github.com/stretchr/objx v0.2.0 // indirect
 	github.com/stretchr/testify v1.4.0 // indirect
 	github.com/technoweenie/multipartstreamer v1.0.1 // indirect
-	github.com/urfave/cli v1.21.0
 	github.com/wacul/ptr v1.0.0 // indirect
 	go.uber.org/atomic v1.3.2 // indirect
 	go.uber.org/multierr v1.1.0 // indirect
-	go.uber.org/zap v1.9.1
-	golang.org/x/crypto v0.0.0-20190829043050-9756ffdc2472 // indirect
-	golang.org/x/net v0.0.0-20190827160401-ba9fcec4b297 // indirect
-	golang.org/x/sys v0.0.0-20190903213830-1f305c863dab // indirect
-	golang.org/x/text v0.3.2 // indirect
-	golang.org/x/tools v0.0.0-20190903163617-be0da057c5e3 // indirect
+	golang.org/x/net v0.25.0 // indirect
 	gopkg.in/check.v1 v1.0.0-20190902080502-41f04d3bba15 // indirect
+	gopkg.in/yaml.v2 v2.2.2 // indirect
 )